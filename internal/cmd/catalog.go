@@ -13,8 +13,11 @@ func newCatalogCmd(cfg *action.Configuration) *cobra.Command {
 	}
 	cmd.AddCommand(
 		newCatalogAddCmd(cfg),
+		newCatalogDiffCmd(cfg),
+		newCatalogInspectCmd(),
 		newCatalogListCmd(cfg),
 		newCatalogRemoveCmd(cfg),
+		newCatalogUpdateCmd(cfg),
 	)
 	return cmd
 }
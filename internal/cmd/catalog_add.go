@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"time"
 
@@ -24,21 +25,20 @@ func newCatalogAddCmd(cfg *action.Configuration) *cobra.Command {
 		Short: "Add an operator catalog",
 		Args:  cobra.ExactArgs(2),
 		PreRun: func(cmd *cobra.Command, args []string) {
-			regLogger := logrus.New()
-			regLogger.SetOutput(io.Discard)
-			a.RegistryOptions = []containerdregistry.RegistryOption{
-				containerdregistry.WithLog(logrus.NewEntry(regLogger)),
-			}
+			a.RegistryOptions = discardRegistryLog()
 		},
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			a.CatalogSourceName = args[0]
+			log.SetObject("CatalogSource", a.CatalogSourceName)
 			a.IndexImage = args[1]
 
 			cs, err := a.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to add catalog: %v", err)
+				return fmt.Errorf("failed to add catalog: %w", err)
 			}
 			log.Printf("created catalogsource %q\n", cs.Name)
+			log.Done(cs)
+			return nil
 		},
 	}
 	bindCatalogAddFlags(cmd.Flags(), a)
@@ -50,4 +50,23 @@ func bindCatalogAddFlags(fs *pflag.FlagSet, a *internalaction.CatalogAdd) {
 	fs.StringVarP(&a.DisplayName, "display-name", "d", "", "display name of the index")
 	fs.StringVarP(&a.Publisher, "publisher", "p", "", "publisher of the index")
 	fs.DurationVar(&a.CleanupTimeout, "cleanup-timeout", time.Minute, "the amount of time to wait before cancelling cleanup")
+	bindImageRegistryFlags(fs, &a.ImageRegistry)
+	bindCatalogSourceConfigFlags(fs, &a.Config)
+}
+
+func bindImageRegistryFlags(fs *pflag.FlagSet, r *internalaction.ImageRegistry) {
+	fs.StringVar(&r.RegistryConfig, "registry-config", "", "path to a docker config.json or podman auth.json file with registry credentials (defaults to the docker and podman locations)")
+	fs.StringVar(&r.RegistriesConf, "registries-conf", "", "path to a registries.conf file with mirror rules (defaults to the system registries.conf)")
+	fs.StringVar(&r.CAFile, "ca-file", "", "path to a PEM bundle of additional CAs to trust when pulling the index image")
+	fs.BoolVar(&r.SkipTLSVerify, "skip-tls-verify", false, "skip TLS certificate verification when pulling the index image")
+	fs.BoolVar(&r.PlainHTTP, "plain-http", false, "use plain HTTP when pulling the index image")
+}
+
+// discardRegistryLog returns registry options that silence the registry's own logging.
+func discardRegistryLog() []containerdregistry.RegistryOption {
+	regLogger := logrus.New()
+	regLogger.SetOutput(io.Discard)
+	return []containerdregistry.RegistryOption{
+		containerdregistry.WithLog(logrus.NewEntry(regLogger)),
+	}
 }
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/olmv1"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newCatalogDiffCmd(cfg *action.Configuration) *cobra.Command {
+	var output string
+	d := internalaction.NewCatalogDiff(cfg)
+	d.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "Show what changed between two versions of a catalog",
+		Long: `Show what changed between two versions of a catalog.
+
+Each version can be a directory containing a file-based catalog, the name of a
+serving ClusterCatalog, or an index image reference. The diff lists packages
+that were added or removed, default channel changes, channels whose head
+moved, and bundles that were added, removed or deprecated.
+
+Installs in the cluster that are affected by the changes are flagged: the
+ClusterExtensions whose catalog selector matches a diffed ClusterCatalog and,
+with --check-installs, the Subscriptions to CatalogSources and the
+ClusterExtensions selecting ClusterCatalogs that serve a diffed index image.
+
+The installs from index images are only flagged with --check-installs, since
+an image is often diffed before it is served by any cluster, or without access
+to one. Without it, directories and index images are diffed without a
+kubeconfig or querying the cluster.`,
+		Args:        cobra.ExactArgs(2),
+		Annotations: map[string]string{offlineAnnotation: ""},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			d.RegistryOptions = discardRegistryLog()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d.Old, d.New = args[0], args[1]
+			if d.NeedsCluster() {
+				if err := loadConfig(cfg); err != nil {
+					return err
+				}
+			}
+			d.Catalogd = olmv1.CatalogdClient(cfg)
+			result, err := d.Run(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to diff catalogs: %w", err)
+			}
+			switch output {
+			case "":
+				writeCatalogDiff(os.Stdout, result)
+			case "json":
+				data, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintln(os.Stdout, string(data))
+			case "yaml":
+				data, err := yaml.Marshal(result)
+				if err != nil {
+					return err
+				}
+				_, _ = os.Stdout.Write(data)
+			default:
+				return exitcode.Validation(fmt.Errorf("unsupported output format %q: allowed formats are (json|yaml)", output))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: (json, yaml)")
+	cmd.Flags().StringVar(&d.CatalogdNamespace, "catalogd-namespace", "olmv1-system", "namespace for the catalogd controller")
+	cmd.Flags().BoolVar(&d.CheckInstalls, "check-installs", false, "flag the installs in the cluster from the catalogs serving the diffed index images")
+	bindImageRegistryFlags(cmd.Flags(), &d.ImageRegistry)
+	return cmd
+}
+
+func writeCatalogDiff(w io.Writer, result *internalaction.CatalogDiffResult) {
+	if len(result.AddedPackages) == 0 && len(result.RemovedPackages) == 0 && len(result.Packages) == 0 {
+		_, _ = fmt.Fprintln(w, "No changes found")
+		return
+	}
+	writeList := func(indent, title string, items []string) {
+		if len(items) > 0 {
+			_, _ = fmt.Fprintf(w, "%s%s: %s\n", indent, title, strings.Join(items, ", "))
+		}
+	}
+
+	writeList("", "packages added", result.AddedPackages)
+	writeList("", "packages removed", result.RemovedPackages)
+	for _, pd := range result.Packages {
+		_, _ = fmt.Fprintf(w, "package %s\n", pd.Package)
+		if pd.DefaultChannelChanged() {
+			_, _ = fmt.Fprintf(w, "  default channel: %s -> %s\n", valueOrNone(pd.OldDefaultChannel), valueOrNone(pd.NewDefaultChannel))
+		}
+		writeList("  ", "channels added", pd.AddedChannels)
+		writeList("  ", "channels removed", pd.RemovedChannels)
+		for _, h := range pd.MovedHeads {
+			_, _ = fmt.Fprintf(w, "  channel %s head: %s -> %s\n", h.Channel, valueOrNone(h.OldHead), valueOrNone(h.NewHead))
+		}
+		writeList("  ", "bundles added", pd.AddedBundles)
+		writeList("  ", "bundles removed", pd.RemovedBundles)
+		writeList("  ", "bundles deprecated", pd.DeprecatedBundles)
+	}
+
+	if len(result.Affected) > 0 {
+		_, _ = fmt.Fprintln(w, "\naffected installs:")
+		for _, a := range result.Affected {
+			name := a.Name
+			if a.Namespace != "" {
+				name = a.Namespace + "/" + a.Name
+			}
+			_, _ = fmt.Fprintf(w, "  ! %s %s (%s): %s\n", a.Kind, name, a.Package, strings.Join(a.Reasons, "; "))
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/olmv1"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+)
+
+func newCatalogInspectCmd() *cobra.Command {
+	var (
+		output       string
+		listVersions bool
+	)
+	i := internalaction.NewCatalogInspect()
+	i.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "inspect <index_image>",
+		Short: "List the contents of an index image",
+		Long: `List the packages, channels, versions and providers of a file-based catalog
+index image, without adding it to a cluster.
+
+The image is pulled locally using the same registry settings as "catalog add",
+and the catalog is read from the directory named by the image's
+operators.operatorframework.io.index.configs.v1 label.`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{offlineAnnotation: ""},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			i.RegistryOptions = discardRegistryLog()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			i.IndexImage = args[0]
+			dcfg, err := i.Run(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to inspect index image: %w", err)
+			}
+			contents := map[string]*declcfg.DeclarativeConfig{i.IndexImage: dcfg}
+			if err := olmv1.PrintDeclCfg(os.Stdout, contents, output, listVersions); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&i.Package, "package", "", "only list the contents of the named package")
+	cmd.Flags().BoolVar(&listVersions, "list-versions", false, "list all versions available for each package")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: (json, yaml)")
+	bindImageRegistryFlags(cmd.Flags(), &i.ImageRegistry)
+	return cmd
+}
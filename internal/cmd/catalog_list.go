@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"text/tabwriter"
@@ -16,18 +17,29 @@ import (
 )
 
 func newCatalogListCmd(cfg *action.Configuration) *cobra.Command {
-	var allNamespaces bool
+	var (
+		allNamespaces bool
+		health        bool
+	)
 	l := internalaction.NewCatalogList(cfg)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List installed operator catalogs",
-		Run: func(cmd *cobra.Command, args []string) {
+		Long: `List installed operator catalogs.
+
+Use --health to show the state of each catalog's registry server, the time it
+was last updated, the digest of the image it serves and the number of packages
+it provides. Unhealthy catalogs are listed first.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if allNamespaces {
 				cfg.Namespace = corev1.NamespaceAll
 			}
+			if health {
+				return listCatalogHealth(cmd.Context(), cfg, allNamespaces)
+			}
 			catalogs, err := l.Run(cmd.Context())
 			if err != nil {
-				log.Fatal(err)
+				return err
 			}
 
 			if len(catalogs) == 0 {
@@ -36,7 +48,7 @@ func newCatalogListCmd(cfg *action.Configuration) *cobra.Command {
 				} else {
 					log.Printf("No resources found in %s namespace.", cfg.Namespace)
 				}
-				return
+				return nil
 			}
 
 			nsCol := ""
@@ -54,8 +66,65 @@ func newCatalogListCmd(cfg *action.Configuration) *cobra.Command {
 				_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\n", cs.Name, ns, cs.Spec.DisplayName, cs.Spec.SourceType, cs.Spec.Publisher, duration.HumanDuration(age))
 			}
 			_ = tw.Flush()
+			return nil
 		},
 	}
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list catalogs in all namespaces")
+	cmd.Flags().BoolVar(&health, "health", false, "show the health of each catalog")
 	return cmd
 }
+
+func listCatalogHealth(ctx context.Context, cfg *action.Configuration, allNamespaces bool) error {
+	health, err := internalaction.NewCatalogListHealth(cfg).Run(ctx)
+	if err != nil {
+		return fmt.Errorf("list catalogs: %w", err)
+	}
+
+	if len(health) == 0 {
+		if cfg.Namespace == corev1.NamespaceAll {
+			log.Print("No resources found")
+		} else {
+			log.Printf("No resources found in %s namespace.", cfg.Namespace)
+		}
+		return nil
+	}
+
+	nsCol := ""
+	if allNamespaces {
+		nsCol = "\tNAMESPACE"
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "NAME%s\tHEALTH\tCONNECTION STATE\tADDRESS\tLAST UPDATED\tPACKAGES\tDIGEST\n", nsCol)
+	for _, h := range health {
+		ns := ""
+		if allNamespaces {
+			ns = "\t" + h.CatalogSource.Namespace
+		}
+		status := "Healthy"
+		if !h.Healthy() {
+			status = "UNHEALTHY"
+		}
+		lastUpdated := "<unknown>"
+		if h.LastUpdated != nil {
+			lastUpdated = duration.HumanDuration(time.Since(h.LastUpdated.Time)) + " ago"
+		}
+		_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			h.CatalogSource.Name, ns,
+			status,
+			valueOrNone(h.ConnectionState),
+			valueOrNone(h.Address),
+			lastUpdated,
+			h.Packages,
+			valueOrNone(h.ImageDigest),
+		)
+	}
+	_ = tw.Flush()
+	return nil
+}
+
+func valueOrNone(v string) string {
+	if v == "" {
+		return "<none>"
+	}
+	return v
+}
@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
 	"github.com/spf13/cobra"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
@@ -10,19 +17,55 @@ import (
 
 func newCatalogRemoveCmd(cfg *action.Configuration) *cobra.Command {
 	u := internalaction.NewCatalogRemove(cfg)
+	u.Logf = log.Printf
+
 	cmd := &cobra.Command{
 		Use:   "remove <catalog_name>",
 		Short: "Remove an operator catalog",
-		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		Long: `Remove an operator catalog.
+
+A catalog that is still used by subscriptions is not removed, because the
+operators installed from it would no longer receive upgrades. Use
+--migrate-to to point those subscriptions at a replacement catalog that
+provides the same packages and channels, or --force to remove the catalog
+anyway. The replacement must be in the namespace of each subscription or in
+the global catalog namespace. If some subscriptions cannot be updated, the
+catalog is kept and the subscriptions that were and were not migrated are
+reported.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			u.CatalogName = args[0]
+			log.SetObject("CatalogSource", u.CatalogName)
 
-			if err := u.Run(cmd.Context()); err != nil {
-				log.Fatalf("failed to remove catalog %q: %v", u.CatalogName, err)
+			err := u.Run(cmd.Context())
+			var inUse *internalaction.ErrCatalogInUse
+			if errors.As(err, &inUse) {
+				writeDependentSubscriptions(os.Stderr, inUse)
+				return fmt.Errorf("failed to remove catalog %q: %w; use --migrate-to or --force", u.CatalogName, err)
+			}
+			var incomplete *internalaction.ErrMigrationIncomplete
+			if errors.As(err, &incomplete) {
+				return exitcode.Partial(fmt.Errorf("failed to remove catalog %q: %w", u.CatalogName, err))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to remove catalog %q: %w", u.CatalogName, err)
 			}
 			log.Printf("catalogsource %q removed", u.CatalogName)
+			log.Done(nil)
+			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&u.Force, "force", false, "remove the catalog even if subscriptions use it")
+	cmd.Flags().Var(&u.MigrateTo, "migrate-to", "catalog to migrate the catalog's subscriptions to, in the form [namespace/]name")
 
 	return cmd
 }
+
+func writeDependentSubscriptions(w io.Writer, e *internalaction.ErrCatalogInUse) {
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "NAMESPACE\tSUBSCRIPTION\tPACKAGE\tCHANNEL\n")
+	for _, sub := range e.Subscriptions {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", sub.Namespace, sub.Name, sub.Spec.Package, sub.Spec.Channel)
+	}
+	_ = tw.Flush()
+}
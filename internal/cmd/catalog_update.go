@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/catalogsource"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newCatalogUpdateCmd(cfg *action.Configuration) *cobra.Command {
+	u := internalaction.NewCatalogUpdate(cfg)
+	u.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "update <catalog_name>",
+		Short: "Update the settings of an operator catalog",
+		Long: `Update the settings of an operator catalog.
+
+Only the provided settings are changed. Node selector labels replace existing
+keys and tolerations are added if not already present.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			u.CatalogName = args[0]
+			log.SetObject("CatalogSource", u.CatalogName)
+			cs, err := u.Run(cmd.Context())
+			if errors.Is(err, internalaction.ErrNoCatalogChange) {
+				log.Printf("catalogsource %q unchanged", cs.Name)
+				log.Done(cs)
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to update catalog: %w", err)
+			}
+			log.Printf("catalogsource %q updated", cs.Name)
+			log.Done(cs)
+			return nil
+		},
+	}
+	bindCatalogSourceConfigFlags(cmd.Flags(), &u.Config)
+	return cmd
+}
+
+func bindCatalogSourceConfigFlags(fs *pflag.FlagSet, o *catalogsource.ConfigOptions) {
+	fs.DurationVar(&o.PollInterval, "poll-interval", 0, "interval at which to poll the index image for updates (e.g. 10m)")
+	fs.Var(&intPtrValue{&o.Priority}, "priority", "priority of the catalog for dependency resolution")
+	fs.StringToStringVar(&o.NodeSelector, "node-selector", nil, "node selector labels for the catalog's pod")
+	fs.StringArrayVar(&o.Tolerations, "toleration", nil, "toleration for the catalog's pod, in the form key[=value][:effect[:seconds]] (can be repeated)")
+	fs.StringVar(&o.PriorityClassName, "priority-class-name", "", "priority class of the catalog's pod")
+	fs.StringVar(&o.SecurityContextConfig, "security-context-config", "", "security context of the catalog's pod, one of legacy|restricted")
+	fs.StringVar(&o.MemoryTarget, "memory-target", "", "soft memory limit of the catalog's gRPC server (e.g. 128Mi)")
+	fs.BoolVar(&o.ExtractContent, "extract-content", false, "serve the index image's file-based catalog with OLM's opm server")
+	fs.StringVar(&o.ExtractContentCatalogDir, "extract-content-catalog-dir", catalogsource.DefaultExtractContentCatalogDir, "directory of the file-based catalog in the index image, used with --extract-content")
+	fs.StringVar(&o.ExtractContentCacheDir, "extract-content-cache-dir", catalogsource.DefaultExtractContentCacheDir, "directory of the catalog cache in the index image, used with --extract-content")
+}
+
+// intPtrValue is an int flag that is left nil unless it is set.
+type intPtrValue struct {
+	p **int
+}
+
+func (v *intPtrValue) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*v.p = &i
+	return nil
+}
+
+func (v *intPtrValue) String() string {
+	if v.p == nil || *v.p == nil {
+		return ""
+	}
+	return strconv.Itoa(**v.p)
+}
+
+func (v *intPtrValue) Type() string {
+	return "int"
+}
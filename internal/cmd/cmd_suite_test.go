@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var update = flag.Bool("update", false, "update the golden files with the output of the commands")
+
+// testdata is the absolute path of the testdata directory, since the tests run in a temporary
+// directory so that pulled images are cached outside of the source tree.
+var testdata string
+
+var wd, tmpDir string
+
+func TestCommand(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Command Suite")
+}
+
+var _ = BeforeSuite(func() {
+	ctrllog.SetLogger(logr.Discard())
+
+	var err error
+	testdata, err = filepath.Abs("testdata")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(os.Setenv("KUBECONFIG", filepath.Join(testdata, "kubeconfig"))).To(Succeed())
+
+	wd, err = os.Getwd()
+	Expect(err).ToNot(HaveOccurred())
+	tmpDir, err = os.MkdirTemp("", "kubectl-operator-test-")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(os.Chdir(tmpDir)).To(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	Expect(os.Chdir(wd)).To(Succeed())
+	Expect(os.RemoveAll(tmpDir)).To(Succeed())
+})
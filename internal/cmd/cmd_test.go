@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/complete"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/olmv1"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/fakecluster"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1/catalogd"
+)
+
+// cluster is the fake cluster the commands of the running spec are executed against.
+var cluster *fakecluster.Cluster
+
+var _ = BeforeEach(func() {
+	var err error
+	cluster, err = fakecluster.New([]fakecluster.Catalog{
+		{
+			Name:        "operatorhubio",
+			DisplayName: "Community Operators",
+			Publisher:   "OperatorHub.io",
+			FS:          os.DirFS(filepath.Join(testdata, "catalogs", "operatorhubio")),
+		},
+		{
+			Name:        "operatorhubio-next",
+			DisplayName: "Community Operators (next)",
+			Publisher:   "OperatorHub.io",
+			FS:          os.DirFS(filepath.Join(testdata, "catalogs", "operatorhubio-next")),
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	load := func(cfg *action.Configuration) error {
+		if err := cfg.Load(); err != nil {
+			return err
+		}
+		cluster.Configure(cfg)
+		return nil
+	}
+	loadConfig, complete.LoadConfig = load, load
+	olmv1.CatalogdClient = func(*action.Configuration) catalogd.Client { return cluster.CatalogdClient() }
+})
+
+var _ = AfterEach(func() {
+	cluster.Close()
+	loadConfig, complete.LoadConfig = (*action.Configuration).Load, (*action.Configuration).Load
+	olmv1.CatalogdClient = func(*action.Configuration) catalogd.Client { return nil }
+})
+
+// result is the output of a command.
+type result struct {
+	args   []string
+	stdout string
+	stderr string
+	code   int
+}
+
+func (r result) String() string {
+	return fmt.Sprintf("$ kubectl operator %s\n--- stdout\n%s--- stderr\n%s--- exit code %d\n",
+		strings.Join(r.args, " "), r.stdout, r.stderr, r.code)
+}
+
+// run executes the plugin with args against the cluster, and returns its normalized output.
+// Occurrences of $REGISTRY and $TMPDIR in args are replaced with the registry host and the
+// working directory of the tests.
+func run(args ...string) result {
+	expanded := make([]string, 0, len(args))
+	for _, arg := range args {
+		arg = strings.ReplaceAll(arg, "$REGISTRY", cluster.RegistryHost())
+		arg = strings.ReplaceAll(arg, "$TMPDIR", tmpDir)
+		expanded = append(expanded, arg)
+	}
+
+	stdout, stderr := tempFile(), tempFile()
+	stdin, err := os.Open(os.DevNull)
+	Expect(err).ToNot(HaveOccurred())
+	realStdin, realStdout, realStderr := os.Stdin, os.Stdout, os.Stderr
+	os.Stdin, os.Stdout, os.Stderr = stdin, stdout, stderr
+	log.SetOutput(stdout, stderr)
+	defer func() {
+		os.Stdin, os.Stdout, os.Stderr = realStdin, realStdout, realStderr
+		log.SetOutput(realStdout, realStderr)
+		_ = stdin.Close()
+	}()
+
+	cmd := newCmd()
+	cmd.SetArgs(expanded)
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	code := execute(context.Background(), cmd)
+
+	return result{
+		args:   args,
+		stdout: normalize(readFile(stdout)),
+		stderr: normalize(readFile(stderr)),
+		code:   int(code),
+	}
+}
+
+// mustRun runs a command that prepares the cluster for a spec, which must succeed.
+func mustRun(args ...string) {
+	r := run(args...)
+	ExpectWithOffset(1, r.code).To(BeZero(), r.String())
+}
+
+// expectGolden runs a command and compares its output with testdata/golden/<name>.golden, which
+// is rewritten instead if the tests run with -update.
+func expectGolden(name string, args ...string) {
+	out := run(args...).String()
+	path := filepath.Join(testdata, "golden", name+".golden")
+	if *update {
+		ExpectWithOffset(1, os.WriteFile(path, []byte(out), 0600)).To(Succeed())
+		return
+	}
+	golden, err := os.ReadFile(path)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, out).To(Equal(string(golden)))
+}
+
+var timestamp = regexp.MustCompile(`\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(\.\d+)?Z`)
+
+// normalize replaces the parts of an output that change from one run to the next.
+func normalize(s string) string {
+	s = strings.ReplaceAll(s, cluster.CatalogdURL(), "$CATALOGD")
+	s = strings.ReplaceAll(s, cluster.RegistryHost(), "$REGISTRY")
+	s = strings.ReplaceAll(s, tmpDir, "$TMPDIR")
+	s = strings.ReplaceAll(s, runtime.Version(), "$GOVERSION")
+	s = strings.ReplaceAll(s, runtime.GOOS+"/"+runtime.GOARCH, "$PLATFORM")
+	return timestamp.ReplaceAllString(s, "$$TIME")
+}
+
+func tempFile() *os.File {
+	f, err := os.CreateTemp("", "kubectl-operator-output-")
+	ExpectWithOffset(2, err).ToNot(HaveOccurred())
+	return f
+}
+
+func readFile(f *os.File) string {
+	defer os.Remove(f.Name())
+	defer f.Close()
+	data, err := os.ReadFile(f.Name())
+	ExpectWithOffset(2, err).ToNot(HaveOccurred())
+	return string(data)
+}
+
+var _ = Describe("version", func() {
+	It("prints the version", func() {
+		expectGolden("version", "version")
+	})
+})
+
+var _ = Describe("catalog", func() {
+	It("lists catalogs", func() {
+		expectGolden("catalog-list", "catalog", "list", "-A")
+	})
+	It("lists catalogs with their health", func() {
+		expectGolden("catalog-list-health", "catalog", "list", "-n", fakecluster.GlobalNamespace, "--health")
+	})
+	It("adds a catalog", func() {
+		expectGolden("catalog-add", "catalog", "add", "next", "$REGISTRY/catalogs/operatorhubio-next:latest", "--plain-http")
+	})
+	It("fails to add a catalog whose image does not exist", func() {
+		expectGolden("catalog-add-missing-image", "catalog", "add", "missing", "$REGISTRY/catalogs/missing:latest", "--plain-http")
+	})
+	It("inspects an index image", func() {
+		expectGolden("catalog-inspect", "catalog", "inspect", "$REGISTRY/catalogs/operatorhubio:latest", "--plain-http")
+	})
+	It("inspects a package in an index image", func() {
+		expectGolden("catalog-inspect-package", "catalog", "inspect", "$REGISTRY/catalogs/operatorhubio:latest", "--plain-http", "--package", "etcd", "--list-versions", "-o", "yaml")
+	})
+	It("diffs two index images", func() {
+		expectGolden("catalog-diff", "catalog", "diff", "$REGISTRY/catalogs/operatorhubio:latest", "$REGISTRY/catalogs/operatorhubio-next:latest", "--plain-http")
+	})
+	It("flags the subscriptions affected by a diff of index images", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("catalog-diff-check-installs", "catalog", "diff", "$REGISTRY/catalogs/operatorhubio:latest", "$REGISTRY/catalogs/operatorhubio-next:latest", "--plain-http", "--check-installs")
+	})
+	It("diffs two cluster catalogs", func() {
+		expectGolden("catalog-diff-clustercatalogs", "catalog", "diff", "operatorhubio", "operatorhubio-next", "-o", "json")
+	})
+	It("updates a catalog", func() {
+		expectGolden("catalog-update", "catalog", "update", "operatorhubio", "-n", fakecluster.GlobalNamespace, "--poll-interval", "10m", "--priority", "5")
+	})
+	It("removes a catalog", func() {
+		expectGolden("catalog-remove", "catalog", "remove", "operatorhubio", "-n", fakecluster.GlobalNamespace)
+	})
+	It("refuses to remove a catalog that subscriptions use", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("catalog-remove-in-use", "catalog", "remove", "operatorhubio", "-n", fakecluster.GlobalNamespace)
+	})
+})
+
+var _ = Describe("operator", func() {
+	It("lists available operators", func() {
+		expectGolden("list-available", "list-available")
+	})
+	It("lists the available operators of a catalog", func() {
+		expectGolden("list-available-catalog", "list-available", "-c", fakecluster.GlobalNamespace+"/operatorhubio-next")
+	})
+	It("describes an operator", func() {
+		expectGolden("describe", "describe", "etcd", "-c", fakecluster.GlobalNamespace+"/operatorhubio", "-L")
+	})
+	It("installs an operator", func() {
+		expectGolden("install", "install", "etcd", "-C", "-a", "Automatic")
+	})
+	It("installs an operator that needs approval", func() {
+		expectGolden("install-manual", "install", "etcd", "-C", "-v", "0.9.2", "-c", "stable")
+	})
+	It("fails to install an operator whose install plan needs approval", func() {
+		mustRun("install", "etcd", "-C")
+		expectGolden("install-requires-approval", "install", "prometheus", "-C", "-a", "Automatic")
+	})
+	It("fails to install an unknown operator", func() {
+		expectGolden("install-unknown", "install", "unknown", "-C")
+	})
+	It("rejects invalid flags", func() {
+		expectGolden("install-invalid-flag", "install", "etcd", "--approval", "Sometimes")
+	})
+	It("lists installed operators", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("list", "list")
+	})
+	It("lists installed operators with their health", func() {
+		mustRun("install", "etcd", "-C", "--version", "0.9.2")
+		expectGolden("list-health", "list", "-A", "--health")
+	})
+	It("approves pending install plans", func() {
+		mustRun("install", "etcd", "-C", "--version", "0.9.2")
+		expectGolden("approve", "approve", "--all", "-y")
+	})
+	It("upgrades an operator", func() {
+		mustRun("install", "etcd", "-C", "--version", "0.9.2")
+		expectGolden("upgrade", "upgrade", "etcd")
+	})
+	It("fails to upgrade an operator at the latest version", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("upgrade-latest", "upgrade", "etcd")
+	})
+	It("configures an operator", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("configure", "configure", "etcd", "--env", "LOG_LEVEL=debug", "--node-selector", "kubernetes.io/os=linux")
+	})
+	It("lists the operands of an operator", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("list-operands", "list-operands", "etcd")
+	})
+	It("prints the plan to uninstall an operator", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("uninstall-dry-run", "uninstall", "etcd", "--dry-run", "-X")
+	})
+	It("uninstalls an operator", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("uninstall", "uninstall", "etcd", "-X", "-y", "--backup-dir", "$TMPDIR/backup")
+	})
+	It("refuses to uninstall without --yes on a non-interactive terminal", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		expectGolden("uninstall-non-interactive", "uninstall", "etcd")
+	})
+	It("fails to uninstall an operator that is not installed", func() {
+		expectGolden("uninstall-unknown", "uninstall", "nope", "-y")
+	})
+	It("restores an uninstalled operator", func() {
+		mustRun("install", "etcd", "-C", "-a", "Automatic")
+		mustRun("uninstall", "etcd", "-y", "--backup-dir", "$TMPDIR/restore")
+		expectGolden("restore", "restore", "$TMPDIR/restore")
+	})
+	It("reports results as JSON", func() {
+		expectGolden("install-json", "install", "etcd", "-C", "-a", "Automatic", "--log-format", "json")
+	})
+	It("completes package names", func() {
+		expectGolden("complete-install", "__complete", "install", "e")
+	})
+})
+
+var _ = Describe("olmv1", func() {
+	It("gets catalogs", func() {
+		expectGolden("olmv1-get-catalog", "olmv1", "get", "catalog")
+	})
+	It("gets a catalog", func() {
+		expectGolden("olmv1-get-catalog-yaml", "olmv1", "get", "catalog", "operatorhubio", "-o", "yaml")
+	})
+	It("creates a catalog", func() {
+		expectGolden("olmv1-create-catalog", "olmv1", "create", "catalog", "next", "$REGISTRY/catalogs/operatorhubio-next:latest", "--priority", "10")
+	})
+	It("prints a catalog without creating it", func() {
+		expectGolden("olmv1-create-catalog-dry-run", "olmv1", "create", "catalog", "next", "$REGISTRY/catalogs/operatorhubio-next:latest", "--dry-run", "All", "-o", "yaml")
+	})
+	It("fails to create a catalog that exists", func() {
+		expectGolden("olmv1-create-catalog-exists", "olmv1", "create", "catalog", "operatorhubio", "$REGISTRY/catalogs/operatorhubio:latest")
+	})
+	It("updates a catalog", func() {
+		expectGolden("olmv1-update-catalog", "olmv1", "update", "catalog", "operatorhubio", "--available", "false")
+	})
+	It("deletes a catalog", func() {
+		expectGolden("olmv1-delete-catalog", "olmv1", "delete", "catalog", "operatorhubio-next")
+	})
+	It("searches catalogs", func() {
+		expectGolden("olmv1-search-catalog", "olmv1", "search", "catalog")
+	})
+	It("searches a package", func() {
+		expectGolden("olmv1-search-catalog-package", "olmv1", "search", "catalog", "--package", "etcd", "--list-versions")
+	})
+	It("installs an extension", func() {
+		expectGolden("olmv1-install-extension", "olmv1", "install", "extension", "etcd", "-p", "etcd", "-c", "stable")
+	})
+	It("fails to install an extension of an unknown package", func() {
+		expectGolden("olmv1-install-extension-unknown", "olmv1", "install", "extension", "unknown", "-p", "unknown", "--timeout", "2s", "--cleanup-timeout", "2s")
+	})
+	It("gets extensions", func() {
+		mustRun("olmv1", "install", "extension", "etcd", "-p", "etcd")
+		expectGolden("olmv1-get-extension", "olmv1", "get", "extension")
+	})
+	It("reports that there are no extensions", func() {
+		expectGolden("olmv1-get-extension-none", "olmv1", "get", "extension")
+	})
+	It("updates an extension", func() {
+		mustRun("olmv1", "install", "extension", "etcd", "-p", "etcd", "--version", "0.9.2")
+		expectGolden("olmv1-update-extension", "olmv1", "update", "extension", "etcd", "--version", "0.9.4")
+	})
+	It("deletes an extension", func() {
+		mustRun("olmv1", "install", "extension", "etcd", "-p", "etcd")
+		expectGolden("olmv1-delete-extension", "olmv1", "delete", "extension", "etcd")
+	})
+	It("completes catalog names", func() {
+		expectGolden("olmv1-complete-catalog", "__complete", "olmv1", "get", "catalog", "operatorhubio")
+	})
+	It("refuses to browse without a terminal", func() {
+		expectGolden("olmv1-browse", "olmv1", "browse")
+	})
+})
+
+var _ = Describe("without a kubeconfig", func() {
+	var kubeconfig, home string
+
+	BeforeEach(func() {
+		kubeconfig, home = os.Getenv("KUBECONFIG"), os.Getenv("HOME")
+		Expect(os.Setenv("KUBECONFIG", filepath.Join(tmpDir, "missing-kubeconfig"))).To(Succeed())
+		Expect(os.Setenv("HOME", tmpDir)).To(Succeed())
+		loadConfig = (*action.Configuration).Load
+	})
+
+	AfterEach(func() {
+		Expect(os.Setenv("KUBECONFIG", kubeconfig)).To(Succeed())
+		Expect(os.Setenv("HOME", home)).To(Succeed())
+	})
+
+	It("fails to run commands that need a cluster", func() {
+		r := run("list")
+		Expect(r.code).To(Equal(1), r.String())
+		Expect(r.stderr).To(ContainSubstring("no configuration has been provided"))
+	})
+	It("inspects an index image", func() {
+		r := run("catalog", "inspect", "$REGISTRY/catalogs/operatorhubio:latest", "--plain-http")
+		Expect(r.code).To(BeZero(), r.String())
+		Expect(r.stdout).To(ContainSubstring("etcd"))
+	})
+	It("diffs two directories", func() {
+		r := run("catalog", "diff", filepath.Join(testdata, "catalogs", "operatorhubio"), filepath.Join(testdata, "catalogs", "operatorhubio-next"))
+		Expect(r.code).To(BeZero(), r.String())
+		Expect(r.stdout).To(ContainSubstring("package etcd"))
+	})
+	It("diffs two index images", func() {
+		r := run("catalog", "diff", "$REGISTRY/catalogs/operatorhubio:latest", "$REGISTRY/catalogs/operatorhubio-next:latest", "--plain-http")
+		Expect(r.code).To(BeZero(), r.String())
+		Expect(r.stdout).To(ContainSubstring("package etcd"))
+	})
+	It("fails to flag the installs affected by a diff", func() {
+		r := run("catalog", "diff", "$REGISTRY/catalogs/operatorhubio:latest", "$REGISTRY/catalogs/operatorhubio-next:latest", "--plain-http", "--check-installs")
+		Expect(r.code).To(Equal(1), r.String())
+		Expect(r.stderr).To(ContainSubstring("no configuration has been provided"))
+	})
+})
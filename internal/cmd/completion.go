@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/complete"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// completePackages completes the names of the PackageManifests available in the namespace.
+func completePackages(cfg *action.Configuration) cobra.CompletionFunc {
+	return complete.FirstArg(func(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx, cancel, err := complete.Load(cmd, cfg)
+		if err != nil {
+			return complete.Error()
+		}
+		defer cancel()
+
+		pms, err := internalaction.NewOperatorListAvailable(cfg).Run(ctx)
+		if err != nil {
+			return complete.Error()
+		}
+		names := make([]string, 0, len(pms))
+		for _, pm := range pms {
+			names = append(names, pm.Name)
+		}
+		return complete.Matching(names, toComplete)
+	})
+}
+
+// completeChannels completes the channels of the package named by the first argument.
+func completeChannels(cfg *action.Configuration) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		pm, directive, ok := completionPackage(cmd, cfg, args)
+		if !ok {
+			return nil, directive
+		}
+		names := make([]string, 0, len(pm.Status.Channels))
+		for _, ch := range pm.Status.Channels {
+			names = append(names, ch.Name)
+		}
+		return complete.Matching(names, toComplete)
+	}
+}
+
+// completeVersions completes the versions in the channel set by --channel, or in the default
+// channel, of the package named by the first argument.
+func completeVersions(cfg *action.Configuration) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		pm, directive, ok := completionPackage(cmd, cfg, args)
+		if !ok {
+			return nil, directive
+		}
+		channel, _ := cmd.Flags().GetString("channel")
+		pc, err := pm.GetChannel(channel)
+		if err != nil {
+			return complete.Error()
+		}
+		versions := make([]string, 0, len(pc.Entries)+1)
+		for _, e := range pc.Entries {
+			versions = append(versions, e.Version)
+		}
+		if len(versions) == 0 {
+			versions = append(versions, pc.CurrentCSVDesc.Version.String())
+		}
+		return complete.Matching(versions, toComplete)
+	}
+}
+
+func completionPackage(cmd *cobra.Command, cfg *action.Configuration, args []string) (*operator.PackageManifest, cobra.ShellCompDirective, bool) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp, false
+	}
+	ctx, cancel, err := complete.Load(cmd, cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError, false
+	}
+	defer cancel()
+
+	l := internalaction.NewOperatorListAvailable(cfg)
+	l.Package = args[0]
+	pms, err := l.Run(ctx)
+	if err != nil || len(pms) == 0 {
+		return nil, cobra.ShellCompDirectiveError, false
+	}
+	return &pms[0], 0, true
+}
@@ -0,0 +1,72 @@
+// Package complete provides helpers for the dynamic shell completions of the plugin's commands.
+package complete
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// defaultTimeout bounds the requests made for a completion if --timeout is not set.
+const defaultTimeout = 10 * time.Second
+
+// LoadConfig loads the configuration completions use to reach the cluster. Tests replace it to
+// complete against a fake cluster.
+var LoadConfig = (*action.Configuration).Load
+
+// Load loads cfg from the parsed flags of cmd, which is not done by the root command for
+// completions, and returns a context bounded by the --timeout flag.
+func Load(cmd *cobra.Command, cfg *action.Configuration) (context.Context, context.CancelFunc, error) {
+	if err := LoadConfig(cfg); err != nil {
+		return nil, nil, err
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil || timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	return ctx, cancel, nil
+}
+
+// Matching returns the sorted, unique names that start with prefix, together with the directive
+// that disables file completion.
+func Matching(names []string, prefix string) ([]string, cobra.ShellCompDirective) {
+	seen := map[string]struct{}{}
+	var out []string
+	for _, n := range names {
+		if _, ok := seen[n]; ok || n == "" || !strings.HasPrefix(n, prefix) {
+			continue
+		}
+		seen[n] = struct{}{}
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// Error is returned by completion functions that could not query the cluster.
+func Error() ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveError
+}
+
+// FirstArg wraps fn so that it only completes the first positional argument.
+func FirstArg(fn func(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective)) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, toComplete)
+	}
+}
+
+// RegisterFlag registers fn as the completion function of the flag called name on cmd.
+func RegisterFlag(cmd *cobra.Command, name string, fn cobra.CompletionFunc) {
+	if err := cmd.RegisterFlagCompletionFunc(name, fn); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,85 @@
+// Package exitcode classifies command errors and maps them to the exit codes of the plugin.
+package exitcode
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+// Code is the exit code of a failed command.
+type Code int
+
+const (
+	// Failure is returned for errors that have no more specific code.
+	Failure Code = 1
+	// Invalid is returned for invalid arguments and flags.
+	Invalid Code = 2
+	// NotFound is returned when an object, package or catalog does not exist.
+	NotFound Code = 3
+	// AlreadyExists is returned when an object to create already exists.
+	AlreadyExists Code = 4
+	// Timeout is returned when the command timed out waiting for the cluster.
+	Timeout Code = 5
+	// NoChange is returned when an update would not change anything.
+	NoChange Code = 6
+	// PartialFailure is returned when a command acting on several objects failed for some of them.
+	PartialFailure Code = 7
+)
+
+// Error is an error with an explicit exit code.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Validation returns an error for invalid arguments or flags.
+func Validation(err error) error {
+	return &Error{Code: Invalid, Err: err}
+}
+
+// Partial returns an error for a command that failed for some of the objects it acted on.
+func Partial(err error) error {
+	return &Error{Code: PartialFailure, Err: err}
+}
+
+// For returns the exit code for err: the code of an *Error it wraps, or a code derived from the
+// API server, context and action errors it wraps. It returns 0 for a nil error.
+func For(err error) Code {
+	var (
+		codeErr     *Error
+		pkgErr      *olmv1.PackageNotFoundError
+		operatorErr *internalaction.ErrPackageNotFound
+		stuckErr    *internalaction.ErrOperandsStuck
+	)
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &codeErr):
+		return codeErr.Code
+	case errors.Is(err, context.DeadlineExceeded), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.As(err, &stuckErr):
+		return Timeout
+	case errors.Is(err, olmv1.ErrNoChange):
+		return NoChange
+	case errors.Is(err, olmv1.ErrNameAndSelector), errors.Is(err, olmv1.ErrNameAndDeleteAll):
+		return Invalid
+	case apierrors.IsNotFound(err), errors.As(err, &pkgErr), errors.As(err, &operatorErr),
+		errors.Is(err, olmv1.ErrNoResourcesFound), errors.Is(err, olmv1.ErrNoServingCatalogs):
+		return NotFound
+	case apierrors.IsAlreadyExists(err):
+		return AlreadyExists
+	}
+	return Failure
+}
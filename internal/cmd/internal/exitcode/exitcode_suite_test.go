@@ -0,0 +1,13 @@
+package exitcode
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestExitCode(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Exit code Suite")
+}
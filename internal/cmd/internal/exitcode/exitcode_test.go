@@ -0,0 +1,61 @@
+package exitcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+var _ = Describe("For", func() {
+	resource := schema.GroupResource{Group: "olm.operatorframework.io", Resource: "clusterextensions"}
+
+	It("returns 0 for nil", func() {
+		Expect(For(nil)).To(Equal(Code(0)))
+	})
+
+	It("returns Failure for unclassified errors", func() {
+		Expect(For(errors.New("boom"))).To(Equal(Failure))
+	})
+
+	It("returns Invalid for validation errors", func() {
+		Expect(For(Validation(errors.New("bad flag")))).To(Equal(Invalid))
+		Expect(For(fmt.Errorf("delete: %w", olmv1.ErrNameAndDeleteAll))).To(Equal(Invalid))
+	})
+
+	It("returns NotFound for missing objects and packages", func() {
+		Expect(For(fmt.Errorf("get: %w", apierrors.NewNotFound(resource, "foo")))).To(Equal(NotFound))
+		Expect(For(&olmv1.PackageNotFoundError{Package: "foo"})).To(Equal(NotFound))
+		Expect(For(olmv1.ErrNoResourcesFound)).To(Equal(NotFound))
+		Expect(For(fmt.Errorf("uninstall operator: %w", &internalaction.ErrPackageNotFound{PackageName: "foo"}))).To(Equal(NotFound))
+	})
+
+	It("returns AlreadyExists for conflicting creates", func() {
+		Expect(For(apierrors.NewAlreadyExists(resource, "foo"))).To(Equal(AlreadyExists))
+	})
+
+	It("returns Timeout when waiting timed out", func() {
+		Expect(For(&olmv1.WaitError{Kind: "ClusterExtension", Name: "foo", Err: context.DeadlineExceeded})).To(Equal(Timeout))
+		stuck := &internalaction.ErrOperandsStuck{Operands: []internalaction.StuckOperand{{Kind: "EtcdCluster", Name: "foo"}}}
+		Expect(For(fmt.Errorf("uninstall operator: %w", stuck))).To(Equal(Timeout))
+	})
+
+	It("returns NoChange for updates without changes", func() {
+		Expect(For(fmt.Errorf("update: %w", olmv1.ErrNoChange))).To(Equal(NoChange))
+		Expect(For(fmt.Errorf("upgrade: %w", internalaction.ErrOperatorUpToDate))).To(Equal(NoChange))
+		Expect(For(fmt.Errorf("update catalog: %w", internalaction.ErrNoCatalogChange))).To(Equal(NoChange))
+		Expect(For(fmt.Errorf("configure operator: %w", internalaction.ErrNoConfigChange))).To(Equal(NoChange))
+	})
+
+	It("prefers an explicit code over the wrapped error", func() {
+		Expect(For(Partial(apierrors.NewNotFound(resource, "foo")))).To(Equal(PartialFailure))
+	})
+})
@@ -1,28 +1,215 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Format is the format log output is written in.
+type Format string
+
+const (
+	// FormatText writes messages as plain lines, and the command's output as is.
+	FormatText Format = "text"
+	// FormatJSON writes every message as a JSON Event line to stderr, and a JSON Result to
+	// stdout when the command finishes.
+	FormatJSON Format = "json"
+)
+
+// Level is the severity of an Event.
+type Level string
+
+const (
+	LevelError Level = "error"
+	LevelWarn  Level = "warning"
+	LevelInfo  Level = "info"
+	LevelDebug Level = "debug"
 )
 
-func Fatal(a ...interface{}) {
-	Print(a...)
-	os.Exit(1)
+// Event is a single structured log message.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Level   Level     `json:"level"`
+	Action  string    `json:"action,omitempty"`
+	Kind    string    `json:"kind,omitempty"`
+	Name    string    `json:"name,omitempty"`
+	Phase   string    `json:"phase,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Result is the outcome of a command, written to stdout with FormatJSON.
+type Result struct {
+	Time     time.Time   `json:"time"`
+	Action   string      `json:"action,omitempty"`
+	Kind     string      `json:"kind,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	Phase    string      `json:"phase"`
+	Error    string      `json:"error,omitempty"`
+	ExitCode int         `json:"exitCode,omitempty"`
+	Object   interface{} `json:"object,omitempty"`
 }
 
-func Fatalf(f string, a ...interface{}) {
-	Printf(f, a...)
-	os.Exit(1)
+var (
+	mu        sync.Mutex
+	format    = FormatText
+	verbosity int
+	action    string
+	kind      string
+	name      string
+
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+// BindFlags binds the flags that control the log format and verbosity.
+func BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar((*string)(&format), "log-format", string(FormatText), fmt.Sprintf("format of log output. One of: (%s, %s)", FormatText, FormatJSON))
+	fs.IntVar(&verbosity, "verbosity", 0, "log verbosity. Debug messages are logged at 1 and above.")
+}
+
+// Configure validates the log flags and sets the action that events are reported for, such as
+// "olmv1 install extension".
+func Configure(a string) error {
+	switch format {
+	case FormatText, FormatJSON:
+	default:
+		return fmt.Errorf("invalid `--log-format` %q: must be one of: (%s, %s)", format, FormatText, FormatJSON)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	action = a
+	kind, name = "", ""
+	return nil
+}
+
+// SetOutput sets the writers that output and log messages are written to, which default to
+// stdout and stderr.
+func SetOutput(out, err io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	stdout, stderr = out, err
+}
+
+// JSON reports whether log output is structured.
+func JSON() bool {
+	return format == FormatJSON
+}
+
+// SetObject sets the kind and name of the object the command acts on, which are added to every
+// event and the result.
+func SetObject(k, n string) {
+	mu.Lock()
+	defer mu.Unlock()
+	kind, name = k, n
+}
+
+// Emit writes e with FormatJSON, filling in its time, action and object. With FormatText, its
+// message is logged at its level.
+func Emit(e Event) {
+	if !JSON() {
+		msg := e.Message
+		if e.Error != "" {
+			msg = fmt.Sprintf("%s: %s", msg, e.Error)
+		}
+		logf(e.Level, "%s", msg)
+		return
+	}
+	if e.Level == LevelDebug && verbosity < 1 {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	e.Time = time.Now().UTC()
+	e.Action = action
+	if e.Kind == "" && e.Name == "" {
+		e.Kind, e.Name = kind, name
+	}
+	_ = json.NewEncoder(stderr).Encode(e)
+}
+
+// Done reports that the command succeeded. With FormatJSON, a Result holding obj is written to
+// stdout; obj may be nil.
+func Done(obj interface{}) {
+	writeResult("succeeded", "", 0, obj)
+}
+
+// Failed reports that the command failed with err and will exit with code. With FormatJSON, an
+// error event and a failed Result are written; otherwise err is printed to stderr.
+func Failed(err error, code int) {
+	if !JSON() {
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return
+	}
+	Emit(Event{Level: LevelError, Error: err.Error()})
+	writeResult("failed", err.Error(), code, nil)
+}
+
+func writeResult(phase, errMsg string, code int, obj interface{}) {
+	if !JSON() {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	_ = json.NewEncoder(stdout).Encode(Result{
+		Time:     time.Now().UTC(),
+		Action:   action,
+		Kind:     kind,
+		Name:     name,
+		Phase:    phase,
+		Error:    errMsg,
+		ExitCode: code,
+		Object:   obj,
+	})
 }
 
 func Print(a ...interface{}) {
-	fmt.Println(a...)
+	if JSON() {
+		Emit(Event{Level: LevelInfo, Message: strings.TrimSuffix(fmt.Sprintln(a...), "\n")})
+		return
+	}
+	_, _ = fmt.Fprintln(stdout, a...)
 }
 
 func Printf(f string, a ...interface{}) {
+	logf(LevelInfo, f, a...)
+}
+
+// Warnf logs a warning to stderr.
+func Warnf(f string, a ...interface{}) {
+	logf(LevelWarn, f, a...)
+}
+
+// Debugf logs a message to stderr if the verbosity is at least 1.
+func Debugf(f string, a ...interface{}) {
+	logf(LevelDebug, f, a...)
+}
+
+func logf(level Level, f string, a ...interface{}) {
+	if level == LevelDebug && verbosity < 1 {
+		return
+	}
+	if JSON() {
+		Emit(Event{Level: level, Message: strings.TrimSuffix(fmt.Sprintf(f, a...), "\n")})
+		return
+	}
 	if !strings.HasSuffix(f, "\n") {
 		f += "\n"
 	}
-	fmt.Printf(f, a...)
+	switch level {
+	case LevelInfo:
+		_, _ = fmt.Fprintf(stdout, f, a...)
+	case LevelError:
+		_, _ = fmt.Fprintf(stderr, "error: "+f, a...)
+	default:
+		_, _ = fmt.Fprintf(stderr, string(level)+": "+f, a...)
+	}
 }
@@ -0,0 +1,124 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+)
+
+var _ = Describe("log", func() {
+	var stdoutBuf, stderrBuf *bytes.Buffer
+
+	// setFlags parses the log flags the way the root command does.
+	setFlags := func(args ...string) {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		log.BindFlags(fs)
+		Expect(fs.Parse(args)).To(Succeed())
+		Expect(log.Configure("test")).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		stdoutBuf, stderrBuf = &bytes.Buffer{}, &bytes.Buffer{}
+		log.SetOutput(stdoutBuf, stderrBuf)
+		Expect(log.Configure("test")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		log.SetOutput(os.Stdout, os.Stderr)
+		setFlags()
+	})
+
+	events := func() []log.Event {
+		var evs []log.Event
+		for _, line := range strings.Split(strings.TrimSpace(stderrBuf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			e := log.Event{}
+			Expect(json.Unmarshal([]byte(line), &e)).To(Succeed())
+			evs = append(evs, e)
+		}
+		return evs
+	}
+
+	DescribeTable("text output",
+		func(v int, write func(), expStdout, expStderr string) {
+			setFlags(fmt.Sprintf("--verbosity=%d", v))
+			write()
+			Expect(stdoutBuf.String()).To(Equal(expStdout))
+			Expect(stderrBuf.String()).To(Equal(expStderr))
+		},
+		Entry("info goes to stdout", 0, func() { log.Printf("installed %q", "etcd") }, "installed \"etcd\"\n", ""),
+		Entry("warnings go to stderr", 0, func() { log.Warnf("deprecated") }, "", "warning: deprecated\n"),
+		Entry("debug is dropped at verbosity 0", 0, func() { log.Debugf("waiting") }, "", ""),
+		Entry("debug is logged at verbosity 1", 1, func() { log.Debugf("waiting") }, "", "debug: waiting\n"),
+		Entry("debug is logged above verbosity 1", 2, func() { log.Debugf("waiting") }, "", "debug: waiting\n"),
+		Entry("emitted debug events are dropped at verbosity 0", 0, func() { log.Emit(log.Event{Level: log.LevelDebug, Message: "waiting"}) }, "", ""),
+		Entry("emitted debug events are logged at verbosity 1", 1, func() { log.Emit(log.Event{Level: log.LevelDebug, Message: "waiting"}) }, "", "debug: waiting\n"),
+		Entry("emitted errors include the error", 0, func() { log.Emit(log.Event{Level: log.LevelError, Message: "install failed", Error: "timed out"}) }, "", "error: install failed: timed out\n"),
+	)
+
+	DescribeTable("json output",
+		func(v int, write func(), expLevels []log.Level) {
+			setFlags("--log-format=json", fmt.Sprintf("--verbosity=%d", v))
+			log.SetObject("ClusterExtension", "etcd")
+			write()
+			Expect(stdoutBuf.String()).To(BeEmpty())
+			var levels []log.Level
+			for _, e := range events() {
+				Expect(e.Action).To(Equal("test"))
+				Expect(e.Kind).To(Equal("ClusterExtension"))
+				Expect(e.Name).To(Equal("etcd"))
+				levels = append(levels, e.Level)
+			}
+			Expect(levels).To(Equal(expLevels))
+		},
+		Entry("debug is dropped at verbosity 0", 0, func() {
+			log.Printf("info")
+			log.Debugf("debug")
+			log.Emit(log.Event{Level: log.LevelDebug, Message: "debug"})
+			log.Warnf("warning")
+		}, []log.Level{log.LevelInfo, log.LevelWarn}),
+		Entry("debug is logged at verbosity 1", 1, func() {
+			log.Printf("info")
+			log.Debugf("debug")
+			log.Emit(log.Event{Level: log.LevelDebug, Message: "debug"})
+			log.Warnf("warning")
+		}, []log.Level{log.LevelInfo, log.LevelDebug, log.LevelDebug, log.LevelWarn}),
+	)
+
+	It("should keep the object an event is emitted for", func() {
+		setFlags("--log-format=json")
+		log.SetObject("ClusterExtension", "etcd")
+		log.Emit(log.Event{Level: log.LevelInfo, Kind: "ClusterCatalog", Name: "operatorhubio", Message: "unpacked"})
+		Expect(events()).To(ConsistOf(WithTransform(func(e log.Event) string { return e.Kind + "/" + e.Name }, Equal("ClusterCatalog/operatorhubio"))))
+	})
+
+	It("should write a result to stdout when the command finishes", func() {
+		setFlags("--log-format=json")
+		log.SetObject("ClusterExtension", "etcd")
+		log.Failed(os.ErrNotExist, 3)
+		r := log.Result{}
+		Expect(json.Unmarshal(stdoutBuf.Bytes(), &r)).To(Succeed())
+		Expect(r.Phase).To(Equal("failed"))
+		Expect(r.ExitCode).To(Equal(3))
+		Expect(r.Error).To(Equal(os.ErrNotExist.Error()))
+		Expect(events()).To(HaveLen(1))
+	})
+
+	It("should reject an unknown format", func() {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		log.BindFlags(fs)
+		Expect(fs.Parse([]string{"--log-format=xml"})).To(Succeed())
+		Expect(log.Configure("test")).To(MatchError(ContainSubstring(`invalid ` + "`--log-format`" + ` "xml"`)))
+	})
+})
@@ -0,0 +1,196 @@
+package olmv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+type browseOptions struct {
+	Selector          string
+	CatalogdNamespace string
+	CleanupTimeout    time.Duration
+}
+
+// NewBrowseCmd returns a command that browses the packages of the serving catalogs in an
+// interactive terminal UI, and installs the selected package as an extension.
+func NewBrowseCmd(cfg *action.Configuration) *cobra.Command {
+	var opts browseOptions
+
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "Browse catalogs and install extensions interactively",
+		Long: `Browse the packages of the serving catalogs in an interactive terminal UI.
+
+Type to fuzzy filter the packages by name and press enter to show the channels,
+versions and upgrade graph of a package. Select a channel and either a version or
+the channel head, then choose the name, namespace and service account of the
+extension to install it and follow its progress.
+
+The namespace and service account must already exist, and the service account must
+have the permissions needed to install the package. The command requires an
+interactive terminal.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+				return exitcode.Validation(errors.New("olmv1 browse requires an interactive terminal; use `olmv1 search catalog` and `olmv1 install extension` instead"))
+			}
+			s := v1action.NewCatalogSearch(cfg)
+			s.Logf = log.Printf
+			s.CatalogdNamespace = opts.CatalogdNamespace
+			s.Catalogd = CatalogdClient(cfg)
+			if opts.Selector != "" {
+				selector, err := labels.Parse(opts.Selector)
+				if err != nil {
+					return exitcode.Validation(fmt.Errorf("invalid `--selector` value %q: %w", opts.Selector, err))
+				}
+				s.Selector = selector
+			}
+			log.Printf("fetching the contents of the serving catalogs...")
+			contents, err := s.Run(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed querying catalog(s): %w", err)
+			}
+			packages := browsePackages(contents)
+			if len(packages) == 0 {
+				return fmt.Errorf("failed to browse catalogs: %w", v1action.ErrNoResourcesFound)
+			}
+
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			var ext *olmv1.ClusterExtension
+			install := func(ctx context.Context, in browseInstall, progress v1action.ProgressReporter) error {
+				ctx, cancel := context.WithTimeout(ctx, timeout)
+				defer cancel()
+				i := v1action.NewExtensionInstall(cfg)
+				i.ExtensionName = in.ExtensionName
+				i.PackageName = in.Package.Name
+				i.Namespace.Name = in.Namespace
+				i.ServiceAccount = in.ServiceAccount
+				i.Version = in.Version
+				if in.Channel != "" {
+					i.Channels = []string{in.Channel}
+				}
+				i.CatalogSelector = &metav1.LabelSelector{MatchLabels: map[string]string{olmv1.MetadataNameLabel: in.Package.Catalog}}
+				i.CleanupTimeout = opts.CleanupTimeout
+				i.Progress = progress
+				var err error
+				ext, err = i.Run(ctx)
+				return err
+			}
+
+			m := newBrowseModel(packages, "olmv1-system", "default")
+			// Browsing can take longer than --timeout, which only bounds the install.
+			if err := runBrowser(context.WithoutCancel(cmd.Context()), os.Stdin, os.Stdout, m, install); err != nil {
+				return err
+			}
+			if !m.finished {
+				return nil
+			}
+			log.SetObject(olmv1.ClusterExtensionKind, m.install.ExtensionName)
+			if m.err != nil {
+				return fmt.Errorf("failed to install extension %q: %w", m.install.ExtensionName, m.err)
+			}
+			log.Printf("extension %q created", m.install.ExtensionName)
+			log.Done(ext)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&opts.Selector, "selector", "l", "", "selector (label query) to filter the catalogs to browse")
+	cmd.Flags().StringVar(&opts.CatalogdNamespace, "catalogd-namespace", "olmv1-system", "namespace for the catalogd controller.")
+	cmd.Flags().DurationVar(&opts.CleanupTimeout, "cleanup-timeout", time.Minute, "the amount of time to wait before cancelling cleanup after a failed installation attempt.")
+
+	return cmd
+}
+
+// runBrowser runs m on the terminal until the user quits, calling install in the background
+// when the user asks to install an extension. The context passed to install is cancelled when
+// the user presses ctrl+c.
+func runBrowser(ctx context.Context, in, out *os.File, m *browseModel, install func(context.Context, browseInstall, v1action.ProgressReporter) error) error {
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = term.Restore(int(in.Fd()), state) }()
+
+	// Use the alternate screen and hide the cursor while browsing.
+	_, _ = out.WriteString("\x1b[?1049h\x1b[?25l")
+	defer func() { _, _ = out.WriteString("\x1b[?25h\x1b[?1049l") }()
+
+	keys := make(chan browseKey, 16)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			for _, k := range parseBrowseKeys(buf[:n]) {
+				keys <- k
+			}
+		}
+	}()
+
+	events := make(chan v1action.Event, 16)
+	done := make(chan error, 1)
+	installCtx, cancelInstall := context.WithCancel(ctx)
+	defer cancelInstall()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		draw(out, m)
+		select {
+		case k, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch m.handleKey(k) {
+			case browseActionQuit:
+				return nil
+			case browseActionCancel:
+				cancelInstall()
+			case browseActionInstall:
+				progress := v1action.ProgressFunc(func(e v1action.Event) { events <- e })
+				go func(in browseInstall) {
+					done <- install(installCtx, in, progress)
+				}(m.install)
+			}
+		case e := <-events:
+			m.report(e)
+		case err := <-done:
+			// Drain the events reported before the install returned.
+			for len(events) > 0 {
+				m.report(<-events)
+			}
+			m.finish(err)
+		case <-ticker.C:
+			m.tick++
+		}
+	}
+}
+
+func draw(out *os.File, m *browseModel) {
+	width, height, err := term.GetSize(int(out.Fd()))
+	if err != nil {
+		width, height = 80, 24
+	}
+	// Overwrite the previous frame line by line instead of clearing the screen, which flickers.
+	lines := m.view(width, height)
+	_, _ = out.WriteString("\x1b[H" + strings.Join(lines, "\x1b[K\r\n") + "\x1b[K\x1b[J")
+}
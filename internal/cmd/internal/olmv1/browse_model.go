@@ -0,0 +1,785 @@
+package olmv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+// browsePackage is a package of a catalog as shown by olmv1 browse.
+type browsePackage struct {
+	Catalog        string
+	Name           string
+	Description    string
+	DefaultChannel string
+	// Channels are sorted by name, with the default channel first.
+	Channels []browseChannel
+}
+
+type browseChannel struct {
+	Name string
+	// Entries are sorted by version, newest first.
+	Entries []browseEntry
+}
+
+// browseEntry is a bundle of a channel and its upgrade edges, with bundle names replaced by
+// their versions where the version is known.
+type browseEntry struct {
+	Version   string
+	Replaces  string
+	Skips     []string
+	SkipRange string
+}
+
+// browsePackages builds the sorted packages of the catalog contents returned by CatalogSearch.
+func browsePackages(contents map[string]*declcfg.DeclarativeConfig) []browsePackage {
+	var pkgs []browsePackage
+	for catalog, dcfg := range contents {
+		versions := map[string]string{}
+		descriptions := map[string]string{}
+		for i := range dcfg.Bundles {
+			b := &dcfg.Bundles[i]
+			if v, err := getBundleVersion(b); err == nil {
+				versions[b.Name] = v.String()
+			}
+			if d := getCSVDescription(b); d != "" {
+				descriptions[b.Name] = d
+			}
+		}
+		versionOf := func(bundle string) string {
+			if v, ok := versions[bundle]; ok {
+				return v
+			}
+			return bundle
+		}
+
+		channels := map[string][]browseChannel{}
+		heads := map[string]map[string]string{}
+		for _, c := range dcfg.Channels {
+			ch := browseChannel{Name: c.Name}
+			for _, e := range c.Entries {
+				entry := browseEntry{Version: versionOf(e.Name), SkipRange: e.SkipRange}
+				if e.Replaces != "" {
+					entry.Replaces = versionOf(e.Replaces)
+				}
+				for _, s := range e.Skips {
+					entry.Skips = append(entry.Skips, versionOf(s))
+				}
+				ch.Entries = append(ch.Entries, entry)
+			}
+			sort.SliceStable(ch.Entries, func(i, j int) bool {
+				return compareVersions(ch.Entries[i].Version, ch.Entries[j].Version) > 0
+			})
+			channels[c.Package] = append(channels[c.Package], ch)
+			if heads[c.Package] == nil {
+				heads[c.Package] = map[string]string{}
+			}
+			if len(c.Entries) > 0 {
+				heads[c.Package][c.Name] = headBundle(c.Entries, versions)
+			}
+		}
+
+		for _, p := range dcfg.Packages {
+			pkg := browsePackage{
+				Catalog:        catalog,
+				Name:           p.Name,
+				Description:    p.Description,
+				DefaultChannel: p.DefaultChannel,
+				Channels:       channels[p.Name],
+			}
+			if pkg.Description == "" {
+				pkg.Description = descriptions[heads[p.Name][p.DefaultChannel]]
+			}
+			sort.SliceStable(pkg.Channels, func(i, j int) bool {
+				if (pkg.Channels[i].Name == pkg.DefaultChannel) != (pkg.Channels[j].Name == pkg.DefaultChannel) {
+					return pkg.Channels[i].Name == pkg.DefaultChannel
+				}
+				return pkg.Channels[i].Name < pkg.Channels[j].Name
+			})
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		if pkgs[i].Name != pkgs[j].Name {
+			return pkgs[i].Name < pkgs[j].Name
+		}
+		return pkgs[i].Catalog < pkgs[j].Catalog
+	})
+	return pkgs
+}
+
+// headBundle returns the name of the newest bundle of a channel.
+func headBundle(entries []declcfg.ChannelEntry, versions map[string]string) string {
+	head := entries[0].Name
+	for _, e := range entries[1:] {
+		if compareVersions(versions[e.Name], versions[head]) > 0 {
+			head = e.Name
+		}
+	}
+	return head
+}
+
+// compareVersions compares two versions as semver, falling back to comparing them as strings.
+func compareVersions(a, b string) int {
+	va, errA := semver.Parse(a)
+	vb, errB := semver.Parse(b)
+	if errA == nil && errB == nil {
+		return va.Compare(vb)
+	}
+	return strings.Compare(a, b)
+}
+
+func getCSVDescription(bundle *declcfg.Bundle) string {
+	for _, p := range bundle.Properties {
+		if p.Type == property.TypeCSVMetadata {
+			var csv property.CSVMetadata
+			if err := json.Unmarshal(p.Value, &csv); err == nil {
+				return csv.Description
+			}
+		}
+	}
+	return ""
+}
+
+// fuzzyScore reports whether the characters of pattern appear in s in order, ignoring case. The
+// score is higher the more of the characters are adjacent and the earlier the match starts.
+func fuzzyScore(pattern, s string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+	p := []rune(strings.ToLower(pattern))
+	score, pi, prev := 0, 0, -2
+	for i, r := range []rune(strings.ToLower(s)) {
+		if pi == len(p) {
+			break
+		}
+		if r != p[pi] {
+			continue
+		}
+		switch {
+		case i == 0:
+			score += 3
+		case i == prev+1:
+			score += 2
+		default:
+			score++
+		}
+		prev = i
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	return score, true
+}
+
+type browseScreen int
+
+const (
+	browseScreenList browseScreen = iota
+	browseScreenPackage
+	browseScreenInstall
+	browseScreenProgress
+)
+
+type browseKeyKind int
+
+const (
+	browseKeyRune browseKeyKind = iota
+	browseKeyUp
+	browseKeyDown
+	browseKeyLeft
+	browseKeyRight
+	browseKeyEnter
+	browseKeyBackspace
+	browseKeyTab
+	browseKeyEsc
+	browseKeyCtrlC
+)
+
+type browseKey struct {
+	kind browseKeyKind
+	r    rune
+}
+
+// parseBrowseKeys decodes the keys in a chunk of terminal input read in raw mode.
+func parseBrowseKeys(b []byte) []browseKey {
+	var keys []browseKey
+	for len(b) > 0 {
+		switch {
+		case b[0] == 0x1b && len(b) >= 3 && (b[1] == '[' || b[1] == 'O'):
+			switch b[2] {
+			case 'A':
+				keys = append(keys, browseKey{kind: browseKeyUp})
+			case 'B':
+				keys = append(keys, browseKey{kind: browseKeyDown})
+			case 'C':
+				keys = append(keys, browseKey{kind: browseKeyRight})
+			case 'D':
+				keys = append(keys, browseKey{kind: browseKeyLeft})
+			}
+			// Skip the parameters and final byte of other sequences, such as "\x1b[3~".
+			n := 2
+			for n < len(b) && (b[n] < 0x40 || b[n] > 0x7e) {
+				n++
+			}
+			b = b[min(n+1, len(b)):]
+			continue
+		case b[0] == 0x1b:
+			keys = append(keys, browseKey{kind: browseKeyEsc})
+		case b[0] == 0x03:
+			keys = append(keys, browseKey{kind: browseKeyCtrlC})
+		case b[0] == '\r' || b[0] == '\n':
+			keys = append(keys, browseKey{kind: browseKeyEnter})
+		case b[0] == 0x7f || b[0] == 0x08:
+			keys = append(keys, browseKey{kind: browseKeyBackspace})
+		case b[0] == '\t':
+			keys = append(keys, browseKey{kind: browseKeyTab})
+		case b[0] < 0x20:
+		default:
+			r, n := utf8.DecodeRune(b)
+			if r != utf8.RuneError && unicode.IsPrint(r) {
+				keys = append(keys, browseKey{kind: browseKeyRune, r: r})
+			}
+			b = b[n:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// browseInstall is the extension the user asked to install.
+type browseInstall struct {
+	Package        browsePackage
+	Channel        string
+	Version        string
+	ExtensionName  string
+	Namespace      string
+	ServiceAccount string
+}
+
+type browseAction int
+
+const (
+	browseActionNone browseAction = iota
+	browseActionQuit
+	browseActionInstall
+	browseActionCancel
+)
+
+const (
+	browseFieldName = iota
+	browseFieldNamespace
+	browseFieldServiceAccount
+	browseFieldCount
+)
+
+var browseFieldLabels = [browseFieldCount]string{"Extension name", "Namespace", "Service account"}
+
+type browseStep struct {
+	message string
+	phase   v1action.Phase
+	err     error
+}
+
+// browseModel is the state of olmv1 browse. It is updated by keys and progress events, and
+// rendered to lines of text, so that it can be driven without a terminal.
+type browseModel struct {
+	packages []browsePackage
+	screen   browseScreen
+
+	filter  string
+	matches []int
+	cursor  int
+
+	channel int
+	// version is the selected entry of the channel plus one; 0 follows the channel head.
+	version int
+
+	form  [browseFieldCount]string
+	field int
+
+	install  browseInstall
+	steps    []browseStep
+	running  bool
+	finished bool
+	err      error
+	tick     int
+}
+
+func newBrowseModel(packages []browsePackage, namespace, serviceAccount string) *browseModel {
+	m := &browseModel{packages: packages}
+	m.form[browseFieldNamespace] = namespace
+	m.form[browseFieldServiceAccount] = serviceAccount
+	m.applyFilter()
+	return m
+}
+
+func (m *browseModel) applyFilter() {
+	type match struct {
+		index, score int
+	}
+	var matches []match
+	for i, p := range m.packages {
+		if score, ok := fuzzyScore(m.filter, p.Name); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	m.matches = m.matches[:0]
+	for _, match := range matches {
+		m.matches = append(m.matches, match.index)
+	}
+	m.cursor = 0
+}
+
+func (m *browseModel) selected() *browsePackage {
+	if len(m.matches) == 0 {
+		return nil
+	}
+	return &m.packages[m.matches[m.cursor]]
+}
+
+func (m *browseModel) selectedChannel() *browseChannel {
+	p := m.selected()
+	if p == nil || len(p.Channels) == 0 {
+		return nil
+	}
+	return &p.Channels[m.channel]
+}
+
+// selectedVersion returns the selected version, or "" to follow the head of the channel.
+func (m *browseModel) selectedVersion() string {
+	ch := m.selectedChannel()
+	if ch == nil || m.version == 0 {
+		return ""
+	}
+	return ch.Entries[m.version-1].Version
+}
+
+// handleKey updates the model for k and returns what the caller has to do.
+func (m *browseModel) handleKey(k browseKey) browseAction {
+	if k.kind == browseKeyCtrlC {
+		if m.running {
+			return browseActionCancel
+		}
+		return browseActionQuit
+	}
+	switch m.screen {
+	case browseScreenList:
+		return m.handleListKey(k)
+	case browseScreenPackage:
+		return m.handlePackageKey(k)
+	case browseScreenInstall:
+		return m.handleInstallKey(k)
+	case browseScreenProgress:
+		if m.finished {
+			return browseActionQuit
+		}
+	}
+	return browseActionNone
+}
+
+func (m *browseModel) handleListKey(k browseKey) browseAction {
+	switch k.kind {
+	case browseKeyEsc:
+		if m.filter == "" {
+			return browseActionQuit
+		}
+		m.filter = ""
+		m.applyFilter()
+	case browseKeyUp:
+		m.cursor = max(m.cursor-1, 0)
+	case browseKeyDown:
+		m.cursor = max(min(m.cursor+1, len(m.matches)-1), 0)
+	case browseKeyBackspace:
+		if m.filter != "" {
+			_, n := utf8.DecodeLastRuneInString(m.filter)
+			m.filter = m.filter[:len(m.filter)-n]
+			m.applyFilter()
+		}
+	case browseKeyRune:
+		m.filter += string(k.r)
+		m.applyFilter()
+	case browseKeyEnter:
+		if m.selected() != nil {
+			m.screen = browseScreenPackage
+			m.channel, m.version = 0, 0
+		}
+	}
+	return browseActionNone
+}
+
+func (m *browseModel) handlePackageKey(k browseKey) browseAction {
+	p := m.selected()
+	switch k.kind {
+	case browseKeyEsc:
+		m.screen = browseScreenList
+	case browseKeyUp:
+		if m.channel > 0 {
+			m.channel--
+			m.version = 0
+		}
+	case browseKeyDown:
+		if m.channel < len(p.Channels)-1 {
+			m.channel++
+			m.version = 0
+		}
+	case browseKeyLeft:
+		m.version = max(m.version-1, 0)
+	case browseKeyRight:
+		if ch := m.selectedChannel(); ch != nil && m.version < len(ch.Entries) {
+			m.version++
+		}
+	case browseKeyEnter:
+		m.screen = browseScreenInstall
+		m.form[browseFieldName] = p.Name
+		m.field = browseFieldName
+	}
+	return browseActionNone
+}
+
+func (m *browseModel) handleInstallKey(k browseKey) browseAction {
+	switch k.kind {
+	case browseKeyEsc:
+		m.screen = browseScreenPackage
+	case browseKeyUp:
+		m.field = (m.field + browseFieldCount - 1) % browseFieldCount
+	case browseKeyDown, browseKeyTab:
+		m.field = (m.field + 1) % browseFieldCount
+	case browseKeyBackspace:
+		if f := m.form[m.field]; f != "" {
+			_, n := utf8.DecodeLastRuneInString(f)
+			m.form[m.field] = f[:len(f)-n]
+		}
+	case browseKeyRune:
+		m.form[m.field] += string(k.r)
+	case browseKeyEnter:
+		if m.field < browseFieldCount-1 {
+			m.field++
+			return browseActionNone
+		}
+		for _, f := range m.form {
+			if strings.TrimSpace(f) == "" {
+				return browseActionNone
+			}
+		}
+		m.install = browseInstall{
+			Package:        *m.selected(),
+			Version:        m.selectedVersion(),
+			ExtensionName:  strings.TrimSpace(m.form[browseFieldName]),
+			Namespace:      strings.TrimSpace(m.form[browseFieldNamespace]),
+			ServiceAccount: strings.TrimSpace(m.form[browseFieldServiceAccount]),
+		}
+		if ch := m.selectedChannel(); ch != nil {
+			m.install.Channel = ch.Name
+		}
+		m.screen = browseScreenProgress
+		m.running = true
+		return browseActionInstall
+	}
+	return browseActionNone
+}
+
+// report records a progress event of the install.
+func (m *browseModel) report(e v1action.Event) {
+	for i := len(m.steps) - 1; i >= 0; i-- {
+		if m.steps[i].message == e.Message && m.steps[i].phase == v1action.PhaseStarted {
+			m.steps[i].phase, m.steps[i].err = e.Phase, e.Err
+			return
+		}
+	}
+	m.steps = append(m.steps, browseStep{message: e.Message, phase: e.Phase, err: e.Err})
+}
+
+// finish records the result of the install.
+func (m *browseModel) finish(err error) {
+	m.running, m.finished, m.err = false, true, err
+}
+
+var browseSpinner = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const (
+	browseReverse = "\x1b[7m"
+	browseBold    = "\x1b[1m"
+	browseDim     = "\x1b[2m"
+	browseReset   = "\x1b[0m"
+)
+
+// view renders the model to at most height lines of at most width columns.
+func (m *browseModel) view(width, height int) []string {
+	var lines []string
+	switch m.screen {
+	case browseScreenList:
+		lines = m.listView(width, height)
+	case browseScreenPackage:
+		lines = m.packageView(width, height)
+	case browseScreenInstall:
+		lines = m.installView(width)
+	case browseScreenProgress:
+		lines = m.progressView(width)
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return lines
+}
+
+func (m *browseModel) listView(width, height int) []string {
+	count := fmt.Sprintf("%d/%d packages", len(m.matches), len(m.packages))
+	header := fit("Filter: "+m.filter+"_", width-len(count)-1) + " " + count
+	lines := []string{header, strings.Repeat("─", max(width, 0))}
+
+	rows := max(height-3, 1)
+	listWidth := width
+	var details []string
+	if width >= 80 {
+		listWidth = min(40, width/2)
+		details = wrapLines(m.packageSummary(), width-listWidth-3)
+	}
+
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	for row := 0; row < rows; row++ {
+		var left string
+		if i := start + row; i < len(m.matches) {
+			p := m.packages[m.matches[i]]
+			if i == m.cursor {
+				left = browseReverse + fit(fmt.Sprintf(" %s (%s)", p.Name, p.Catalog), listWidth) + browseReset
+			} else {
+				left = fit(fmt.Sprintf(" %s %s(%s)%s", p.Name, browseDim, p.Catalog, browseReset), listWidth)
+			}
+		} else {
+			left = strings.Repeat(" ", listWidth)
+		}
+		if details != nil {
+			right := ""
+			if row < len(details) {
+				right = details[row]
+			}
+			left += " │ " + right
+		}
+		lines = append(lines, left)
+	}
+	if len(m.matches) == 0 {
+		lines[2] = fit(" No packages match the filter", width)
+	}
+	return append(lines, browseDim+fit("type to filter  ↑/↓ move  enter select  esc clear/quit", width)+browseReset)
+}
+
+func (m *browseModel) packageSummary() []string {
+	p := m.selected()
+	if p == nil {
+		return nil
+	}
+	var channels []string
+	for _, ch := range p.Channels {
+		channels = append(channels, ch.Name)
+	}
+	lines := []string{
+		browseBold + p.Name + browseReset,
+		"catalog:  " + p.Catalog,
+		"channels: " + strings.Join(channels, ", "),
+		"",
+	}
+	return append(lines, p.Description)
+}
+
+func (m *browseModel) packageView(width, height int) []string {
+	p := m.selected()
+	lines := []string{
+		fit(fmt.Sprintf("%s%s%s (catalog %s)", browseBold, p.Name, browseReset, p.Catalog), width),
+		strings.Repeat("─", max(width, 0)),
+	}
+	description := wrapLines([]string{p.Description}, width)
+	if len(description) > 5 {
+		description = append(description[:5], "...")
+	}
+	lines = append(lines, description...)
+	lines = append(lines, "")
+
+	channelWidth := 24
+	var channels, graph []string
+	channels = append(channels, browseBold+"CHANNELS"+browseReset)
+	for i, ch := range p.Channels {
+		name := ch.Name
+		if name == p.DefaultChannel {
+			name += " (default)"
+		}
+		if i == m.channel {
+			channels = append(channels, browseReverse+fit(" "+name, channelWidth)+browseReset)
+		} else {
+			channels = append(channels, " "+name)
+		}
+	}
+	if ch := m.selectedChannel(); ch != nil {
+		graph = append(graph, browseBold+"VERSIONS AND UPGRADE GRAPH"+browseReset)
+		head := " latest (follow the channel head)"
+		if m.version == 0 {
+			head = browseReverse + head + browseReset
+		}
+		graph = append(graph, head)
+		for i, e := range ch.Entries {
+			line := " " + e.Version
+			var edges []string
+			if e.Replaces != "" {
+				edges = append(edges, "replaces "+e.Replaces)
+			}
+			if len(e.Skips) > 0 {
+				edges = append(edges, "skips "+strings.Join(e.Skips, ", "))
+			}
+			if e.SkipRange != "" {
+				edges = append(edges, "skipRange "+e.SkipRange)
+			}
+			if len(edges) > 0 {
+				line += "  ← " + strings.Join(edges, "; ")
+			}
+			if i == m.version-1 {
+				line = browseReverse + line + browseReset
+			}
+			graph = append(graph, line)
+		}
+	}
+	for i := 0; i < max(len(channels), len(graph)); i++ {
+		left, right := "", ""
+		if i < len(channels) {
+			left = channels[i]
+		}
+		if i < len(graph) {
+			right = graph[i]
+		}
+		lines = append(lines, fit(left, channelWidth)+"  "+right)
+	}
+	if len(lines) > height-1 {
+		lines = lines[:max(height-1, 0)]
+	}
+	return append(lines, browseDim+fit("↑/↓ channel  ←/→ version  enter install  esc back", width)+browseReset)
+}
+
+func (m *browseModel) installView(width int) []string {
+	p := m.selected()
+	version := m.selectedVersion()
+	if version == "" {
+		version = "latest"
+	}
+	channel := "<none>"
+	if ch := m.selectedChannel(); ch != nil {
+		channel = ch.Name
+	}
+	lines := []string{
+		fit(fmt.Sprintf("%sInstall %s%s from catalog %s", browseBold, p.Name, browseReset, p.Catalog), width),
+		strings.Repeat("─", max(width, 0)),
+		fit(fmt.Sprintf("channel: %s  version: %s", channel, version), width),
+		"",
+	}
+	for i, label := range browseFieldLabels {
+		value := m.form[i]
+		line := fmt.Sprintf("  %-16s %s", label+":", value)
+		if i == m.field {
+			line = fmt.Sprintf("%s> %-16s %s_%s", browseBold, label+":", value, browseReset)
+		}
+		lines = append(lines, fit(line, width))
+	}
+	return append(lines, "", browseDim+fit("tab/↑/↓ move  enter next/install  esc back", width)+browseReset)
+}
+
+func (m *browseModel) progressView(width int) []string {
+	in := m.install
+	version := in.Version
+	if version == "" {
+		version = "latest in " + in.Channel
+	}
+	lines := []string{
+		fit(fmt.Sprintf("%sInstalling %s%s (%s %s) into namespace %s", browseBold, in.ExtensionName, browseReset, in.Package.Name, version, in.Namespace), width),
+		strings.Repeat("─", max(width, 0)),
+	}
+	for _, s := range m.steps {
+		var line string
+		switch s.phase {
+		case v1action.PhaseStarted:
+			line = browseSpinner[m.tick%len(browseSpinner)] + " " + s.message
+		case v1action.PhaseSucceeded:
+			line = "✓ " + s.message
+		case v1action.PhaseFailed:
+			line = fmt.Sprintf("✗ %s: %v", s.message, s.err)
+		}
+		lines = append(lines, fit(line, width))
+	}
+	if !m.finished {
+		if len(m.steps) == 0 {
+			lines = append(lines, browseSpinner[m.tick%len(browseSpinner)]+" creating ClusterExtension")
+		}
+		return append(lines, "", browseDim+fit("ctrl+c cancel", width)+browseReset)
+	}
+	lines = append(lines, "")
+	if m.err != nil {
+		lines = append(lines, wrapLines([]string{fmt.Sprintf("failed to install extension %q: %v", in.ExtensionName, m.err)}, width)...)
+	} else {
+		lines = append(lines, fit(fmt.Sprintf("extension %q installed", in.ExtensionName), width))
+	}
+	return append(lines, "", browseDim+fit("press any key to exit", width)+browseReset)
+}
+
+// fit pads or truncates s to width columns. Escape sequences are not counted and are kept.
+func fit(s string, width int) string {
+	var b strings.Builder
+	cols, escape := 0, false
+	for _, r := range s {
+		switch {
+		case escape:
+			b.WriteRune(r)
+			escape = r < 0x40 || r > 0x7e || r == '['
+			continue
+		case r == 0x1b:
+			b.WriteRune(r)
+			escape = true
+			continue
+		case cols >= width:
+			continue
+		}
+		b.WriteRune(r)
+		cols++
+	}
+	if cols < width {
+		b.WriteString(strings.Repeat(" ", width-cols))
+	}
+	return b.String()
+}
+
+// wrapLines wraps every paragraph of text to width columns.
+func wrapLines(text []string, width int) []string {
+	var out []string
+	for _, t := range text {
+		for _, paragraph := range strings.Split(t, "\n") {
+			line := ""
+			for _, word := range strings.Fields(paragraph) {
+				switch {
+				case line == "":
+					line = word
+				case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+					line += " " + word
+				default:
+					out = append(out, line)
+					line = word
+				}
+			}
+			out = append(out, line)
+		}
+	}
+	return out
+}
@@ -0,0 +1,189 @@
+package olmv1
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+var _ = Describe("browsePackages", func() {
+	It("builds packages with their channels, versions and upgrade graph", func() {
+		pkgs := browsePackages(browseContents())
+
+		Expect(pkgs).To(HaveLen(2))
+		Expect(pkgs[0].Name).To(Equal("etcd"))
+		Expect(pkgs[0].Catalog).To(Equal("operatorhubio"))
+		Expect(pkgs[0].Description).To(Equal("etcd from its CSV"))
+		Expect(pkgs[0].Channels).To(HaveLen(2))
+		Expect(pkgs[0].Channels[0].Name).To(Equal("stable"))
+		Expect(pkgs[0].Channels[1].Name).To(Equal("alpha"))
+		Expect(pkgs[0].Channels[0].Entries).To(Equal([]browseEntry{
+			{Version: "0.9.4", Replaces: "0.9.2", Skips: []string{"0.9.3"}},
+			{Version: "0.9.2"},
+		}))
+
+		Expect(pkgs[1].Name).To(Equal("prometheus"))
+		Expect(pkgs[1].Description).To(Equal("Prometheus operator"))
+	})
+})
+
+var _ = Describe("fuzzyScore", func() {
+	It("matches characters in order, ignoring case", func() {
+		_, ok := fuzzyScore("ETD", "etcd")
+		Expect(ok).To(BeTrue())
+		_, ok = fuzzyScore("dte", "etcd")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("prefers adjacent characters and matches at the start", func() {
+		prefix, _ := fuzzyScore("pro", "prometheus")
+		spread, _ := fuzzyScore("pro", "apache-rollout")
+		Expect(prefix).To(BeNumerically(">", spread))
+	})
+})
+
+var _ = Describe("parseBrowseKeys", func() {
+	It("decodes runes, control keys and escape sequences", func() {
+		keys := parseBrowseKeys([]byte("ab\x1b[A\x1b[B\x1b[3~\r\x7f\t\x03\x1b"))
+		Expect(keys).To(Equal([]browseKey{
+			{kind: browseKeyRune, r: 'a'},
+			{kind: browseKeyRune, r: 'b'},
+			{kind: browseKeyUp},
+			{kind: browseKeyDown},
+			{kind: browseKeyEnter},
+			{kind: browseKeyBackspace},
+			{kind: browseKeyTab},
+			{kind: browseKeyCtrlC},
+			{kind: browseKeyEsc},
+		}))
+	})
+})
+
+var _ = Describe("browseModel", func() {
+	var m *browseModel
+
+	BeforeEach(func() {
+		m = newBrowseModel(browsePackages(browseContents()), "olmv1-system", "default")
+	})
+
+	press := func(keys ...browseKey) browseAction {
+		var action browseAction
+		for _, k := range keys {
+			action = m.handleKey(k)
+		}
+		return action
+	}
+	typeText := func(s string) {
+		for _, r := range s {
+			press(browseKey{kind: browseKeyRune, r: r})
+		}
+	}
+
+	It("filters packages and quits on escape", func() {
+		typeText("prm")
+		Expect(m.matches).To(HaveLen(1))
+		Expect(m.selected().Name).To(Equal("prometheus"))
+
+		Expect(press(browseKey{kind: browseKeyEsc})).To(Equal(browseActionNone))
+		Expect(m.matches).To(HaveLen(2))
+		Expect(press(browseKey{kind: browseKeyEsc})).To(Equal(browseActionQuit))
+	})
+
+	It("installs the selected channel and version", func() {
+		typeText("etcd")
+		press(browseKey{kind: browseKeyEnter})
+		Expect(m.screen).To(Equal(browseScreenPackage))
+
+		press(browseKey{kind: browseKeyRight}, browseKey{kind: browseKeyRight})
+		Expect(m.selectedVersion()).To(Equal("0.9.2"))
+		press(browseKey{kind: browseKeyEnter})
+		Expect(m.screen).To(Equal(browseScreenInstall))
+
+		typeText("-1")
+		press(browseKey{kind: browseKeyEnter})
+		for range "olmv1-system" {
+			press(browseKey{kind: browseKeyBackspace})
+		}
+		typeText("etcd")
+		press(browseKey{kind: browseKeyEnter})
+		Expect(press(browseKey{kind: browseKeyEnter})).To(Equal(browseActionInstall))
+
+		Expect(m.install.Package.Name).To(Equal("etcd"))
+		Expect(m.install.Channel).To(Equal("stable"))
+		Expect(m.install.Version).To(Equal("0.9.2"))
+		Expect(m.install.ExtensionName).To(Equal("etcd-1"))
+		Expect(m.install.Namespace).To(Equal("etcd"))
+		Expect(m.install.ServiceAccount).To(Equal("default"))
+		Expect(m.screen).To(Equal(browseScreenProgress))
+	})
+
+	It("follows the install progress and cancels it", func() {
+		typeText("etcd")
+		press(browseKey{kind: browseKeyEnter}, browseKey{kind: browseKeyEnter},
+			browseKey{kind: browseKeyEnter}, browseKey{kind: browseKeyEnter}, browseKey{kind: browseKeyEnter})
+		Expect(m.install.Version).To(BeEmpty())
+
+		m.report(v1action.Event{Phase: v1action.PhaseStarted, Message: "waiting"})
+		Expect(strings.Join(m.view(80, 24), "\n")).To(ContainSubstring("waiting"))
+		Expect(press(browseKey{kind: browseKeyCtrlC})).To(Equal(browseActionCancel))
+
+		m.report(v1action.Event{Phase: v1action.PhaseFailed, Message: "waiting", Err: errors.New("canceled")})
+		m.finish(errors.New("canceled"))
+		Expect(m.steps).To(HaveLen(1))
+		Expect(strings.Join(m.view(80, 24), "\n")).To(ContainSubstring("✗ waiting: canceled"))
+		Expect(press(browseKey{kind: browseKeyRune, r: 'q'})).To(Equal(browseActionQuit))
+	})
+
+	It("renders every screen within the terminal size", func() {
+		views := [][]string{m.view(100, 10)}
+		press(browseKey{kind: browseKeyEnter})
+		views = append(views, m.view(100, 10))
+		press(browseKey{kind: browseKeyEnter})
+		views = append(views, m.view(100, 10))
+		for _, lines := range views {
+			Expect(len(lines)).To(BeNumerically("<=", 10))
+		}
+		Expect(strings.Join(views[1], "\n")).To(ContainSubstring("0.9.4  ← replaces 0.9.2; skips 0.9.3"))
+	})
+})
+
+func browseContents() map[string]*declcfg.DeclarativeConfig {
+	bundle := func(name, pkg, version, description string) declcfg.Bundle {
+		pkgProp, _ := json.Marshal(property.Package{PackageName: pkg, Version: version})
+		csvProp, _ := json.Marshal(property.CSVMetadata{Description: description})
+		return declcfg.Bundle{Name: name, Package: pkg, Properties: []property.Property{
+			{Type: property.TypePackage, Value: pkgProp},
+			{Type: property.TypeCSVMetadata, Value: csvProp},
+		}}
+	}
+	return map[string]*declcfg.DeclarativeConfig{
+		"operatorhubio": {
+			Packages: []declcfg.Package{
+				{Name: "prometheus", DefaultChannel: "beta", Description: "Prometheus operator"},
+				{Name: "etcd", DefaultChannel: "stable"},
+			},
+			Channels: []declcfg.Channel{
+				{Name: "alpha", Package: "etcd", Entries: []declcfg.ChannelEntry{{Name: "etcdoperator.v0.9.2"}}},
+				{Name: "stable", Package: "etcd", Entries: []declcfg.ChannelEntry{
+					{Name: "etcdoperator.v0.9.2"},
+					{Name: "etcdoperator.v0.9.4", Replaces: "etcdoperator.v0.9.2", Skips: []string{"etcdoperator.v0.9.3"}},
+				}},
+				{Name: "beta", Package: "prometheus", Entries: []declcfg.ChannelEntry{{Name: "prometheusoperator.0.47.0"}}},
+			},
+			Bundles: []declcfg.Bundle{
+				bundle("etcdoperator.v0.9.2", "etcd", "0.9.2", "old etcd"),
+				bundle("etcdoperator.v0.9.3", "etcd", "0.9.3", "skipped etcd"),
+				bundle("etcdoperator.v0.9.4", "etcd", "0.9.4", "etcd from its CSV"),
+				bundle("prometheusoperator.0.47.0", "prometheus", "0.47.0", "from the CSV"),
+			},
+		},
+	}
+}
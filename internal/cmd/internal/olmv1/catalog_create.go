@@ -1,6 +1,7 @@
 package olmv1
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -10,9 +11,10 @@ import (
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 type catalogCreateOptions struct {
@@ -32,35 +34,43 @@ func NewCatalogCreateCmd(cfg *action.Configuration) *cobra.Command {
 		Aliases: []string{"catalogs <catalog_name> <image_source_ref>"},
 		Args:    cobra.ExactArgs(2),
 		Short:   "Create a new catalog",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			i.CatalogName = args[0]
+			log.SetObject("ClusterCatalog", i.CatalogName)
 			i.ImageSourceRef = args[1]
 			opts.Image = i.ImageSourceRef
 			if err := opts.validate(); err != nil {
-				log.Fatalf("failed to parse flags: %v", err)
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
 			}
-			i.DryRun = opts.DryRun
-			i.Output = opts.Output
-			i.AvailabilityMode = opts.AvailabilityMode
+			progress, err := progressReporter(cmd)
+			if err != nil {
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
+			}
+			i.Progress = progress
+			i.DryRun = v1action.DryRunMode(opts.DryRun)
+			i.AvailabilityMode = olmv1.AvailabilityMode(opts.AvailabilityMode)
 			i.Priority = opts.Priority
 			i.Labels = opts.Labels
 			i.PollIntervalMinutes = opts.PollIntervalMinutes
 			catalogObj, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to create catalog %q: %v", i.CatalogName, err)
+				return fmt.Errorf("failed to create catalog %q: %w", i.CatalogName, err)
 			}
 			if len(i.DryRun) == 0 {
 				log.Printf("catalog %q created", i.CatalogName)
-				return
+				log.Done(catalogObj)
+				return nil
 			}
-			if len(i.Output) == 0 {
+			if len(opts.Output) == 0 {
 				log.Printf("catalog %q created (dry run)", i.CatalogName)
-				return
+				log.Done(catalogObj)
+				return nil
 			}
 
 			catalogObj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{Group: olmv1.GroupVersion.Group,
 				Version: olmv1.GroupVersion.Version, Kind: "ClusterCatalog"})
-			printFormattedCatalogs(i.Output, *catalogObj)
+			printFormattedCatalogs(opts.Output, *catalogObj)
+			return nil
 		},
 	}
 	bindMutableCatalogFlags(cmd.Flags(), &opts.mutableCatalogOptions)
@@ -1,15 +1,18 @@
 package olmv1
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 type catalogDeleteOptions struct {
@@ -24,44 +27,59 @@ func NewCatalogDeleteCmd(cfg *action.Configuration) *cobra.Command {
 	var opts catalogDeleteOptions
 
 	cmd := &cobra.Command{
-		Use:     "catalog [catalog_name]",
-		Aliases: []string{"catalogs [catalog_name]"},
-		Args:    cobra.RangeArgs(0, 1),
-		Short:   "Delete either a single or all of the existing catalogs",
-		Run: func(cmd *cobra.Command, args []string) {
+		Use:               "catalog [catalog_name]",
+		Aliases:           []string{"catalogs [catalog_name]"},
+		Args:              cobra.RangeArgs(0, 1),
+		Short:             "Delete either a single or all of the existing catalogs",
+		ValidArgsFunction: completeCatalogs(cfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				if i.DeleteAll {
-					log.Fatalf("failed to delete catalog: cannot specify both --all and a catalog name")
+					return fmt.Errorf("failed to delete catalog: %w", v1action.ErrNameAndDeleteAll)
 				}
 				i.CatalogName = args[0]
 			}
+			log.SetObject("ClusterCatalog", i.CatalogName)
 			if err := opts.validate(); err != nil {
-				log.Fatalf("failed to parse flags: %v", err)
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
+			}
+			progress, err := progressReporter(cmd)
+			if err != nil {
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
 			}
-			i.DryRun = opts.DryRun
-			i.Output = opts.Output
+			i.Progress = progress
+			i.DryRun = v1action.DryRunMode(opts.DryRun)
 			catalogs, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to delete catalog(s): %v", err)
+				if !i.DeleteAll || len(catalogs) == 0 {
+					return fmt.Errorf("failed to delete catalog(s): %w", err)
+				}
+				for _, c := range catalogs {
+					log.Printf("catalog %q deleted", c.Name)
+				}
+				return exitcode.Partial(fmt.Errorf("failed to delete catalog(s): %w", err))
 			}
 			if len(i.DryRun) == 0 {
 				for _, c := range catalogs {
 					log.Printf("catalog %q deleted", c.Name)
 				}
-				return
+				log.Done(catalogs)
+				return nil
 			}
-			if len(i.Output) == 0 {
+			if len(opts.Output) == 0 {
 				for _, c := range catalogs {
 					log.Printf("catalog %q deleted (dry run)", c.Name)
 				}
-				return
+				log.Done(catalogs)
+				return nil
 			}
 
 			for _, c := range catalogs {
 				c.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{Group: olmv1.GroupVersion.Group,
 					Version: olmv1.GroupVersion.Version, Kind: "ClusterCatalog"})
 			}
-			printFormattedCatalogs(i.Output, catalogs...)
+			printFormattedCatalogs(opts.Output, catalogs...)
+			return nil
 		},
 	}
 	bindCatalogDeleteFlags(cmd.Flags(), i)
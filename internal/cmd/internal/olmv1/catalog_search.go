@@ -1,16 +1,29 @@
 package olmv1
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1/catalogd"
 )
 
+// CatalogdClient returns the client that commands fetch the contents of ClusterCatalogs with, or
+// nil to port forward to catalogd. Tests replace it to reach the catalogd of a fake cluster.
+var CatalogdClient = func(*action.Configuration) catalogd.Client { return nil }
+
+type catalogSearchOptions struct {
+	getOptions
+	ListVersions bool
+}
+
 // NewCatalogSearchCmd handles get commands in the form of:
 // catalog(s) - this will either list all packages
 // from available catalogs if no catalog has been provided.
@@ -21,43 +34,38 @@ import (
 func NewCatalogSearchCmd(cfg *action.Configuration) *cobra.Command {
 	i := v1action.NewCatalogSearch(cfg)
 	i.Logf = log.Printf
-	var opts getOptions
+	var opts catalogSearchOptions
 
 	cmd := &cobra.Command{
 		Use:     "catalog",
 		Aliases: []string{"catalogs"},
 		Short:   "Search catalogs for installable packages matching parameters",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := opts.validate(); err != nil {
-				log.Fatalf("failed to parse flags: %v", err)
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
 			}
 			i.Selector = opts.ParsedSelector
+			i.Catalogd = CatalogdClient(cfg)
 			catalogContents, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed querying catalog(s): %v", err)
+				return fmt.Errorf("failed querying catalog(s): %w", err)
 			}
-			switch opts.Output {
-			case "":
-				printFormattedDeclCfg(os.Stdout, catalogContents, i.ListVersions)
-			case "json":
-				printDeclCfgJSON(os.Stdout, catalogContents)
-			case "yaml":
-				printDeclCfgYAML(os.Stdout, catalogContents)
-			default:
-				log.Fatalf("unsupported output format %q: allowed formats are (json|yaml)", opts.Output)
+			if err := PrintDeclCfg(os.Stdout, catalogContents, opts.Output, opts.ListVersions); err != nil {
+				return err
 			}
+			return nil
 		},
 	}
 	bindCatalogSearchFlags(cmd.Flags(), i)
-	bindGetFlags(cmd.Flags(), &opts)
+	cmd.Flags().BoolVar(&opts.ListVersions, "list-versions", false, "list all versions available for each package.")
+	bindGetFlags(cmd.Flags(), &opts.getOptions)
 
 	return cmd
 }
 
 func bindCatalogSearchFlags(fs *pflag.FlagSet, i *v1action.CatalogSearch) {
 	fs.StringVar(&i.CatalogName, "catalog", "", "name of the catalog to search. If not provided, all available catalogs are searched.")
-	fs.BoolVar(&i.ListVersions, "list-versions", false, "list all versions available for each package.")
 	fs.StringVar(&i.Package, "package", "", "search for package by name. If empty, all available packages will be listed.")
 	fs.StringVar(&i.CatalogdNamespace, "catalogd-namespace", "olmv1-system", "namespace for the catalogd controller.")
-	fs.StringVar(&i.Timeout, "timeout", "5m", "timeout for fetching catalog contents.")
+	fs.DurationVar(&i.Timeout, "timeout", 5*time.Minute, "timeout for fetching catalog contents.")
 }
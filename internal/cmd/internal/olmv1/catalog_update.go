@@ -1,15 +1,18 @@
 package olmv1
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/errors"
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 type catalogUpdateOptions struct {
@@ -26,14 +29,16 @@ func NewCatalogUpdateCmd(cfg *action.Configuration) *cobra.Command {
 	var opts catalogUpdateOptions
 
 	cmd := &cobra.Command{
-		Use:     "catalog <catalog_name>",
-		Aliases: []string{"catalogs <catalog_name>"},
-		Short:   "Update a catalog",
-		Args:    cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		Use:               "catalog <catalog_name>",
+		Aliases:           []string{"catalogs <catalog_name>"},
+		Short:             "Update a catalog",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCatalogs(cfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			i.CatalogName = args[0]
+			log.SetObject("ClusterCatalog", i.CatalogName)
 			if err := opts.validate(); err != nil {
-				log.Fatalf("failed to parse flags: %v", err)
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
 			}
 			if cmd.Flags().Changed("priority") {
 				i.Priority = &opts.Priority
@@ -45,27 +50,29 @@ func NewCatalogUpdateCmd(cfg *action.Configuration) *cobra.Command {
 				i.Labels = opts.Labels
 			}
 			i.ImageRef = opts.Image
-			i.AvailabilityMode = opts.AvailabilityMode
+			i.AvailabilityMode = olmv1.AvailabilityMode(opts.AvailabilityMode)
 			i.IgnoreUnset = opts.IgnoreUnset
-			i.DryRun = opts.DryRun
-			i.Output = opts.Output
+			i.DryRun = v1action.DryRunMode(opts.DryRun)
 			catalogObj, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to update catalog: %v", err)
+				return fmt.Errorf("failed to update catalog: %w", err)
 			}
 
 			if len(i.DryRun) == 0 {
 				log.Printf("catalog %q updated", i.CatalogName)
-				return
+				log.Done(catalogObj)
+				return nil
 			}
-			if len(i.Output) == 0 {
+			if len(opts.Output) == 0 {
 				log.Printf("catalog %q updated (dry run)", i.CatalogName)
-				return
+				log.Done(catalogObj)
+				return nil
 			}
 
 			catalogObj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{Group: olmv1.GroupVersion.Group,
 				Version: olmv1.GroupVersion.Version, Kind: "ClusterCatalog"})
-			printFormattedCatalogs(i.Output, *catalogObj)
+			printFormattedCatalogs(opts.Output, *catalogObj)
+			return nil
 		},
 	}
 	bindMutableCatalogFlags(cmd.Flags(), &opts.mutableCatalogOptions)
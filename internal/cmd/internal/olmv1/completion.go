@@ -0,0 +1,88 @@
+package olmv1
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/complete"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+// defaultCatalogdNamespace is the namespace of catalogd assumed by completions of commands that
+// have no --catalogd-namespace flag.
+const defaultCatalogdNamespace = "olmv1-system"
+
+// completeExtensions completes the names of the ClusterExtensions on the cluster.
+func completeExtensions(cfg *action.Configuration) cobra.CompletionFunc {
+	return complete.FirstArg(func(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx, cancel, err := complete.Load(cmd, cfg)
+		if err != nil {
+			return complete.Error()
+		}
+		defer cancel()
+
+		extensions, err := v1action.NewExtensionInstalledGet(cfg).Run(ctx)
+		if err != nil {
+			return complete.Error()
+		}
+		names := make([]string, 0, len(extensions))
+		for _, e := range extensions {
+			names = append(names, e.Name)
+		}
+		return complete.Matching(names, toComplete)
+	})
+}
+
+// completeCatalogs completes the names of the ClusterCatalogs on the cluster.
+func completeCatalogs(cfg *action.Configuration) cobra.CompletionFunc {
+	return complete.FirstArg(func(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx, cancel, err := complete.Load(cmd, cfg)
+		if err != nil {
+			return complete.Error()
+		}
+		defer cancel()
+
+		catalogs, err := v1action.NewCatalogInstalledGet(cfg).Run(ctx)
+		if err != nil {
+			return complete.Error()
+		}
+		names := make([]string, 0, len(catalogs))
+		for _, c := range catalogs {
+			names = append(names, c.Name)
+		}
+		return complete.Matching(names, toComplete)
+	})
+}
+
+// completePackages completes the names of the packages served by the ClusterCatalogs matching
+// the --catalog-selector flag, fetched from catalogd.
+func completePackages(cfg *action.Configuration) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx, cancel, err := complete.Load(cmd, cfg)
+		if err != nil {
+			return complete.Error()
+		}
+		defer cancel()
+
+		s := v1action.NewCatalogSearch(cfg)
+		s.CatalogdNamespace = defaultCatalogdNamespace
+		s.Catalogd = CatalogdClient(cfg)
+		if selector, _ := cmd.Flags().GetString("catalog-selector"); selector != "" {
+			if s.Selector, err = labels.Parse(selector); err != nil {
+				return complete.Error()
+			}
+		}
+		contents, err := s.Run(ctx)
+		if err != nil {
+			return complete.Error()
+		}
+		var names []string
+		for _, dc := range contents {
+			for _, p := range dc.Packages {
+				names = append(names, p.Name)
+			}
+		}
+		return complete.Matching(names, toComplete)
+	}
+}
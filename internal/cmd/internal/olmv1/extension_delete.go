@@ -1,15 +1,18 @@
 package olmv1
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 type extensionDeleteOptions struct {
@@ -30,41 +33,56 @@ func NewExtensionDeleteCmd(cfg *action.Configuration) *cobra.Command {
 		Long: `Warning: Permanently deletes the named cluster extension object.
 		If the extension contains CRDs, the CRDs will be deleted, which
 		 cascades to the deletion of all operands.`,
-		Args: cobra.RangeArgs(0, 1),
-		Run: func(cmd *cobra.Command, args []string) {
+		Args:              cobra.RangeArgs(0, 1),
+		ValidArgsFunction: completeExtensions(cfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				if i.DeleteAll {
-					log.Fatalf("failed to delete extension: cannot specify both --all and an extension name")
+					return fmt.Errorf("failed to delete extension: %w", v1action.ErrNameAndDeleteAll)
 				}
 				i.ExtensionName = args[0]
 			}
+			log.SetObject(olmv1.ClusterExtensionKind, i.ExtensionName)
 			if err := opts.validate(); err != nil {
-				log.Fatalf("failed to parse flags: %v", err)
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
+			}
+			progress, err := progressReporter(cmd)
+			if err != nil {
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
 			}
-			i.DryRun = opts.DryRun
-			i.Output = opts.Output
+			i.Progress = progress
+			i.DryRun = v1action.DryRunMode(opts.DryRun)
 			extensions, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to delete extension: %v", err)
+				if !i.DeleteAll || len(extensions) == 0 {
+					return fmt.Errorf("failed to delete extension: %w", err)
+				}
+				for _, e := range extensions {
+					log.Printf("extension %q deleted", e.Name)
+				}
+				return exitcode.Partial(fmt.Errorf("failed to delete extension: %w", err))
 			}
 			if len(i.DryRun) == 0 {
 				for _, e := range extensions {
 					log.Printf("extension %q deleted", e.Name)
 				}
-				return
+				log.Done(extensions)
+				return nil
 			}
-			if len(i.Output) == 0 {
+			if len(opts.Output) == 0 {
 				for _, e := range extensions {
 					log.Printf("extension %q deleted (dry run)", e.Name)
 				}
-				return
+				log.Done(extensions)
+				return nil
 			}
 
 			for _, e := range extensions {
 				e.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{Group: olmv1.GroupVersion.Group,
 					Version: olmv1.GroupVersion.Version, Kind: olmv1.ClusterExtensionKind})
 			}
-			printFormattedExtensions(i.Output, extensions...)
+			printFormattedExtensions(opts.Output, extensions...)
+			return nil
 		},
 	}
 	bindExtensionDeleteFlags(cmd.Flags(), i)
@@ -1,14 +1,17 @@
 package olmv1
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 // NewExtensionInstalledGetCmd handles get commands in the form of:
@@ -21,21 +24,22 @@ func NewExtensionInstalledGetCmd(cfg *action.Configuration) *cobra.Command {
 	var opts getOptions
 
 	cmd := &cobra.Command{
-		Use:     "extension [extension_name]",
-		Aliases: []string{"extensions [extension_name]"},
-		Args:    cobra.RangeArgs(0, 1),
-		Short:   "Display one or many installed extensions",
-		Run: func(cmd *cobra.Command, args []string) {
+		Use:               "extension [extension_name]",
+		Aliases:           []string{"extensions [extension_name]"},
+		Args:              cobra.RangeArgs(0, 1),
+		Short:             "Display one or many installed extensions",
+		ValidArgsFunction: completeExtensions(cfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 1 {
 				i.ExtensionName = args[0]
 			}
 			if err := opts.validate(); err != nil {
-				log.Fatalf("failed to parse flags: %v", err)
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
 			}
 			i.Selector = opts.ParsedSelector
 			installedExtensions, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed getting installed extension(s): %v", err)
+				return fmt.Errorf("failed getting installed extension(s): %w", err)
 			}
 
 			for i := range installedExtensions {
@@ -43,6 +47,7 @@ func NewExtensionInstalledGetCmd(cfg *action.Configuration) *cobra.Command {
 					Version: olmv1.GroupVersion.Version, Kind: olmv1.ClusterExtensionKind})
 			}
 			printFormattedExtensions(opts.Output, installedExtensions...)
+			return nil
 		},
 	}
 	bindGetFlags(cmd.Flags(), &opts)
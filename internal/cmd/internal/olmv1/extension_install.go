@@ -1,6 +1,7 @@
 package olmv1
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -10,9 +11,11 @@ import (
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/complete"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 type extensionInstallOptions struct {
@@ -32,39 +35,48 @@ func NewExtensionInstallCmd(cfg *action.Configuration) *cobra.Command {
 		Aliases: []string{"extensions <extension_name>"},
 		Short:   "Install an extension",
 		Args:    cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			i.ExtensionName = args[0]
+			log.SetObject(olmv1.ClusterExtensionKind, i.ExtensionName)
 			if err := opts.validate(); err != nil {
-				log.Fatalf("failed to parse flags: %v", err)
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
 			}
+			progress, err := progressReporter(cmd)
+			if err != nil {
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
+			}
+			i.Progress = progress
 			i.Version = opts.Version
 			i.Channels = opts.Channels
 			i.Labels = opts.Labels
-			i.UpgradeConstraintPolicy = opts.UpgradeConstraintPolicy
-			i.CRDUpgradeSafetyEnforcement = opts.CRDUpgradeSafetyEnforcement
+			i.UpgradeConstraintPolicy = olmv1.UpgradeConstraintPolicy(opts.UpgradeConstraintPolicy)
+			i.CRDUpgradeSafetyEnforcement = olmv1.CRDUpgradeSafetyEnforcement(opts.CRDUpgradeSafetyEnforcement)
 			i.CatalogSelector = opts.ParsedSelector
-			i.DryRun = opts.DryRun
-			i.Output = opts.Output
+			i.DryRun = v1action.DryRunMode(opts.DryRun)
 			extObj, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to install extension %q: %v", i.ExtensionName, err)
+				return fmt.Errorf("failed to install extension %q: %w", i.ExtensionName, err)
 			}
 			if len(i.DryRun) == 0 {
 				log.Printf("extension %q created", i.ExtensionName)
-				return
+				log.Done(extObj)
+				return nil
 			}
-			if len(i.Output) == 0 {
+			if len(opts.Output) == 0 {
 				log.Printf("extension %q created (dry run)", i.ExtensionName)
-				return
+				log.Done(extObj)
+				return nil
 			}
 
 			extObj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{Group: olmv1.GroupVersion.Group,
 				Version: olmv1.GroupVersion.Version, Kind: olmv1.ClusterExtensionKind})
-			printFormattedExtensions(i.Output, *extObj)
+			printFormattedExtensions(opts.Output, *extObj)
+			return nil
 		},
 	}
 	bindMutableExtensionFlags(cmd.Flags(), &opts.mutableExtensionOptions)
 	bindExtensionInstallFlags(cmd.Flags(), i)
+	complete.RegisterFlag(cmd, "package-name", completePackages(cfg))
 	bindDryRunFlags(cmd.Flags(), &opts.dryRunOptions)
 
 	return cmd
@@ -77,7 +89,7 @@ func bindExtensionInstallFlags(fs *pflag.FlagSet, i *v1action.ExtensionInstall)
 	fs.DurationVar(&i.CleanupTimeout, "cleanup-timeout", time.Minute, "the amount of time to wait before cancelling cleanup after a failed creation attempt.")
 
 	if err := cobra.MarkFlagRequired(fs, "package-name"); err != nil {
-		log.Fatalf("failed to process command flags: %v", err)
+		panic(fmt.Sprintf("failed to process command flags: %v", err))
 	}
 }
 
@@ -1,15 +1,18 @@
 package olmv1
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/errors"
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 type extensionUpdateOptions struct {
@@ -26,40 +29,49 @@ func NewExtensionUpdateCmd(cfg *action.Configuration) *cobra.Command {
 	var opts extensionUpdateOptions
 
 	cmd := &cobra.Command{
-		Use:     "extension <extension_name>",
-		Aliases: []string{"extensions <extension_name>"},
-		Short:   "Update an extension",
-		Args:    cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		Use:               "extension <extension_name>",
+		Aliases:           []string{"extensions <extension_name>"},
+		Short:             "Update an extension",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeExtensions(cfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			i.ExtensionName = args[0]
+			log.SetObject(olmv1.ClusterExtensionKind, i.ExtensionName)
 			if err := opts.validate(); err != nil {
-				log.Fatalf("failed to parse flags: %v", err)
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
+			}
+			progress, err := progressReporter(cmd)
+			if err != nil {
+				return exitcode.Validation(fmt.Errorf("failed to parse flags: %w", err))
 			}
+			i.Progress = progress
 			i.Version = opts.Version
 			i.Channels = opts.Channels
 			i.Labels = opts.Labels
-			i.UpgradeConstraintPolicy = opts.UpgradeConstraintPolicy
-			i.CRDUpgradeSafetyEnforcement = opts.CRDUpgradeSafetyEnforcement
+			i.UpgradeConstraintPolicy = olmv1.UpgradeConstraintPolicy(opts.UpgradeConstraintPolicy)
+			i.CRDUpgradeSafetyEnforcement = olmv1.CRDUpgradeSafetyEnforcement(opts.CRDUpgradeSafetyEnforcement)
 			i.CatalogSelector = opts.ParsedSelector
 			i.IgnoreUnset = opts.IgnoreUnset
-			i.DryRun = opts.DryRun
-			i.Output = opts.Output
+			i.DryRun = v1action.DryRunMode(opts.DryRun)
 			extObj, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to update extension: %v", err)
+				return fmt.Errorf("failed to update extension: %w", err)
 			}
 			if len(i.DryRun) == 0 {
 				log.Printf("extension %q updated", i.ExtensionName)
-				return
+				log.Done(extObj)
+				return nil
 			}
-			if len(i.Output) == 0 {
+			if len(opts.Output) == 0 {
 				log.Printf("extension %q updated (dry run)", i.ExtensionName)
-				return
+				log.Done(extObj)
+				return nil
 			}
 
 			extObj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{Group: olmv1.GroupVersion.Group,
 				Version: olmv1.GroupVersion.Version, Kind: olmv1.ClusterExtensionKind})
-			printFormattedExtensions(i.Output, *extObj)
+			printFormattedExtensions(opts.Output, *extObj)
+			return nil
 		},
 	}
 	bindMutableExtensionFlags(cmd.Flags(), &opts.mutableExtensionOptions)
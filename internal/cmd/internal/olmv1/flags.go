@@ -2,17 +2,22 @@ package olmv1
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/blang/semver/v4"
 	"github.com/containerd/containerd/reference"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/errors"
 
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	v1action "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	v1action "github.com/operator-framework/kubectl-operator/pkg/olmv1"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1/progress"
 )
 
 // getOptions is used in searching catalogs and listing resources
@@ -59,7 +64,7 @@ func bindDryRunFlags(fs *pflag.FlagSet, o *dryRunOptions) {
 
 func (o *dryRunOptions) validate() error {
 	var errs []error
-	if len(o.DryRun) > 0 && o.DryRun != v1action.DryRunAll {
+	if len(o.DryRun) > 0 && o.DryRun != string(v1action.DryRunAll) {
 		errs = append(errs, fmt.Errorf("invalid value for `--dry-run` %q, must be one of (%s)", o.DryRun, v1action.DryRunAll))
 	}
 	switch o.Output {
@@ -173,3 +178,52 @@ func (o *mutableCatalogOptions) validate() error {
 	}
 	return errors.NewAggregate(errs)
 }
+
+const progressFlag = "progress"
+
+// BindProgressFlags binds the flag that selects how commands report their progress while
+// they wait for the cluster.
+func BindProgressFlags(fs *pflag.FlagSet) {
+	fs.String(progressFlag, "auto", "how to report progress while waiting for the cluster. One of: (auto, spinner, plain, json, none). "+
+		"auto logs progress events with --log-format=json, and otherwise shows a spinner if stderr is a terminal and plain lines if not.")
+}
+
+// progressReporter returns the reporter selected by the progress flag. Progress is written to
+// stderr, so that it does not mix with the command's output.
+func progressReporter(cmd *cobra.Command) (v1action.ProgressReporter, error) {
+	mode := "auto"
+	if f := cmd.Flags().Lookup(progressFlag); f != nil {
+		mode = f.Value.String()
+	}
+	if mode == "auto" {
+		switch {
+		case log.JSON():
+			return v1action.ProgressFunc(logProgress), nil
+		case term.IsTerminal(int(os.Stderr.Fd())):
+			mode = "spinner"
+		default:
+			mode = "plain"
+		}
+	}
+	switch mode {
+	case "spinner":
+		return progress.NewSpinner(os.Stderr), nil
+	case "plain":
+		return progress.NewPlain(os.Stderr), nil
+	case "json":
+		return progress.NewJSON(os.Stderr), nil
+	case "none":
+		return v1action.ProgressFunc(func(v1action.Event) {}), nil
+	default:
+		return nil, fmt.Errorf("invalid `--progress` value %q: must be one of: [auto, spinner, plain, json, none]", mode)
+	}
+}
+
+// logProgress logs progress events, so that they are structured like other log messages.
+func logProgress(e v1action.Event) {
+	event := log.Event{Level: log.LevelInfo, Kind: e.Kind, Name: e.Name, Phase: string(e.Phase), Message: e.Message}
+	if e.Err != nil {
+		event.Level, event.Error = log.LevelError, e.Err.Error()
+	}
+	log.Emit(event)
+}
@@ -21,6 +21,8 @@ import (
 	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 	"github.com/operator-framework/operator-registry/alpha/property"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 )
 
 func printFormattedExtensions(outputFormat string, extensions ...olmv1.ClusterExtension) {
@@ -59,7 +61,7 @@ func printFormattedExtensions(outputFormat string, extensions ...olmv1.ClusterEx
 	default:
 	}
 	if len(extensions) == 0 {
-		fmt.Println("No resources found")
+		log.Print("No resources found")
 		return
 	}
 	tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
@@ -121,7 +123,7 @@ func printFormattedCatalogs(outputFormat string, catalogs ...olmv1.ClusterCatalo
 	default:
 	}
 	if len(catalogs) == 0 {
-		fmt.Println("No resources found")
+		log.Print("No resources found")
 		return
 	}
 	tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
@@ -146,6 +148,23 @@ func printFormattedCatalogs(outputFormat string, catalogs ...olmv1.ClusterCatalo
 	_ = tw.Flush()
 }
 
+// PrintDeclCfg prints the contents of each catalog in the given output format: a table of
+// packages (or package versions, if listVersions is set) when output is empty, or the raw
+// catalog contents when output is json or yaml.
+func PrintDeclCfg(w io.Writer, catalogDcfg map[string]*declcfg.DeclarativeConfig, output string, listVersions bool) error {
+	switch output {
+	case "":
+		printFormattedDeclCfg(w, catalogDcfg, listVersions)
+	case "json":
+		printDeclCfgJSON(w, catalogDcfg)
+	case "yaml":
+		printDeclCfgYAML(w, catalogDcfg)
+	default:
+		return fmt.Errorf("unsupported output format %q: allowed formats are (json|yaml)", output)
+	}
+	return nil
+}
+
 func printFormattedDeclCfg(w io.Writer, catalogDcfg map[string]*declcfg.DeclarativeConfig, listVersions bool) {
 	var printedHeaders bool
 	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
@@ -186,6 +205,11 @@ func printFormattedDeclCfg(w io.Writer, catalogDcfg map[string]*declcfg.Declarat
 				}
 				pkgProviders[c.Package].channels = append(pkgProviders[c.Package].channels, c.Name)
 			}
+			for _, b := range dcfg.Bundles {
+				if meta := pkgProviders[b.Package]; meta != nil && meta.provider == "" {
+					meta.provider = getCSVProvider(&b)
+				}
+			}
 		}
 
 		for _, p := range dcfg.Packages {
@@ -13,6 +13,7 @@ func newOlmV1Cmd(cfg *action.Configuration) *cobra.Command {
 		Short: "Manage OLMv1 extensions and catalogs",
 		Long:  "Manage OLMv1 resources like clusterextensions and clustercatalogs from the command line.",
 	}
+	olmv1.BindProgressFlags(cmd.PersistentFlags())
 
 	getCmd := &cobra.Command{
 		Use:   "get",
@@ -72,6 +73,7 @@ func newOlmV1Cmd(cfg *action.Configuration) *cobra.Command {
 		deleteCmd,
 		updateCmd,
 		searchCmd,
+		olmv1.NewBrowseCmd(cfg),
 	)
 
 	return cmd
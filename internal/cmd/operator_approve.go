@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorApproveCmd(cfg *action.Configuration) *cobra.Command {
+	var (
+		allNamespaces bool
+		all           bool
+		yes           bool
+		selector      string
+	)
+	a := internalaction.NewOperatorApprove(cfg)
+	a.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "approve [<installplan>...]",
+		Short: "List and approve pending install plans",
+		Long: `List and approve install plans that are waiting for manual approval.
+
+Without arguments, approve lists the pending install plans along with the CSVs
+each would install or replace and the cluster-scoped resources it would create.
+
+To approve install plans, name them on the command line, select them with
+--selector, or use --all to approve every pending install plan. A summary of
+each install plan's steps is printed before asking for confirmation:
+
+  + the resource will be created
+  ~ the resource already exists and will be updated
+
+Use --yes to skip the confirmation prompt.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if allNamespaces {
+				cfg.Namespace = corev1.NamespaceAll
+			}
+			if selector != "" {
+				sel, err := labels.Parse(selector)
+				if err != nil {
+					return exitcode.Validation(fmt.Errorf("invalid selector %q: %w", selector, err))
+				}
+				a.Selector = sel
+			}
+			a.InstallPlanNames = args
+
+			pending, err := a.Pending(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("list pending install plans: %w", err)
+			}
+			if len(pending) == 0 {
+				log.Print("No pending install plans found")
+				return nil
+			}
+
+			if len(args) == 0 && selector == "" && !all {
+				writePendingInstallPlans(os.Stdout, pending)
+				return nil
+			}
+
+			for _, p := range pending {
+				writeInstallPlanSummary(os.Stdout, p)
+			}
+			if !yes && !confirm(os.Stdin, os.Stdout, fmt.Sprintf("Approve %d install plan(s)?", len(pending))) {
+				log.Print("approval cancelled")
+				return nil
+			}
+			if err := a.Approve(cmd.Context(), pending); err != nil {
+				return fmt.Errorf("failed to approve install plans: %w", err)
+			}
+			log.Done(pending)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "find install plans in all namespaces")
+	cmd.Flags().BoolVar(&all, "all", false, "approve all pending install plans")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "approve without asking for confirmation")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "selector (label query) to filter install plans on")
+	return cmd
+}
+
+func writePendingInstallPlans(w io.Writer, pending []internalaction.PendingInstallPlan) {
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "NAMESPACE\tNAME\tINSTALLS\tREPLACES\tCLUSTER-SCOPED RESOURCES\tAGE\n")
+	for _, p := range pending {
+		age := time.Since(p.InstallPlan.CreationTimestamp.Time)
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			p.InstallPlan.Namespace,
+			p.InstallPlan.Name,
+			strings.Join(p.Installs, ","),
+			joinOrNone(p.Replaces),
+			len(p.ClusterScoped),
+			duration.HumanDuration(age),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func writeInstallPlanSummary(w io.Writer, p internalaction.PendingInstallPlan) {
+	_, _ = fmt.Fprintf(w, "installplan %s/%s\n", p.InstallPlan.Namespace, p.InstallPlan.Name)
+	_, _ = fmt.Fprintf(w, "  installs: %s\n", strings.Join(p.Installs, ", "))
+	_, _ = fmt.Fprintf(w, "  replaces: %s\n", joinOrNone(p.Replaces))
+
+	clusterScoped := map[v1alpha1.StepResource]bool{}
+	for _, r := range p.ClusterScoped {
+		clusterScoped[r] = true
+	}
+	for _, step := range p.InstallPlan.Status.Plan {
+		if step == nil {
+			continue
+		}
+		marker := "+"
+		if step.Status == v1alpha1.StepStatusPresent {
+			marker = "~"
+		}
+		scope := ""
+		if clusterScoped[step.Resource] {
+			scope = " (cluster-scoped)"
+		}
+		_, _ = fmt.Fprintf(w, "  %s %s %s%s\n", marker, step.Resource.Kind, step.Resource.Name, scope)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func confirm(in io.Reader, out io.Writer, prompt string) bool {
+	_, _ = fmt.Fprintf(out, "%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+func joinOrNone(s []string) string {
+	if len(s) == 0 {
+		return "<none>"
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorConfigureCmd(cfg *action.Configuration) *cobra.Command {
+	c := internalaction.NewOperatorConfigure(cfg)
+	c.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "configure <operator>",
+		Short: "Configure the subscription of an installed operator",
+		Long: `Configure the subscription of an installed operator.
+
+Settings are merged into the existing subscription config: environment
+variables, volumes and volume mounts replace existing entries with the same
+name (or mount path), map values replace existing keys, and tolerations and
+env-from sources are added if not already present. OLM rolls out the new
+config to the operator's deployments.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c.Package = args[0]
+			log.SetObject("Operator", c.Package)
+			sub, err := c.Run(cmd.Context())
+			if errors.Is(err, internalaction.ErrNoConfigChange) {
+				log.Printf("subscription %q unchanged", sub.Name)
+				log.Done(sub)
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to configure operator: %w", err)
+			}
+			log.Printf("subscription %q configured", sub.Name)
+			log.Done(sub)
+			return nil
+		},
+	}
+	bindSubscriptionConfigFlags(cmd.Flags(), &c.Config)
+	return cmd
+}
+
+func bindSubscriptionConfigFlags(fs *pflag.FlagSet, o *subscription.ConfigOptions) {
+	fs.StringVar(&o.ConfigFile, "config-file", "", "path to a YAML or JSON file containing a subscription config; flags override values from the file")
+	fs.StringArrayVar(&o.Env, "env", nil, "environment variable to set on the operator's containers, in the form NAME=VALUE (can be repeated)")
+	fs.StringArrayVar(&o.EnvFrom, "env-from", nil, "configmap or secret to source environment variables from, in the form configmap:NAME or secret:NAME (can be repeated)")
+	fs.StringToStringVar(&o.ResourceLimits, "resource-limits", nil, "resource limits for the operator's containers (e.g. cpu=500m,memory=256Mi)")
+	fs.StringToStringVar(&o.ResourceRequests, "resource-requests", nil, "resource requests for the operator's containers (e.g. cpu=100m,memory=128Mi)")
+	fs.StringToStringVar(&o.NodeSelector, "node-selector", nil, "node selector labels for the operator's pods")
+	fs.StringArrayVar(&o.Tolerations, "toleration", nil, "toleration for the operator's pods, in the form key[=value][:effect[:seconds]] (can be repeated)")
+	fs.StringToStringVar(&o.Annotations, "annotations", nil, "annotations to add to the operator's deployments and pods")
+}
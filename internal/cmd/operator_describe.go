@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/util/sets"
 
-	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/internal/pkg/operator"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
@@ -37,14 +36,14 @@ func newOperatorDescribeCmd(cfg *action.Configuration) *cobra.Command {
 		Use:   "describe <operator>",
 		Short: "Describe an operator",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			// the operator to show details about, provided by the user
 			l.Package = args[0]
 
 			// Find the package manifest and package channel for the operator
 			pms, err := l.Run(cmd.Context())
 			if err != nil {
-				log.Fatal(err)
+				return err
 			}
 
 			// we only expect one item because describe always searches
@@ -54,7 +53,7 @@ func newOperatorDescribeCmd(cfg *action.Configuration) *cobra.Command {
 			pc, err := pm.GetChannel(channel)
 			if err != nil {
 				// the requested channel doesn't exist
-				log.Fatal(err)
+				return err
 			}
 
 			// prepare what we want to print to the console
@@ -93,6 +92,7 @@ func newOperatorDescribeCmd(cfg *action.Configuration) *cobra.Command {
 			for _, v := range out {
 				fmt.Print(v)
 			}
+			return nil
 		},
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/complete"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
@@ -19,19 +20,25 @@ func newOperatorInstallCmd(cfg *action.Configuration) *cobra.Command {
 	i.Logf = log.Printf
 
 	cmd := &cobra.Command{
-		Use:   "install <operator>",
-		Short: "Install an operator",
-		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		Use:               "install <operator>",
+		Short:             "Install an operator",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePackages(cfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			i.Package = args[0]
+			log.SetObject("Operator", i.Package)
 			csv, err := i.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to install operator: %v", err)
+				return fmt.Errorf("failed to install operator: %w", err)
 			}
 			log.Printf("operator %q installed; installed csv is %q", i.Package, csv.Name)
+			log.Done(csv)
+			return nil
 		},
 	}
 	bindOperatorInstallFlags(cmd.Flags(), i)
+	complete.RegisterFlag(cmd, "channel", completeChannels(cfg))
+	complete.RegisterFlag(cmd, "version", completeVersions(cfg))
 
 	return cmd
 }
@@ -41,6 +48,7 @@ func bindOperatorInstallFlags(fs *pflag.FlagSet, i *internalaction.OperatorInsta
 	fs.VarP(&i.Approval, "approval", "a", fmt.Sprintf("approval (%s or %s)", v1alpha1.ApprovalManual, v1alpha1.ApprovalAutomatic))
 	fs.StringVarP(&i.Version, "version", "v", "", "install specific version for operator (default latest)")
 	fs.StringSliceVarP(&i.WatchNamespaces, "watch", "w", []string{}, "namespaces to watch")
-	fs.DurationVar(&i.CleanupTimeout, "cleanup-timeout", time.Minute, "the amount of time to wait before cancelling cleanup")
+	fs.DurationVar(&i.CleanupTimeout, "cleanup-timeout", time.Minute, "the amount of time to wait before cancelling cleanup after a failed install")
 	fs.BoolVarP(&i.CreateOperatorGroup, "create-operator-group", "C", false, "create operator group if necessary")
+	bindSubscriptionConfigFlags(fs, &i.Config)
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -18,19 +19,28 @@ import (
 )
 
 func newOperatorListCmd(cfg *action.Configuration) *cobra.Command {
-	var allNamespaces bool
+	var allNamespaces, health bool
 	l := internalaction.NewOperatorList(cfg)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List installed operators",
-		Args:  cobra.ExactArgs(0),
-		Run: func(cmd *cobra.Command, args []string) {
+		Long: `List installed operators.
+
+With --health, each subscription is joined with the phase of its installed CSV,
+any install plan waiting for manual approval, the health of the catalogs it
+depends on, and its resolution status. Operators with problems are listed
+first.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if allNamespaces {
 				cfg.Namespace = corev1.NamespaceAll
 			}
+			if health {
+				return listOperatorHealth(cmd.Context(), cfg, allNamespaces)
+			}
 			subs, err := l.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("list operators: %v", err)
+				return fmt.Errorf("list operators: %w", err)
 			}
 
 			if len(subs) == 0 {
@@ -39,7 +49,7 @@ func newOperatorListCmd(cfg *action.Configuration) *cobra.Command {
 				} else {
 					log.Printf("No resources found in %s namespace.", cfg.Namespace)
 				}
-				return
+				return nil
 			}
 
 			sort.SliceStable(subs, func(i, j int) bool {
@@ -60,8 +70,59 @@ func newOperatorListCmd(cfg *action.Configuration) *cobra.Command {
 				_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\t%s\n", sub.Spec.Package, ns, sub.Name, sub.Status.InstalledCSV, sub.Status.CurrentCSV, sub.Status.State, duration.HumanDuration(age))
 			}
 			_ = tw.Flush()
+			return nil
 		},
 	}
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list operators in all namespaces")
+	cmd.Flags().BoolVar(&health, "health", false, "show csv phase, pending install plans, catalog health and resolution problems")
 	return cmd
 }
+
+func listOperatorHealth(ctx context.Context, cfg *action.Configuration, allNamespaces bool) error {
+	health, err := internalaction.NewOperatorListHealth(cfg).Run(ctx)
+	if err != nil {
+		return fmt.Errorf("list operators: %w", err)
+	}
+
+	if len(health) == 0 {
+		if cfg.Namespace == corev1.NamespaceAll {
+			log.Print("No resources found")
+		} else {
+			log.Printf("No resources found in %s namespace.", cfg.Namespace)
+		}
+		return nil
+	}
+
+	nsCol := ""
+	if allNamespaces {
+		nsCol = "\tNAMESPACE"
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "PACKAGE%s\tINSTALLED CSV\tCSV PHASE\tPENDING INSTALLPLAN\tAPPROVAL\tCATALOG HEALTH\tPROBLEMS\n", nsCol)
+	for _, h := range health {
+		ns := ""
+		if allNamespaces {
+			ns = "\t" + h.Subscription.Namespace
+		}
+		approval := ""
+		if h.PendingInstallPlan != "" {
+			approval = string(h.Approval)
+			if h.Approved {
+				approval += " (approved)"
+			}
+		}
+		catalogHealth := "Healthy"
+		if len(h.UnhealthyCatalogs) > 0 {
+			catalogHealth = "Unhealthy"
+		} else if len(h.Subscription.Status.CatalogHealth) == 0 {
+			catalogHealth = "Unknown"
+		}
+		problems := strings.Join(h.Problems(), "; ")
+		if problems == "" {
+			problems = "<none>"
+		}
+		_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\t%s\t%s\n", h.Subscription.Spec.Package, ns, valueOrNone(h.Subscription.Status.InstalledCSV), h.CSVPhase, h.PendingInstallPlan, approval, catalogHealth, problems)
+	}
+	_ = tw.Flush()
+	return nil
+}
@@ -24,14 +24,14 @@ func newOperatorListAvailableCmd(cfg *action.Configuration) *cobra.Command {
 		Use:   "list-available",
 		Short: "List operators available to be installed",
 		Args:  cobra.MaximumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 1 {
 				l.Package = args[0]
 			}
 
 			operators, err := l.Run(cmd.Context())
 			if err != nil {
-				log.Fatal(err)
+				return err
 			}
 
 			if len(operators) == 0 {
@@ -40,7 +40,7 @@ func newOperatorListAvailableCmd(cfg *action.Configuration) *cobra.Command {
 				} else {
 					log.Printf("No resources found in %s namespace.\n", cfg.Namespace)
 				}
-				return
+				return nil
 			}
 
 			sort.SliceStable(operators, func(i, j int) bool {
@@ -56,6 +56,7 @@ func newOperatorListAvailableCmd(cfg *action.Configuration) *cobra.Command {
 				}
 			}
 			_ = tw.Flush()
+			return nil
 		},
 	}
 	bindOperatorListAvailableFlags(cmd.Flags(), l)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -15,17 +16,22 @@ import (
 	"k8s.io/apimachinery/pkg/util/duration"
 	"sigs.k8s.io/yaml"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/operand"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
 func newOperatorListOperandsCmd(cfg *action.Configuration) *cobra.Command {
 	l := action.NewOperatorListOperands(cfg)
+	l.Logf = log.Warnf
 	output := ""
+	unhealthy := false
+	all := false
 	validOutputs := []string{"json", "yaml"}
 
 	cmd := &cobra.Command{
-		Use:   "list-operands <operator>",
+		Use:   "list-operands [<operator>]",
 		Short: "List operands of an installed operator",
 		Long: `List operands of an installed operator.
 
@@ -38,9 +44,38 @@ To search for operands for an operator in a different namespace, use the
 --namespace flag. By default, the namespace from the current context is used.
 
 Operand kinds are determined from the owned CustomResourceDefinitions listed in
-the operator's ClusterServiceVersion.`,
-		Args: cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+the operator's ClusterServiceVersion. If the namespace has no operator group or
+several of them, the target namespaces are read from the operator group
+annotations on the ClusterServiceVersion. Operands are still listed when the
+ClusterServiceVersion has not succeeded, with a warning that the list may be
+inaccurate.
+
+For operators installed by OLMv1, the operator is looked up as a
+ClusterExtension named after the package or installing it, and operands are
+listed from the CustomResourceDefinitions the extension installed.
+
+The STATUS column summarizes each operand's status: Reconciling if its
+status.observedGeneration is behind metadata.generation, Degraded, NotReady or
+Unavailable based on its Degraded, Ready and Available conditions, and
+otherwise its Ready or Available condition or status.phase. Use --unhealthy to
+only list operands that have not been reconciled or report errors.
+
+Use --all to list the operands of every operator installed in the cluster,
+grouped by operator. Each CustomResourceDefinition is listed once, even if it is
+owned by several operators. CustomResourceDefinitions owned by more than one
+operator are reported, as are operands of CustomResourceDefinitions that OLM
+installed for an operator that is no longer installed.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return listAllOperands(cmd, l, output, unhealthy)
+			}
+
 			writeOutput := func(io.Writer, *unstructured.UnstructuredList) error { panic("writeOutput was not set") } //nolint:staticcheck
 			switch output {
 			case "json":
@@ -50,37 +85,50 @@ the operator's ClusterServiceVersion.`,
 			case "":
 				writeOutput = writeTable
 			default:
-				log.Fatalf("invalid value for flag output %q, expected one of %s", output, strings.Join(validOutputs, "|"))
+				return exitcode.Validation(fmt.Errorf("invalid value for flag output %q, expected one of %s", output, strings.Join(validOutputs, "|")))
 			}
 
 			operands, err := l.Run(cmd.Context(), args[0])
 			if err != nil {
-				log.Fatalf("list operands: %v", err)
+				return fmt.Errorf("list operands: %w", err)
+			}
+
+			if unhealthy {
+				operands.Items = filterUnhealthy(operands.Items)
 			}
 
 			if len(operands.Items) == 0 {
 				log.Print("No resources found")
-				return
+				return nil
 			}
 
-			if err := writeOutput(os.Stdout, operands); err != nil {
-				log.Fatal(err)
-			}
+			return writeOutput(os.Stdout, operands)
 		},
 	}
 	cmd.Flags().StringVarP(&output, "output", "o", output, fmt.Sprintf("Output format. One of: %s", strings.Join(validOutputs, "|")))
+	cmd.Flags().BoolVarP(&all, "all", "A", false, "list the operands of every installed operator")
+	cmd.Flags().BoolVar(&unhealthy, "unhealthy", false, "only list operands that have not been reconciled or report errors")
 	return cmd
 }
 
+func filterUnhealthy(operands []unstructured.Unstructured) []unstructured.Unstructured {
+	var out []unstructured.Unstructured
+	for _, o := range operands {
+		if !operand.HealthOf(o).Healthy {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
 func writeTable(w io.Writer, operands *unstructured.UnstructuredList) error {
 	var buf bytes.Buffer
 	tw := tabwriter.NewWriter(&buf, 3, 4, 2, ' ', 0)
-	if _, err := fmt.Fprintf(tw, "APIVERSION\tKIND\tNAMESPACE\tNAME\tAGE\n"); err != nil {
+	if _, err := fmt.Fprintf(tw, "APIVERSION\tKIND\tNAMESPACE\tNAME\tSTATUS\tAGE\n"); err != nil {
 		return err
 	}
 	for _, o := range operands.Items {
-		age := time.Since(o.GetCreationTimestamp().Time)
-		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", o.GetAPIVersion(), o.GetKind(), o.GetNamespace(), o.GetName(), duration.HumanDuration(age)); err != nil {
+		if _, err := fmt.Fprintf(tw, "%s\n", operandRow(o)); err != nil {
 			return err
 		}
 	}
@@ -93,6 +141,82 @@ func writeTable(w io.Writer, operands *unstructured.UnstructuredList) error {
 	return nil
 }
 
+func operandRow(o unstructured.Unstructured) string {
+	age := time.Since(o.GetCreationTimestamp().Time)
+	status := operandStatus(operand.HealthOf(o))
+	return fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", o.GetAPIVersion(), o.GetKind(), o.GetNamespace(), o.GetName(), status, duration.HumanDuration(age))
+}
+
+func listAllOperands(cmd *cobra.Command, l *action.OperatorListOperands, output string, unhealthy bool) error {
+	result, err := l.RunAll(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("list operands: %w", err)
+	}
+	if unhealthy {
+		for i := range result.Operators {
+			result.Operators[i].Operands = filterUnhealthy(result.Operators[i].Operands)
+		}
+		for i := range result.Orphaned {
+			result.Orphaned[i].Operands = filterUnhealthy(result.Orphaned[i].Operands)
+		}
+	}
+
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(os.Stdout, string(data))
+		return nil
+	case "yaml":
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, _ = os.Stdout.Write(data)
+		return nil
+	}
+
+	crds := make([]string, 0, len(result.SharedCRDs))
+	for crd := range result.SharedCRDs {
+		crds = append(crds, crd)
+	}
+	sort.Strings(crds)
+	for _, crd := range crds {
+		l.Logf("crd %s is owned by several operators: %s", crd, strings.Join(result.SharedCRDs[crd], ", "))
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 3, 4, 2, ' ', 0)
+	rows := 0
+	_, _ = fmt.Fprintf(tw, "OPERATOR\tAPIVERSION\tKIND\tNAMESPACE\tNAME\tSTATUS\tAGE\n")
+	for _, op := range result.Operators {
+		for _, o := range op.Operands {
+			_, _ = fmt.Fprintf(tw, "%s/%s\t%s\n", op.Namespace, op.Package, operandRow(o))
+			rows++
+		}
+	}
+	for _, orphaned := range result.Orphaned {
+		for _, o := range orphaned.Operands {
+			_, _ = fmt.Fprintf(tw, "<orphaned: %s>\t%s\n", orphaned.Operator, operandRow(o))
+			rows++
+		}
+	}
+	if rows == 0 {
+		log.Print("No resources found")
+		return nil
+	}
+	_ = tw.Flush()
+	return nil
+}
+
+func operandStatus(h operand.Health) string {
+	if h.Message == "" {
+		return h.Status
+	}
+	return fmt.Sprintf("%s (%s)", h.Status, h.Message)
+}
+
 func writeJSON(w io.Writer, operands *unstructured.UnstructuredList) error {
 	out, err := json.Marshal(operands)
 	if err != nil {
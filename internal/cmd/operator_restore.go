@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+func newOperatorRestoreCmd(cfg *action.Configuration) *cobra.Command {
+	r := internalaction.NewOperatorRestore(cfg)
+	r.Logf = log.Printf
+
+	cmd := &cobra.Command{
+		Use:   "restore <backup-dir>",
+		Short: "Reinstall an operator and its operands from a backup",
+		Long: `Restore reinstalls an operator from a backup written by
+'kubectl operator uninstall --backup-dir' and re-applies the saved operands.
+
+The operator is restored into the namespace it was backed up from, at the
+version that was installed when the backup was taken. The saved operator group
+is created if the namespace has none, saved CRDs are created if OLM did not
+install them, and saved operands that already exist on the cluster are left
+untouched.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r.BackupDir = args[0]
+			csv, err := r.Run(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to restore operator: %w", err)
+			}
+			log.Printf("operator restored; installed csv is %q", csv.Name)
+			log.Done(csv)
+			return nil
+		},
+	}
+	return cmd
+}
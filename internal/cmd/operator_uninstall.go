@@ -1,11 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
+	"sigs.k8s.io/yaml"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
 	"github.com/operator-framework/kubectl-operator/internal/pkg/operand"
@@ -13,6 +21,11 @@ import (
 )
 
 func newOperatorUninstallCmd(cfg *action.Configuration) *cobra.Command {
+	var (
+		dryRun bool
+		yes    bool
+		output string
+	)
 	u := internalaction.NewOperatorUninstall(cfg)
 	u.Logf = log.Printf
 
@@ -32,12 +45,29 @@ by its operator group, this search will include namespace-scoped operands from
 the operator group's target namespaces and all cluster-scoped operands.
 
 The operand-deletion strategy is then considered if any operands are found
-on-cluster. One of abort|ignore|delete. By default, the strategy is "abort",
-which means that if any operands are found when deleting the operator abort the
-uninstall without deleting anything. The "ignore" strategy keeps the operands on
-cluster and deletes the subscription and the operator. The "delete" strategy
-deletes the subscription, operands, and after they have finished finalizing, the
-operator itself.
+on-cluster. One of abort|ignore|delete|delete-namespaced. By default, the
+strategy is "abort", which means that if any operands are found when deleting
+the operator abort the uninstall without deleting anything. The "ignore"
+strategy keeps the operands on cluster and deletes the subscription and the
+operator. The "delete" strategy deletes the subscription, operands, and after
+they have finished finalizing, the operator itself. The "delete-namespaced"
+strategy behaves like "delete", but keeps cluster-scoped operands.
+
+Use --operand-selector to delete only some of the operands, orphaning the rest.
+Each selector is either a kind in the form Kind[.version][.group] or a label
+selector (e.g. app=etcd); operands matching any selector are deleted. Setting a
+selector implies the "delete" strategy unless another strategy is given.
+
+Operands with finalizers can keep the uninstall waiting. Use
+--operand-finalizer-timeout to stop waiting for each operand after the given
+duration and report the finalizers blocking it, and add --strip-finalizers to
+remove those finalizers instead.
+
+Use --backup-dir to save the subscription, operatorgroup, owned custom resource
+definitions and all operands to a directory before anything is deleted. Objects
+are saved without status and server-populated metadata, with operands laid out
+by group, version, kind and namespace. The backup can be re-applied with
+'kubectl operator restore'.
 
 Setting --delete-operator-groups to true will delete the operatorgroup in the
 provided namespace if no other active subscriptions are currently in that
@@ -61,31 +91,95 @@ the operator (e.g. RBAC objects for the operator). These flags are:
       This is a convenience flag that is effectively equivalent to the flags
       '--delete-operator=true --delete-operator-groups=true'.
 
+Before deleting anything, uninstall prints the plan: every object it would
+delete or orphan, in the order they are processed. It then asks for
+confirmation, and fails if the answer is not yes. Use --yes to skip the plan
+and the prompt, which is required when stdin is not a terminal, or --dry-run
+to only print the plan.
+
 NOTE: This command does not recursively uninstall unused dependencies. To return
 a cluster to its state prior to a 'kubectl operator install' call, each
 dependency of the operator that was installed automatically by OLM must be
 individually uninstalled.
 `,
 		Args: cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			u.Package = args[0]
-			if err := u.Run(cmd.Context()); err != nil {
-				if errors.Is(err, operand.ErrAbortStrategy) {
-					log.Fatalf("uninstall operator: %v"+"\n\n%s", err,
-						"See kubectl operator uninstall --help for more information on operand deletion strategies.")
+			log.SetObject("Operator", u.Package)
+			if output != "" && output != "yaml" {
+				return exitcode.Validation(fmt.Errorf("unsupported output format %q: allowed formats are (yaml)", output))
+			}
+			ctx := cmd.Context()
+			if dryRun || !yes {
+				if !dryRun && !term.IsTerminal(int(os.Stdin.Fd())) {
+					return exitcode.Validation(errors.New("refusing to uninstall without --yes on a non-interactive terminal"))
+				}
+				plan, err := u.Plan(ctx)
+				if err != nil {
+					return uninstallError(err)
+				}
+				if err := writeUninstallPlan(os.Stdout, plan, output); err != nil {
+					return err
 				}
-				log.Fatalf("uninstall operator: %v", err)
+				if dryRun {
+					return nil
+				}
+				if !confirm(os.Stdin, os.Stdout, fmt.Sprintf("Uninstall operator %q?", u.Package)) {
+					return errors.New("uninstall cancelled")
+				}
+				// Answering can take longer than --timeout, which only bounds the uninstall.
+				timeout, _ := cmd.Flags().GetDuration("timeout")
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), timeout)
+				defer cancel()
+			}
+			if err := u.Run(ctx); err != nil {
+				return uninstallError(err)
 			}
+			log.Done(nil)
+			return nil
 		},
 	}
 	bindOperatorUninstallFlags(cmd.Flags(), u)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the objects that would be deleted or orphaned without uninstalling")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "uninstall without printing the plan and asking for confirmation")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format of the plan. One of: (yaml)")
 	return cmd
 }
 
+func uninstallError(err error) error {
+	if errors.Is(err, operand.ErrAbortStrategy) {
+		return fmt.Errorf("uninstall operator: %w"+"\n\n%s", err,
+			"See kubectl operator uninstall --help for more information on operand deletion strategies.")
+	}
+	return fmt.Errorf("uninstall operator: %w", err)
+}
+
+func writeUninstallPlan(w io.Writer, plan *internalaction.UninstallPlan, output string) error {
+	if output == "yaml" {
+		data, err := yaml.Marshal(plan)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 3, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "ACTION\tAPIVERSION\tKIND\tNAMESPACE\tNAME\n")
+	for _, step := range plan.Steps {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", step.Action, step.APIVersion, step.Kind, valueOrNone(step.Namespace), step.Name)
+	}
+	return tw.Flush()
+}
+
 func bindOperatorUninstallFlags(fs *pflag.FlagSet, u *internalaction.OperatorUninstall) {
 	fs.BoolVarP(&u.DeleteAll, "delete-all", "X", false, "delete all objects associated with the operator, implies --delete-operator, --operand-strategy=delete, --delete-operator-groups")
 	fs.BoolVar(&u.DeleteOperator, "delete-operator", false, "delete operator object associated with the operator, --operand-strategy=delete")
 	fs.BoolVar(&u.DeleteOperatorGroups, "delete-operator-groups", false, "delete operator groups if no other operators remain")
 	fs.StringSliceVar(&u.DeleteOperatorGroupNames, "delete-operator-group-names", nil, "specific operator group names to delete (only effective with --delete-operator-groups)")
-	fs.VarP(&u.OperandStrategy, "operand-strategy", "s", "determines how to handle operands when deleting the operator, one of abort|ignore|delete (default: abort)")
+	fs.VarP(&u.OperandStrategy, "operand-strategy", "s", "determines how to handle operands when deleting the operator, one of abort|ignore|delete|delete-namespaced (default: abort)")
+	fs.StringArrayVar(&u.OperandSelector, "operand-selector", nil, "only delete operands matching the kind (Kind[.version][.group]) or label selector, orphaning the rest (can be repeated)")
+	fs.DurationVar(&u.OperandFinalizerTimeout, "operand-finalizer-timeout", 0, "the amount of time to wait for each operand to finalize (0 waits until --timeout)")
+	fs.StringVar(&u.BackupDir, "backup-dir", "", "directory to back up the operator's subscription, operatorgroup, owned CRDs and operands to before deleting them")
+	fs.BoolVar(&u.StripFinalizers, "strip-finalizers", false, "remove the finalizers of operands that did not finalize within --operand-finalizer-timeout")
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -11,17 +13,22 @@ import (
 
 func newOperatorUpgradeCmd(cfg *action.Configuration) *cobra.Command {
 	u := internalaction.NewOperatorUpgrade(cfg)
+	u.Logf = log.Printf
+
 	cmd := &cobra.Command{
 		Use:   "upgrade <operator>",
 		Short: "Upgrade an operator",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			u.Package = args[0]
+			log.SetObject("Operator", u.Package)
 			csv, err := u.Run(cmd.Context())
 			if err != nil {
-				log.Fatalf("failed to upgrade operator: %v", err)
+				return fmt.Errorf("failed to upgrade operator: %w", err)
 			}
 			log.Printf("operator %q upgraded; installed csv is %q", u.Package, csv.Name)
+			log.Done(csv)
+			return nil
 		},
 	}
 	bindOperatorUpgradeFlags(cmd.Flags(), u)
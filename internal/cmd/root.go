@@ -2,19 +2,48 @@ package cmd
 
 import (
 	"context"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/exitcode"
 	"github.com/operator-framework/kubectl-operator/internal/cmd/internal/log"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+// Execute runs the command selected by the arguments. If it fails, the error is logged and the
+// process exits with the code exitcode.For returns for it.
 func Execute() {
-	if err := newCmd().Execute(); err != nil {
-		log.Fatal(err)
+	if code := execute(context.Background(), newCmd()); code != 0 {
+		os.Exit(int(code))
 	}
 }
+
+// execute runs cmd and logs the error it fails with, if any. It returns the code the process
+// exits with, which is 0 if cmd succeeded.
+func execute(ctx context.Context, cmd *cobra.Command) exitcode.Code {
+	ctx, cancel := context.WithCancel(ctx)
+	cmd, err := cmd.ExecuteContextC(ctx)
+	cancel()
+	if err == nil {
+		return 0
+	}
+	// Usage is only silenced once the arguments and flags have been parsed, so an error
+	// returned before that is caused by them.
+	if !cmd.SilenceUsage {
+		err = exitcode.Validation(err)
+	}
+	code := exitcode.For(err)
+	log.Failed(err, int(code))
+	return code
+}
+
+// loadConfig loads the configuration commands use to reach the cluster. Tests replace it to run
+// commands against a fake cluster.
+var loadConfig = (*action.Configuration).Load
+
 func newCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "operator",
@@ -24,8 +53,22 @@ func newCmd() *cobra.Command {
 kubectl operator helps you manage operator installations in your
 cluster. It can install and uninstall operator catalogs, list
 operators available for installation, and install and uninstall
-operators from the installed catalogs.`,
+operators from the installed catalogs.
+
+Exit codes:
+  0  the command succeeded
+  1  the command failed
+  2  invalid arguments or flags
+  3  an object, package or catalog was not found
+  4  an object to create already exists
+  5  timed out waiting for the cluster
+  6  an update would not change anything
+  7  a command acting on several objects failed for some of them`,
+		SilenceErrors: true,
 	}
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return exitcode.Validation(err)
+	})
 
 	var (
 		cfg     action.Configuration
@@ -36,14 +79,33 @@ operators from the installed catalogs.`,
 	flags := cmd.PersistentFlags()
 	cfg.BindFlags(flags)
 	flags.DurationVar(&timeout, "timeout", 1*time.Minute, "The amount of time to wait before giving up on an operation.")
+	log.BindFlags(flags)
 
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		if isCompletionCmd(cmd) {
+			// Completion functions load the configuration from the flags of the command being
+			// completed, and generating a completion script needs no cluster.
+			cancel = func() {}
+			return nil
+		}
+		if err := log.Configure(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")); err != nil {
+			return exitcode.Validation(err)
+		}
+		cmd.SilenceUsage = true
+
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(cmd.Context(), timeout)
 
 		cmd.SetContext(ctx)
 
-		return cfg.Load()
+		if isOfflineCmd(cmd) {
+			return nil
+		}
+		if err := loadConfig(&cfg); err != nil {
+			return err
+		}
+		log.Debugf("using namespace %q with a timeout of %s", cfg.Namespace, timeout)
+		return nil
 	}
 	cmd.PersistentPostRun = func(command *cobra.Command, _ []string) {
 		cancel()
@@ -53,7 +115,10 @@ operators from the installed catalogs.`,
 		newCatalogCmd(&cfg),
 		newOperatorInstallCmd(&cfg),
 		newOperatorUpgradeCmd(&cfg),
+		newOperatorConfigureCmd(&cfg),
+		newOperatorApproveCmd(&cfg),
 		newOperatorUninstallCmd(&cfg),
+		newOperatorRestoreCmd(&cfg),
 		newOperatorListCmd(&cfg),
 		newOperatorListAvailableCmd(&cfg),
 		newOperatorListOperandsCmd(&cfg),
@@ -64,3 +129,21 @@ operators from the installed catalogs.`,
 
 	return cmd
 }
+
+// offlineAnnotation marks commands that do not need a cluster, so that they run without a
+// kubeconfig. The configuration is not loaded before they run; those that may still query the
+// cluster load it with loadConfig when they need it.
+const offlineAnnotation = "operators.operatorframework.io/offline"
+
+func isOfflineCmd(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[offlineAnnotation]
+	return ok
+}
+
+func isCompletionCmd(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return cmd.HasParent() && cmd.Parent().Name() == "completion"
+}
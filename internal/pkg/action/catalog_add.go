@@ -3,6 +3,7 @@ package action
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -37,11 +38,10 @@ type CatalogAdd struct {
 	DisplayName       string
 	Publisher         string
 	CleanupTimeout    time.Duration
+	Config            catalogsource.ConfigOptions
+	ImageRegistry
 
-	Logf            func(string, ...interface{})
-	RegistryOptions []containerdregistry.RegistryOption
-
-	registry *containerdregistry.Registry
+	Logf func(string, ...interface{})
 }
 
 func NewCatalogAdd(cfg *action.Configuration) *CatalogAdd {
@@ -52,18 +52,11 @@ func NewCatalogAdd(cfg *action.Configuration) *CatalogAdd {
 }
 
 func (a *CatalogAdd) Run(ctx context.Context) (*v1alpha1.CatalogSource, error) {
-	var err error
-	a.registry, err = containerdregistry.NewRegistry(a.RegistryOptions...)
+	configOpts, err := a.Config.Options()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("catalogsource config: %v", err)
 	}
 
-	defer func() {
-		if err := a.registry.Destroy(); err != nil {
-			a.Logf("registry cleanup: %v", err)
-		}
-	}()
-
 	csKey := types.NamespacedName{
 		Namespace: a.config.Namespace,
 		Name:      a.CatalogSourceName,
@@ -81,12 +74,28 @@ func (a *CatalogAdd) Run(ctx context.Context) (*v1alpha1.CatalogSource, error) {
 		catalogsource.Publisher(a.Publisher),
 		catalogsource.Image(a.IndexImage),
 	}
+	opts = append(opts, configOpts...)
+
+	secret, err := a.pullSecretFor(csKey, a.IndexImage)
+	if err != nil {
+		return nil, fmt.Errorf("build pull secret: %v", err)
+	}
+	if secret != nil {
+		opts = append(opts, catalogsource.Secrets(secret.Name))
+	}
 
 	cs := catalogsource.Build(csKey, opts...)
 	if err := a.config.Client.Create(ctx, cs); err != nil {
 		return nil, fmt.Errorf("create catalogsource: %v", err)
 	}
 
+	if secret != nil {
+		if err := a.createPullSecret(ctx, cs, secret); err != nil {
+			defer a.cleanup(cs)
+			return nil, err
+		}
+	}
+
 	if err := a.waitForCatalogSourceReady(ctx, cs); err != nil {
 		defer a.cleanup(cs)
 		return nil, err
@@ -95,39 +104,40 @@ func (a *CatalogAdd) Run(ctx context.Context) (*v1alpha1.CatalogSource, error) {
 	return cs, nil
 }
 
+// labelsFor pulls the index image and returns its labels.
 func (a *CatalogAdd) labelsFor(ctx context.Context, indexImage string) (map[string]string, error) {
-	ref := image.SimpleReference(indexImage)
-	if err := a.registry.Pull(ctx, ref); err != nil {
-		return nil, fmt.Errorf("pull image: %v", err)
-	}
-
-	ctx = namespaces.WithNamespace(ctx, namespaces.Default)
-	img, err := a.registry.Images().Get(ctx, ref.String())
-	if err != nil {
-		return nil, fmt.Errorf("get image from local registry: %v", err)
-	}
+	var labels map[string]string
+	err := a.withImage(ctx, indexImage, a.Logf, func(ctx context.Context, reg *containerdregistry.Registry, ref image.Reference) error {
+		ctx = namespaces.WithNamespace(ctx, namespaces.Default)
+		img, err := reg.Images().Get(ctx, ref.String())
+		if err != nil {
+			return fmt.Errorf("get image from local registry: %v", err)
+		}
 
-	manifest, err := images.Manifest(ctx, a.registry.Content(), img.Target, platforms.All)
-	if err != nil {
-		return nil, fmt.Errorf("resolve image manifest: %v", err)
-	}
+		manifest, err := images.Manifest(ctx, reg.Content(), img.Target, platforms.All)
+		if err != nil {
+			return fmt.Errorf("resolve image manifest: %v", err)
+		}
 
-	ra, err := a.registry.Content().ReaderAt(ctx, manifest.Config)
-	if err != nil {
-		return nil, fmt.Errorf("get image reader: %v", err)
-	}
-	defer ra.Close()
+		ra, err := reg.Content().ReaderAt(ctx, manifest.Config)
+		if err != nil {
+			return fmt.Errorf("get image reader: %v", err)
+		}
+		defer ra.Close()
 
-	decompressed, err := compression.DecompressStream(io.NewSectionReader(ra, 0, ra.Size()))
-	if err != nil {
-		return nil, fmt.Errorf("decompress image data: %v", err)
-	}
-	var imageMeta ocispec.Image
-	dec := json.NewDecoder(decompressed)
-	if err := dec.Decode(&imageMeta); err != nil {
-		return nil, fmt.Errorf("decode image metadata: %v", err)
-	}
-	return imageMeta.Config.Labels, nil
+		decompressed, err := compression.DecompressStream(io.NewSectionReader(ra, 0, ra.Size()))
+		if err != nil {
+			return fmt.Errorf("decompress image data: %v", err)
+		}
+		var imageMeta ocispec.Image
+		dec := json.NewDecoder(decompressed)
+		if err := dec.Decode(&imageMeta); err != nil {
+			return fmt.Errorf("decode image metadata: %v", err)
+		}
+		labels = imageMeta.Config.Labels
+		return nil
+	})
+	return labels, err
 }
 
 func (a *CatalogAdd) setDefaults(labels map[string]string) {
@@ -145,6 +155,7 @@ func (a *CatalogAdd) setDefaults(labels map[string]string) {
 
 func (a *CatalogAdd) waitForCatalogSourceReady(ctx context.Context, cs *v1alpha1.CatalogSource) error {
 	csKey := objectKeyForObject(cs)
+	var lastPodCheck time.Time
 	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
 		if err := a.config.Client.Get(conditionCtx, csKey, cs); err != nil {
 			return false, err
@@ -154,8 +165,22 @@ func (a *CatalogAdd) waitForCatalogSourceReady(ctx context.Context, cs *v1alpha1
 				return true, nil
 			}
 		}
+		// Fail fast when the catalog pod cannot become ready rather than
+		// waiting for the context to expire. Pods are checked less often
+		// than the catalog source, since that lists their events.
+		if time.Since(lastPodCheck) < catalogPodCheckInterval {
+			return false, nil
+		}
+		lastPodCheck = time.Now()
+		if failure := a.catalogPodFailure(conditionCtx, cs); failure != nil {
+			return false, failure
+		}
 		return false, nil
 	}); err != nil {
+		var podErr *ErrCatalogPodFailed
+		if errors.As(err, &podErr) {
+			return err
+		}
 		return fmt.Errorf("catalogsource connection not ready: %v", err)
 	}
 	return nil
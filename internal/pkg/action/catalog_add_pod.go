@@ -0,0 +1,278 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	catalogPodEventLimit    = 5
+	catalogPodLogLines      = 20
+	catalogPodCheckInterval = 2 * time.Second
+
+	// catalogReadinessFailureThreshold is the number of failed readiness probes after which a
+	// running registry server is considered to have failed. Probes fail while the index loads,
+	// so a few failures are tolerated.
+	catalogReadinessFailureThreshold = 5
+	// catalogReadinessGracePeriod is how long a registry server may fail its readiness probe
+	// after starting before it is considered to have failed.
+	catalogReadinessGracePeriod = 45 * time.Second
+)
+
+// ErrCatalogPodFailed is returned when the pod serving a catalog source fails in a way that
+// prevents the catalog from becoming ready, like an image that cannot be pulled, a crashing or
+// OOM-killed registry server, or one that keeps failing its readiness probe.
+type ErrCatalogPodFailed struct {
+	Pod       string
+	Namespace string
+	Container string
+	Reason    string
+	Message   string
+
+	// Events are the most recent events of the pod, oldest first.
+	Events []string
+	// Logs is the tail of the failing container's logs, if available.
+	Logs string
+}
+
+func (e ErrCatalogPodFailed) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "catalog pod %q", e.Pod)
+	if e.Container != "" {
+		fmt.Fprintf(&sb, " container %q", e.Container)
+	}
+	fmt.Fprintf(&sb, " failed: %s", e.Reason)
+	if e.Message != "" {
+		fmt.Fprintf(&sb, ": %s", e.Message)
+	}
+	if len(e.Events) > 0 {
+		sb.WriteString("\nrecent events:")
+		for _, ev := range e.Events {
+			fmt.Fprintf(&sb, "\n  %s", ev)
+		}
+	}
+	if e.Logs != "" {
+		sb.WriteString("\ncontainer logs:")
+		for _, line := range strings.Split(strings.TrimRight(e.Logs, "\n"), "\n") {
+			fmt.Fprintf(&sb, "\n  %s", line)
+		}
+	}
+	return sb.String()
+}
+
+// catalogPodFailure returns an error describing why the catalog source's pod cannot become ready,
+// or nil if no pod has failed (yet). Errors looking up the pod are ignored so that the catalog
+// source's own status remains the source of truth.
+func (a *CatalogAdd) catalogPodFailure(ctx context.Context, cs *v1alpha1.CatalogSource) *ErrCatalogPodFailed {
+	pods := corev1.PodList{}
+	if err := a.config.Client.List(ctx, &pods,
+		client.InNamespace(cs.Namespace),
+		client.MatchingLabels{catalogSourceLabel: cs.Name},
+	); err != nil {
+		return nil
+	}
+	now := time.Now()
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		events := a.podEvents(ctx, pod)
+		failure := podFailure(pod, events, now)
+		if failure == nil {
+			continue
+		}
+		if len(events) > catalogPodEventLimit {
+			events = events[len(events)-catalogPodEventLimit:]
+		}
+		failure.Events = formatEvents(events)
+		if s, ok := containerStatus(pod, failure.Container); ok {
+			// A container waiting to be restarted has no logs of its own yet.
+			previous := s.State.Waiting != nil && s.LastTerminationState.Terminated != nil
+			failure.Logs = a.containerLogs(ctx, pod, failure.Container, previous)
+		}
+		return failure
+	}
+	return nil
+}
+
+// podFailure checks the pod's container statuses and events for failures that will not resolve
+// on their own. A single failed image pull is retried by the kubelet, so only the back-off that
+// follows repeated failures is reported. A container that was OOM-killed is reported as such
+// rather than by the crash loop that follows, and readiness probe failures are only reported
+// once they persist, since the registry server fails them while it loads the index.
+func podFailure(pod *corev1.Pod, events []corev1.Event, now time.Time) *ErrCatalogPodFailed {
+	failure := func(s corev1.ContainerStatus, reason, message string) *ErrCatalogPodFailed {
+		return &ErrCatalogPodFailed{
+			Pod:       pod.Name,
+			Namespace: pod.Namespace,
+			Container: s.Name,
+			Reason:    reason,
+			Message:   message,
+		}
+	}
+	for _, s := range podContainerStatuses(pod) {
+		if t := oomKilled(s); t != nil {
+			message := t.Message
+			if message == "" {
+				message = fmt.Sprintf("container exceeded its memory limit (exit code %d, restarted %d times)", t.ExitCode, s.RestartCount)
+			}
+			return failure(s, t.Reason, message)
+		}
+		if w := s.State.Waiting; w != nil {
+			switch w.Reason {
+			case "ImagePullBackOff", "InvalidImageName", "CrashLoopBackOff":
+				return failure(s, w.Reason, w.Message)
+			}
+		}
+		if message, ok := readinessFailure(s, events, now); ok {
+			return failure(s, "Unhealthy", message)
+		}
+	}
+	return nil
+}
+
+func podContainerStatuses(pod *corev1.Pod) []corev1.ContainerStatus {
+	return append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+}
+
+func containerStatus(pod *corev1.Pod, name string) (corev1.ContainerStatus, bool) {
+	for _, s := range podContainerStatuses(pod) {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
+
+// oomKilled returns the termination of the container's current or previous instance if it was
+// killed for running out of memory.
+func oomKilled(s corev1.ContainerStatus) *corev1.ContainerStateTerminated {
+	for _, t := range []*corev1.ContainerStateTerminated{s.State.Terminated, s.LastTerminationState.Terminated} {
+		if t != nil && t.Reason == "OOMKilled" {
+			return t
+		}
+	}
+	return nil
+}
+
+// readinessFailure reports whether a running container that is not ready has failed its
+// readiness probe catalogReadinessFailureThreshold times since it started, or still fails it
+// after catalogReadinessGracePeriod. It returns the message of the latest failure.
+func readinessFailure(s corev1.ContainerStatus, events []corev1.Event, now time.Time) (string, bool) {
+	if s.Ready || s.State.Running == nil {
+		return "", false
+	}
+	started := s.State.Running.StartedAt.Time
+	var (
+		failures int32
+		message  string
+	)
+	for _, ev := range events {
+		if ev.Reason != "Unhealthy" || !strings.HasPrefix(ev.Message, "Readiness probe failed") ||
+			containerForFieldPath(ev.InvolvedObject.FieldPath) != s.Name || eventTime(ev).Before(started) {
+			continue
+		}
+		failures += eventCount(ev)
+		message = ev.Message
+	}
+	if failures == 0 {
+		return "", false
+	}
+	if failures >= catalogReadinessFailureThreshold || now.Sub(started) > catalogReadinessGracePeriod {
+		return fmt.Sprintf("%s (%d failed probes, container started %s ago)", message, failures, now.Sub(started).Round(time.Second)), true
+	}
+	return "", false
+}
+
+// containerForFieldPath returns the name of the container an event's field path refers to, like
+// "registry-server" for "spec.containers{registry-server}".
+func containerForFieldPath(fieldPath string) string {
+	for _, prefix := range []string{"spec.containers{", "spec.initContainers{"} {
+		if name, ok := strings.CutPrefix(fieldPath, prefix); ok {
+			return strings.TrimSuffix(name, "}")
+		}
+	}
+	return ""
+}
+
+// eventCount returns how many times an event occurred.
+func eventCount(ev corev1.Event) int32 {
+	if ev.Series != nil && ev.Series.Count > ev.Count {
+		return ev.Series.Count
+	}
+	if ev.Count > 0 {
+		return ev.Count
+	}
+	return 1
+}
+
+// podEvents returns the events of the pod, oldest first.
+func (a *CatalogAdd) podEvents(ctx context.Context, pod *corev1.Pod) []corev1.Event {
+	list := corev1.EventList{}
+	if err := a.config.Client.List(ctx, &list,
+		client.InNamespace(pod.Namespace),
+		client.MatchingFields{"involvedObject.name": pod.Name},
+	); err != nil {
+		return nil
+	}
+	var events []corev1.Event
+	for _, ev := range list.Items {
+		if ev.InvolvedObject.Kind == "Pod" {
+			events = append(events, ev)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	return events
+}
+
+func eventTime(ev corev1.Event) time.Time {
+	if !ev.LastTimestamp.IsZero() {
+		return ev.LastTimestamp.Time
+	}
+	return ev.EventTime.Time
+}
+
+func formatEvents(events []corev1.Event) []string {
+	out := make([]string, 0, len(events))
+	for _, ev := range events {
+		out = append(out, fmt.Sprintf("%s %s: %s", ev.Type, ev.Reason, ev.Message))
+	}
+	return out
+}
+
+// containerLogs returns the tail of the container's logs. For containers that crashed, the logs
+// of the previous instance are returned. Logs are best effort: errors yield no logs.
+func (a *CatalogAdd) containerLogs(ctx context.Context, pod *corev1.Pod, container string, previous bool) string {
+	if a.config.Config == nil {
+		return ""
+	}
+	cs, err := kubernetes.NewForConfig(a.config.Config)
+	if err != nil {
+		return ""
+	}
+	tail := int64(catalogPodLogLines)
+	rc, err := cs.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &tail,
+		Previous:  previous,
+	}).Stream(ctx)
+	if err != nil {
+		return ""
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,155 @@
+package action
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("podFailure", func() {
+	pod := func(status corev1.PodStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "catalog-abcde", Namespace: "olm"},
+			Status:     status,
+		}
+	}
+	waiting := func(container, reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  container,
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: reason + " message"}},
+		}
+	}
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	running := func(container string, since time.Duration) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  container,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(now.Add(-since))}},
+		}
+	}
+	oomKilled := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}}
+	unhealthy := func(container, probe string, ago time.Duration, count int32) corev1.Event {
+		return corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "catalog-abcde", FieldPath: "spec.containers{" + container + "}"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Unhealthy",
+			Message:        probe + " probe failed: timeout: failed to connect service \":50051\" within 1s",
+			LastTimestamp:  metav1.NewTime(now.Add(-ago)),
+			Count:          count,
+		}
+	}
+
+	DescribeTable("reports only failures that do not resolve on their own",
+		func(status corev1.PodStatus, events []corev1.Event, expected *ErrCatalogPodFailed) {
+			failure := podFailure(pod(status), events, now)
+			if expected == nil {
+				Expect(failure).To(BeNil())
+				return
+			}
+			Expect(failure).To(Equal(expected))
+		},
+		Entry("running pod", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "registry-server",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		}, nil, nil),
+		Entry("pod without container statuses", corev1.PodStatus{}, nil, nil),
+		Entry("image being pulled", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{waiting("registry-server", "ContainerCreating")},
+		}, nil, nil),
+		Entry("single failed image pull", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{waiting("registry-server", "ErrImagePull")},
+		}, nil, nil),
+		Entry("image pull back-off", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{waiting("registry-server", "ImagePullBackOff")},
+		}, nil, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "registry-server",
+			Reason: "ImagePullBackOff", Message: "ImagePullBackOff message",
+		}),
+		Entry("invalid image name", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{waiting("registry-server", "InvalidImageName")},
+		}, nil, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "registry-server",
+			Reason: "InvalidImageName", Message: "InvalidImageName message",
+		}),
+		Entry("crash loop back-off", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{waiting("registry-server", "CrashLoopBackOff")},
+		}, nil, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "registry-server",
+			Reason: "CrashLoopBackOff", Message: "CrashLoopBackOff message",
+		}),
+		Entry("failing init container", corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{waiting("extract-content", "CrashLoopBackOff")},
+			ContainerStatuses:     []corev1.ContainerStatus{waiting("registry-server", "PodInitializing")},
+		}, nil, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "extract-content",
+			Reason: "CrashLoopBackOff", Message: "CrashLoopBackOff message",
+		}),
+		Entry("OOM-killed container", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "registry-server", State: oomKilled}},
+		}, nil, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "registry-server",
+			Reason: "OOMKilled", Message: "container exceeded its memory limit (exit code 137, restarted 0 times)",
+		}),
+		Entry("crash loop after an OOM kill", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "registry-server",
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: oomKilled,
+				RestartCount:         3,
+			}},
+		}, nil, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "registry-server",
+			Reason: "OOMKilled", Message: "container exceeded its memory limit (exit code 137, restarted 3 times)",
+		}),
+		Entry("crash loop after an error", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "registry-server",
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			}},
+		}, nil, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "registry-server",
+			Reason: "CrashLoopBackOff",
+		}),
+		Entry("readiness probe failing while the index loads", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{running("registry-server", 30*time.Second)},
+		}, []corev1.Event{unhealthy("registry-server", "Readiness", time.Second, 2)}, nil),
+		Entry("readiness probe failing repeatedly", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{running("registry-server", 30*time.Second)},
+		}, []corev1.Event{unhealthy("registry-server", "Readiness", time.Second, 5)}, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "registry-server",
+			Reason:  "Unhealthy",
+			Message: "Readiness probe failed: timeout: failed to connect service \":50051\" within 1s (5 failed probes, container started 30s ago)",
+		}),
+		Entry("readiness probe failing past the grace period", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{running("registry-server", time.Minute)},
+		}, []corev1.Event{unhealthy("registry-server", "Readiness", time.Second, 1)}, &ErrCatalogPodFailed{
+			Pod: "catalog-abcde", Namespace: "olm", Container: "registry-server",
+			Reason:  "Unhealthy",
+			Message: "Readiness probe failed: timeout: failed to connect service \":50051\" within 1s (1 failed probes, container started 1m0s ago)",
+		}),
+		Entry("readiness probe failures of a previous container", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{running("registry-server", time.Minute)},
+		}, []corev1.Event{unhealthy("registry-server", "Readiness", 2*time.Minute, 10)}, nil),
+		Entry("ready container with past readiness probe failures", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{func() corev1.ContainerStatus {
+				s := running("registry-server", time.Minute)
+				s.Ready = true
+				return s
+			}()},
+		}, []corev1.Event{unhealthy("registry-server", "Readiness", time.Second, 10)}, nil),
+		Entry("liveness probe failures", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{running("registry-server", time.Minute)},
+		}, []corev1.Event{unhealthy("registry-server", "Liveness", time.Second, 10)}, nil),
+		Entry("readiness probe failures of another container", corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{running("registry-server", time.Minute)},
+		}, []corev1.Event{unhealthy("sidecar", "Readiness", time.Second, 10)}, nil),
+	)
+})
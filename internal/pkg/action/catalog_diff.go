@@ -0,0 +1,444 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/model"
+	"github.com/operator-framework/operator-registry/pkg/image"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1/catalogd"
+)
+
+// CatalogDiffResult describes the changes between two versions of a catalog.
+type CatalogDiffResult struct {
+	AddedPackages   []string      `json:"addedPackages,omitempty"`
+	RemovedPackages []string      `json:"removedPackages,omitempty"`
+	Packages        []PackageDiff `json:"packages,omitempty"`
+
+	// Affected lists the installed operators affected by the changes.
+	Affected []AffectedInstall `json:"affected,omitempty"`
+}
+
+// PackageDiff describes the changes to a package present in both versions of a catalog.
+type PackageDiff struct {
+	Package           string              `json:"package"`
+	OldDefaultChannel string              `json:"oldDefaultChannel,omitempty"`
+	NewDefaultChannel string              `json:"newDefaultChannel,omitempty"`
+	AddedChannels     []string            `json:"addedChannels,omitempty"`
+	RemovedChannels   []string            `json:"removedChannels,omitempty"`
+	MovedHeads        []ChannelHeadChange `json:"movedHeads,omitempty"`
+	AddedBundles      []string            `json:"addedBundles,omitempty"`
+	RemovedBundles    []string            `json:"removedBundles,omitempty"`
+	DeprecatedBundles []string            `json:"deprecatedBundles,omitempty"`
+}
+
+// DefaultChannelChanged returns true if the package's default channel changed.
+func (d PackageDiff) DefaultChannelChanged() bool {
+	return d.OldDefaultChannel != d.NewDefaultChannel
+}
+
+func (d PackageDiff) empty() bool {
+	return !d.DefaultChannelChanged() && len(d.AddedChannels) == 0 && len(d.RemovedChannels) == 0 &&
+		len(d.MovedHeads) == 0 && len(d.AddedBundles) == 0 && len(d.RemovedBundles) == 0 &&
+		len(d.DeprecatedBundles) == 0
+}
+
+// ChannelHeadChange describes a channel whose head bundle changed.
+type ChannelHeadChange struct {
+	Channel string `json:"channel"`
+	OldHead string `json:"oldHead"`
+	NewHead string `json:"newHead"`
+}
+
+// AffectedInstall is a Subscription or ClusterExtension affected by a catalog change.
+type AffectedInstall struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Package   string   `json:"package"`
+	Reasons   []string `json:"reasons"`
+}
+
+// CatalogDiff compares two versions of a catalog. Each version can be a file-based catalog
+// directory, the name of a serving ClusterCatalog, or an index image reference.
+//
+// The installs affected by the changes are the ClusterExtensions whose catalog selector matches
+// a diffed ClusterCatalog. If CheckInstalls is set, they also include the Subscriptions to the
+// CatalogSources and the ClusterExtensions selecting the ClusterCatalogs that serve a diffed
+// index image. Directories are not served by any catalog in the cluster.
+type CatalogDiff struct {
+	config *action.Configuration
+
+	Old               string
+	New               string
+	CatalogdNamespace string
+	// CheckInstalls looks up the installs from diffed index images in the cluster.
+	CheckInstalls bool
+	// Catalogd fetches the contents of ClusterCatalogs. If nil, catalogd is reached by port
+	// forwarding.
+	Catalogd catalogd.Client
+	ImageRegistry
+
+	Logf func(string, ...interface{})
+}
+
+func NewCatalogDiff(cfg *action.Configuration) *CatalogDiff {
+	return &CatalogDiff{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (d *CatalogDiff) Run(ctx context.Context) (*CatalogDiffResult, error) {
+	oldModel, oldSource, err := d.load(ctx, d.Old)
+	if err != nil {
+		return nil, fmt.Errorf("load %q: %v", d.Old, err)
+	}
+	newModel, newSource, err := d.load(ctx, d.New)
+	if err != nil {
+		return nil, fmt.Errorf("load %q: %v", d.New, err)
+	}
+
+	result := diffModels(oldModel, newModel)
+	if result.Affected, err = d.affectedInstalls(ctx, result, oldSource, newSource); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// catalogSource is where a diffed version of a catalog was loaded from. At most one of its
+// fields is set, and none for a directory.
+type catalogSource struct {
+	clusterCatalog *olmv1.ClusterCatalog
+	image          string
+}
+
+// load reads a catalog from a directory, a ClusterCatalog or an index image, in that order.
+func (d *CatalogDiff) load(ctx context.Context, ref string) (model.Model, catalogSource, error) {
+	var (
+		dcfg   *declcfg.DeclarativeConfig
+		source catalogSource
+		err    error
+	)
+	if fi, statErr := os.Stat(ref); statErr == nil && fi.IsDir() {
+		dcfg, err = declcfg.LoadFS(ctx, os.DirFS(ref))
+	} else if cc, ok, getErr := d.clusterCatalog(ctx, ref); getErr != nil {
+		return nil, source, getErr
+	} else if ok {
+		source.clusterCatalog = cc
+		dcfg, err = d.loadClusterCatalog(ctx, cc)
+	} else {
+		source.image = ref
+		err = d.withImage(ctx, ref, d.Logf, func(ctx context.Context, reg *containerdregistry.Registry, imgRef image.Reference) error {
+			var loadErr error
+			dcfg, loadErr = loadIndexImage(ctx, reg, imgRef)
+			return loadErr
+		})
+	}
+	if err != nil {
+		return nil, source, err
+	}
+	m, err := declcfg.ConvertToModel(*dcfg)
+	return m, source, err
+}
+
+// NeedsCluster reports whether the diff queries the cluster, either to look up the installs to
+// flag or because Old or New may name a ClusterCatalog. Directories and index images are
+// otherwise diffed without a cluster, and the configuration's client may be left unset.
+func (d *CatalogDiff) NeedsCluster() bool {
+	return d.CheckInstalls || mayNameClusterCatalog(d.Old) || mayNameClusterCatalog(d.New)
+}
+
+// mayNameClusterCatalog reports whether ref is not a directory and is a valid ClusterCatalog name.
+func mayNameClusterCatalog(ref string) bool {
+	if fi, err := os.Stat(ref); err == nil && fi.IsDir() {
+		return false
+	}
+	return len(validation.IsDNS1123Subdomain(ref)) == 0
+}
+
+// clusterCatalog returns the ClusterCatalog with the given name, if one exists.
+func (d *CatalogDiff) clusterCatalog(ctx context.Context, name string) (*olmv1.ClusterCatalog, bool, error) {
+	if d.config.Client == nil || !mayNameClusterCatalog(name) {
+		return nil, false, nil
+	}
+	cc := &olmv1.ClusterCatalog{}
+	err := d.config.Client.Get(ctx, types.NamespacedName{Name: name}, cc)
+	switch {
+	case err == nil:
+		return cc, true, nil
+	case apierrors.IsNotFound(err), apierrors.IsInvalid(err), apierrors.IsBadRequest(err), meta.IsNoMatchError(err):
+		return nil, false, nil
+	}
+	return nil, false, fmt.Errorf("get clustercatalog %q: %v", name, err)
+}
+
+// servingCatalogs returns the CatalogSources and the ClusterCatalogs that serve the diffed
+// versions of the catalog. The catalogs serving an index image are only looked up if
+// CheckInstalls is set.
+func (d *CatalogDiff) servingCatalogs(ctx context.Context, sources ...catalogSource) (sets.Set[types.NamespacedName], []olmv1.ClusterCatalog, error) {
+	catalogSources := sets.New[types.NamespacedName]()
+	var clusterCatalogs []olmv1.ClusterCatalog
+	images := sets.New[string]()
+	for _, source := range sources {
+		switch {
+		case source.clusterCatalog != nil:
+			clusterCatalogs = append(clusterCatalogs, *source.clusterCatalog)
+		case source.image != "" && d.CheckInstalls:
+			images.Insert(source.image)
+		}
+	}
+	if images.Len() == 0 {
+		return catalogSources, clusterCatalogs, nil
+	}
+
+	css := v1alpha1.CatalogSourceList{}
+	if err := d.config.Client.List(ctx, &css); err != nil && !meta.IsNoMatchError(err) {
+		return nil, nil, fmt.Errorf("list catalogsources: %v", err)
+	}
+	for _, cs := range css.Items {
+		if images.Has(cs.Spec.Image) {
+			catalogSources.Insert(types.NamespacedName{Namespace: cs.Namespace, Name: cs.Name})
+		}
+	}
+	ccs := olmv1.ClusterCatalogList{}
+	if err := d.config.Client.List(ctx, &ccs); err != nil && !meta.IsNoMatchError(err) {
+		return nil, nil, fmt.Errorf("list clustercatalogs: %v", err)
+	}
+	for _, cc := range ccs.Items {
+		if cc.Spec.Source.Image != nil && images.Has(cc.Spec.Source.Image.Ref) {
+			clusterCatalogs = append(clusterCatalogs, cc)
+		}
+	}
+	return catalogSources, clusterCatalogs, nil
+}
+
+func (d *CatalogDiff) loadClusterCatalog(ctx context.Context, cc *olmv1.ClusterCatalog) (*declcfg.DeclarativeConfig, error) {
+	if !meta.IsStatusConditionPresentAndEqual(cc.Status.Conditions, olmv1.TypeServing, metav1.ConditionTrue) {
+		return nil, fmt.Errorf("clustercatalog %q is not serving", cc.Name)
+	}
+	catalogdClient := d.Catalogd
+	if catalogdClient == nil {
+		catalogdClient = catalogd.NewK8sClient(d.config.Config, d.config.Client, d.CatalogdNamespace)
+	}
+	contents, err := catalogdClient.V1().All(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+	defer contents.Close()
+	return declcfg.LoadReader(contents)
+}
+
+func diffModels(oldModel, newModel model.Model) *CatalogDiffResult {
+	result := &CatalogDiffResult{}
+	oldPkgs, newPkgs := sets.KeySet(oldModel), sets.KeySet(newModel)
+	result.AddedPackages = sets.List(newPkgs.Difference(oldPkgs))
+	result.RemovedPackages = sets.List(oldPkgs.Difference(newPkgs))
+
+	for _, name := range sets.List(oldPkgs.Intersection(newPkgs)) {
+		if pd := diffPackage(oldModel[name], newModel[name]); !pd.empty() {
+			result.Packages = append(result.Packages, pd)
+		}
+	}
+	return result
+}
+
+func diffPackage(oldPkg, newPkg *model.Package) PackageDiff {
+	pd := PackageDiff{Package: newPkg.Name}
+	if oldPkg.DefaultChannel != nil {
+		pd.OldDefaultChannel = oldPkg.DefaultChannel.Name
+	}
+	if newPkg.DefaultChannel != nil {
+		pd.NewDefaultChannel = newPkg.DefaultChannel.Name
+	}
+
+	oldChannels, newChannels := sets.KeySet(oldPkg.Channels), sets.KeySet(newPkg.Channels)
+	pd.AddedChannels = sets.List(newChannels.Difference(oldChannels))
+	pd.RemovedChannels = sets.List(oldChannels.Difference(newChannels))
+	for _, ch := range sets.List(oldChannels.Intersection(newChannels)) {
+		oldHead, newHead := channelHead(oldPkg.Channels[ch]), channelHead(newPkg.Channels[ch])
+		if oldHead != newHead {
+			pd.MovedHeads = append(pd.MovedHeads, ChannelHeadChange{Channel: ch, OldHead: oldHead, NewHead: newHead})
+		}
+	}
+
+	oldBundles, newBundles := packageBundles(oldPkg), packageBundles(newPkg)
+	pd.AddedBundles = sets.List(sets.KeySet(newBundles).Difference(sets.KeySet(oldBundles)))
+	pd.RemovedBundles = sets.List(sets.KeySet(oldBundles).Difference(sets.KeySet(newBundles)))
+	for name, b := range newBundles {
+		if b.Deprecation == nil {
+			continue
+		}
+		if old, ok := oldBundles[name]; !ok || old.Deprecation == nil {
+			pd.DeprecatedBundles = append(pd.DeprecatedBundles, name)
+		}
+	}
+	sort.Strings(pd.DeprecatedBundles)
+	return pd
+}
+
+func channelHead(ch *model.Channel) string {
+	head, err := ch.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Name
+}
+
+// packageBundles returns the bundles of every channel of the package by name.
+func packageBundles(pkg *model.Package) map[string]*model.Bundle {
+	bundles := map[string]*model.Bundle{}
+	for _, ch := range pkg.Channels {
+		for name, b := range ch.Bundles {
+			if existing, ok := bundles[name]; ok && existing.Deprecation != nil {
+				continue
+			}
+			bundles[name] = b
+		}
+	}
+	return bundles
+}
+
+// affectedInstalls returns the Subscriptions and ClusterExtensions in the cluster that install a
+// package changed by the diff from a catalog serving one of its versions, along with the reasons
+// they are affected. APIs that are not installed in the cluster are skipped.
+func (d *CatalogDiff) affectedInstalls(ctx context.Context, result *CatalogDiffResult, sources ...catalogSource) ([]AffectedInstall, error) {
+	catalogSources, clusterCatalogs, err := d.servingCatalogs(ctx, sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := sets.New[string](result.RemovedPackages...)
+	changed := map[string]PackageDiff{}
+	for _, pd := range result.Packages {
+		changed[pd.Package] = pd
+	}
+
+	var affected []AffectedInstall
+	subs := v1alpha1.SubscriptionList{}
+	if catalogSources.Len() > 0 {
+		if err := d.config.Client.List(ctx, &subs); err != nil && !meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("list subscriptions: %v", err)
+		}
+	}
+	for _, sub := range subs.Items {
+		if sub.Spec == nil || !catalogSources.Has(types.NamespacedName{Namespace: sub.Spec.CatalogSourceNamespace, Name: sub.Spec.CatalogSource}) {
+			continue
+		}
+		var channels []string
+		if sub.Spec.Channel != "" {
+			channels = []string{sub.Spec.Channel}
+		}
+		reasons := installReasons(sub.Spec.Package, channels, sub.Status.InstalledCSV, removed, changed)
+		if len(reasons) > 0 {
+			affected = append(affected, AffectedInstall{
+				Kind:      v1alpha1.SubscriptionKind,
+				Namespace: sub.Namespace,
+				Name:      sub.Name,
+				Package:   sub.Spec.Package,
+				Reasons:   reasons,
+			})
+		}
+	}
+
+	exts := olmv1.ClusterExtensionList{}
+	if len(clusterCatalogs) > 0 {
+		if err := d.config.Client.List(ctx, &exts); err != nil && !meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("list clusterextensions: %v", err)
+		}
+	}
+	for _, ext := range exts.Items {
+		if ext.Spec.Source.Catalog == nil || !selectsAny(ext.Spec.Source.Catalog.Selector, clusterCatalogs) {
+			continue
+		}
+		installed := ""
+		if ext.Status.Install != nil {
+			installed = ext.Status.Install.Bundle.Name
+		}
+		pkg := ext.Spec.Source.Catalog.PackageName
+		reasons := installReasons(pkg, ext.Spec.Source.Catalog.Channels, installed, removed, changed)
+		if len(reasons) > 0 {
+			affected = append(affected, AffectedInstall{
+				Kind:    olmv1.ClusterExtensionKind,
+				Name:    ext.Name,
+				Package: pkg,
+				Reasons: reasons,
+			})
+		}
+	}
+	return affected, nil
+}
+
+// selectsAny returns true if the catalog selector of a ClusterExtension, which selects every
+// catalog if nil, matches one of ccs.
+func selectsAny(selector *metav1.LabelSelector, ccs []olmv1.ClusterCatalog) bool {
+	sel := labels.Everything()
+	if selector != nil {
+		var err error
+		if sel, err = metav1.LabelSelectorAsSelector(selector); err != nil {
+			return false
+		}
+	}
+	for _, cc := range ccs {
+		if sel.Matches(labels.Set(cc.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// installReasons describes how the changes affect an install of pkg that follows channels (or
+// the default channel, if none) and has the installed bundle.
+func installReasons(pkg string, channels []string, installed string, removed sets.Set[string], changed map[string]PackageDiff) []string {
+	if removed.Has(pkg) {
+		return []string{"package removed"}
+	}
+	pd, ok := changed[pkg]
+	if !ok {
+		return nil
+	}
+
+	var reasons []string
+	if len(channels) == 0 {
+		if pd.DefaultChannelChanged() {
+			reasons = append(reasons, fmt.Sprintf("default channel changed from %q to %q", pd.OldDefaultChannel, pd.NewDefaultChannel))
+		}
+		channels = []string{pd.OldDefaultChannel}
+	}
+	removedChannels := sets.New[string](pd.RemovedChannels...)
+	for _, ch := range channels {
+		if removedChannels.Has(ch) {
+			reasons = append(reasons, fmt.Sprintf("channel %q removed", ch))
+		}
+		for _, h := range pd.MovedHeads {
+			if h.Channel == ch {
+				reasons = append(reasons, fmt.Sprintf("channel %q head moved from %q to %q", ch, h.OldHead, h.NewHead))
+			}
+		}
+	}
+	if installed != "" {
+		if sets.New[string](pd.RemovedBundles...).Has(installed) {
+			reasons = append(reasons, fmt.Sprintf("installed bundle %q removed", installed))
+		}
+		if sets.New[string](pd.DeprecatedBundles...).Has(installed) {
+			reasons = append(reasons, fmt.Sprintf("installed bundle %q deprecated", installed))
+		}
+	}
+	return reasons
+}
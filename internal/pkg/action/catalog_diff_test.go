@@ -0,0 +1,263 @@
+package action_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1/catalogd"
+)
+
+const oldCatalog = `---
+schema: olm.package
+name: etcd
+defaultChannel: alpha
+---
+schema: olm.channel
+package: etcd
+name: alpha
+entries:
+- name: etcd.v1.0.0
+---
+schema: olm.channel
+package: etcd
+name: beta
+entries:
+- name: etcd.v1.0.0
+---
+schema: olm.bundle
+package: etcd
+name: etcd.v1.0.0
+image: quay.io/example/etcd-bundle:v1.0.0
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 1.0.0
+---
+schema: olm.package
+name: redis
+defaultChannel: stable
+---
+schema: olm.channel
+package: redis
+name: stable
+entries:
+- name: redis.v1.0.0
+---
+schema: olm.bundle
+package: redis
+name: redis.v1.0.0
+image: quay.io/example/redis-bundle:v1.0.0
+properties:
+- type: olm.package
+  value:
+    packageName: redis
+    version: 1.0.0
+`
+
+const newCatalog = `---
+schema: olm.package
+name: etcd
+defaultChannel: stable
+---
+schema: olm.channel
+package: etcd
+name: alpha
+entries:
+- name: etcd.v1.0.0
+- name: etcd.v1.1.0
+  replaces: etcd.v1.0.0
+---
+schema: olm.channel
+package: etcd
+name: stable
+entries:
+- name: etcd.v1.1.0
+---
+schema: olm.bundle
+package: etcd
+name: etcd.v1.0.0
+image: quay.io/example/etcd-bundle:v1.0.0
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 1.0.0
+---
+schema: olm.bundle
+package: etcd
+name: etcd.v1.1.0
+image: quay.io/example/etcd-bundle:v1.1.0
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 1.1.0
+---
+schema: olm.deprecations
+package: etcd
+entries:
+- reference:
+    schema: olm.bundle
+    name: etcd.v1.0.0
+  message: etcd.v1.0.0 is deprecated
+---
+schema: olm.package
+name: nginx
+defaultChannel: stable
+---
+schema: olm.channel
+package: nginx
+name: stable
+entries:
+- name: nginx.v1.0.0
+---
+schema: olm.bundle
+package: nginx
+name: nginx.v1.0.0
+image: quay.io/example/nginx-bundle:v1.0.0
+properties:
+- type: olm.package
+  value:
+    packageName: nginx
+    version: 1.0.0
+`
+
+var _ = Describe("CatalogDiff", func() {
+	var (
+		cfg    action.Configuration
+		oldDir string
+		newDir string
+	)
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		writeCatalog := func(contents string) string {
+			dir, err := os.MkdirTemp("", "catalog-diff-")
+			Expect(err).To(BeNil())
+			Expect(os.WriteFile(filepath.Join(dir, "catalog.yaml"), []byte(contents), 0600)).To(Succeed())
+			return dir
+		}
+		oldDir, newDir = writeCatalog(oldCatalog), writeCatalog(newCatalog)
+
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd", Channel: "alpha", CatalogSource: "operatorhubio", CatalogSourceNamespace: "olm"},
+			Status:     v1alpha1.SubscriptionStatus{InstalledCSV: "etcd.v1.0.0"},
+		}
+		clusterCatalog := func(name string) *olmv1.ClusterCatalog {
+			return &olmv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"olm.operatorframework.io/metadata.name": name}},
+				Status: olmv1.ClusterCatalogStatus{
+					Conditions: []metav1.Condition{{Type: olmv1.TypeServing, Status: metav1.ConditionTrue}},
+				},
+			}
+		}
+		clusterExtension := func(name string, selector *metav1.LabelSelector) *olmv1.ClusterExtension {
+			return &olmv1.ClusterExtension{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: olmv1.ClusterExtensionSpec{
+					Source: olmv1.SourceConfig{
+						SourceType: olmv1.SourceTypeCatalog,
+						Catalog:    &olmv1.CatalogFilter{PackageName: "etcd", Channels: []string{"alpha"}, Selector: selector},
+					},
+				},
+			}
+		}
+		selectCatalog := func(name string) *metav1.LabelSelector {
+			return &metav1.LabelSelector{MatchLabels: map[string]string{"olm.operatorframework.io/metadata.name": name}}
+		}
+
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(
+				sub,
+				clusterCatalog("old"), clusterCatalog("new"), clusterCatalog("other"),
+				clusterExtension("etcd-any", nil),
+				clusterExtension("etcd-new", selectCatalog("new")),
+				clusterExtension("etcd-other", selectCatalog("other")),
+			).
+			WithScheme(sch).
+			Build()
+		cfg.Scheme = sch
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(oldDir)).To(Succeed())
+		Expect(os.RemoveAll(newDir)).To(Succeed())
+	})
+
+	It("should report changes between catalog directories", func() {
+		differ := internalaction.NewCatalogDiff(&cfg)
+		differ.Old, differ.New = oldDir, newDir
+		result, err := differ.Run(context.TODO())
+		Expect(err).To(BeNil())
+
+		Expect(result.AddedPackages).To(Equal([]string{"nginx"}))
+		Expect(result.RemovedPackages).To(Equal([]string{"redis"}))
+		Expect(result.Packages).To(HaveLen(1))
+
+		pd := result.Packages[0]
+		Expect(pd.Package).To(Equal("etcd"))
+		Expect(pd.OldDefaultChannel).To(Equal("alpha"))
+		Expect(pd.NewDefaultChannel).To(Equal("stable"))
+		Expect(pd.AddedChannels).To(Equal([]string{"stable"}))
+		Expect(pd.RemovedChannels).To(Equal([]string{"beta"}))
+		Expect(pd.MovedHeads).To(ConsistOf(internalaction.ChannelHeadChange{Channel: "alpha", OldHead: "etcd.v1.0.0", NewHead: "etcd.v1.1.0"}))
+		Expect(pd.AddedBundles).To(Equal([]string{"etcd.v1.1.0"}))
+		Expect(pd.RemovedBundles).To(BeEmpty())
+		Expect(pd.DeprecatedBundles).To(Equal([]string{"etcd.v1.0.0"}))
+
+		Expect(result.Affected).To(BeEmpty())
+	})
+
+	It("should flag the cluster extensions selecting the diffed cluster catalogs", func() {
+		differ := internalaction.NewCatalogDiff(&cfg)
+		differ.Old, differ.New = "old", "new"
+		differ.Catalogd = fakeCatalogd{"old": oldCatalog, "new": newCatalog}
+		result, err := differ.Run(context.TODO())
+		Expect(err).To(BeNil())
+
+		Expect(result.AddedPackages).To(Equal([]string{"nginx"}))
+		Expect(result.Affected).To(ConsistOf(
+			internalaction.AffectedInstall{
+				Kind:    olmv1.ClusterExtensionKind,
+				Name:    "etcd-any",
+				Package: "etcd",
+				Reasons: []string{`channel "alpha" head moved from "etcd.v1.0.0" to "etcd.v1.1.0"`},
+			},
+			internalaction.AffectedInstall{
+				Kind:    olmv1.ClusterExtensionKind,
+				Name:    "etcd-new",
+				Package: "etcd",
+				Reasons: []string{`channel "alpha" head moved from "etcd.v1.0.0" to "etcd.v1.1.0"`},
+			},
+		))
+	})
+})
+
+// fakeCatalogd serves the contents of ClusterCatalogs by name.
+type fakeCatalogd map[string]string
+
+func (c fakeCatalogd) V1() catalogd.V1Client {
+	return c
+}
+
+func (c fakeCatalogd) All(_ context.Context, cc *olmv1.ClusterCatalog) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(c[cc.Name])), nil
+}
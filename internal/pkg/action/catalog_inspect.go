@@ -0,0 +1,109 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/containertools"
+	"github.com/operator-framework/operator-registry/pkg/image"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+)
+
+// CatalogInspect reads the file-based catalog from an index image without adding the catalog
+// to a cluster.
+type CatalogInspect struct {
+	IndexImage string
+	Package    string
+	ImageRegistry
+
+	Logf func(string, ...interface{})
+}
+
+func NewCatalogInspect() *CatalogInspect {
+	return &CatalogInspect{
+		Logf: func(string, ...interface{}) {},
+	}
+}
+
+func (i *CatalogInspect) Run(ctx context.Context) (*declcfg.DeclarativeConfig, error) {
+	var dcfg *declcfg.DeclarativeConfig
+	if err := i.withImage(ctx, i.IndexImage, i.Logf, func(ctx context.Context, reg *containerdregistry.Registry, ref image.Reference) error {
+		var err error
+		dcfg, err = loadIndexImage(ctx, reg, ref)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if i.Package == "" {
+		return dcfg, nil
+	}
+	filtered := filterDeclCfgPackage(dcfg, i.Package)
+	if len(filtered.Packages) == 0 {
+		return nil, fmt.Errorf("package %q was not found in index image %q", i.Package, i.IndexImage)
+	}
+	return filtered, nil
+}
+
+// loadIndexImage unpacks the pulled index image and loads the file-based catalog found in the
+// directory named by its configs label.
+func loadIndexImage(ctx context.Context, reg *containerdregistry.Registry, ref image.Reference) (*declcfg.DeclarativeConfig, error) {
+	labels, err := reg.Labels(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("get image labels: %v", err)
+	}
+	configsDir, ok := labels[containertools.ConfigsLocationLabel]
+	if !ok {
+		return nil, fmt.Errorf("image %q is not a file-based catalog: label %q not found", ref, containertools.ConfigsLocationLabel)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "kubectl-operator-catalog-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := reg.Unpack(ctx, ref, tmpDir); err != nil {
+		return nil, fmt.Errorf("unpack image: %v", err)
+	}
+
+	root := filepath.Join(tmpDir, filepath.Clean("/"+strings.TrimPrefix(configsDir, "/")))
+	dcfg, err := declcfg.LoadFS(ctx, os.DirFS(root))
+	if err != nil {
+		return nil, fmt.Errorf("load catalog from %q: %v", configsDir, err)
+	}
+	return dcfg, nil
+}
+
+func filterDeclCfgPackage(dcfg *declcfg.DeclarativeConfig, packageName string) *declcfg.DeclarativeConfig {
+	filtered := &declcfg.DeclarativeConfig{}
+	for _, p := range dcfg.Packages {
+		if p.Name == packageName {
+			filtered.Packages = append(filtered.Packages, p)
+		}
+	}
+	for _, c := range dcfg.Channels {
+		if c.Package == packageName {
+			filtered.Channels = append(filtered.Channels, c)
+		}
+	}
+	for _, b := range dcfg.Bundles {
+		if b.Package == packageName {
+			filtered.Bundles = append(filtered.Bundles, b)
+		}
+	}
+	for _, d := range dcfg.Deprecations {
+		if d.Package == packageName {
+			filtered.Deprecations = append(filtered.Deprecations, d)
+		}
+	}
+	for _, o := range dcfg.Others {
+		if o.Package == packageName {
+			filtered.Others = append(filtered.Others, o)
+		}
+	}
+	return filtered
+}
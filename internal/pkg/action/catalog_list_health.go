@@ -0,0 +1,143 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// catalogSourceLabel is the label OLM sets on the pods serving a catalog source.
+const catalogSourceLabel = "olm.catalogSource"
+
+// CatalogHealth joins a catalog source with the state of its registry server.
+type CatalogHealth struct {
+	CatalogSource v1alpha1.CatalogSource
+
+	// ConnectionState is the last observed state of OLM's gRPC connection to the catalog.
+	ConnectionState string
+	// Address is the address of the catalog's registry service.
+	Address string
+	// LastUpdated is the last time OLM polled the catalog's image for updates or, if the
+	// catalog is not polled, the time its registry service was created.
+	LastUpdated *metav1.Time
+	// ImageDigest is the image ID reported by the catalog's running registry pod.
+	ImageDigest string
+	// Packages is the number of packages the catalog currently provides.
+	Packages int
+}
+
+// Healthy returns true if OLM is connected to the catalog's registry server.
+func (h CatalogHealth) Healthy() bool {
+	return h.ConnectionState == "READY"
+}
+
+// CatalogListHealth lists catalog sources along with their health.
+type CatalogListHealth struct {
+	config *action.Configuration
+}
+
+func NewCatalogListHealth(cfg *action.Configuration) *CatalogListHealth {
+	return &CatalogListHealth{cfg}
+}
+
+// Run returns the health of every catalog source. Unhealthy catalogs are sorted first, followed
+// by healthy catalogs, each group ordered by name.
+func (l *CatalogListHealth) Run(ctx context.Context) ([]CatalogHealth, error) {
+	catalogs, err := NewCatalogList(l.config).Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	packages, err := l.packagesByCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := make([]CatalogHealth, 0, len(catalogs))
+	for _, cs := range catalogs {
+		h := CatalogHealth{
+			CatalogSource: cs,
+			Packages:      packages[objectKeyForObject(&cs)].Len(),
+			LastUpdated:   cs.Status.LatestImageRegistryPoll,
+		}
+		if s := cs.Status.GRPCConnectionState; s != nil {
+			h.ConnectionState = s.LastObservedState
+		}
+		if s := cs.Status.RegistryServiceStatus; s != nil {
+			h.Address = s.Address()
+			if h.LastUpdated == nil && !s.CreatedAt.IsZero() {
+				h.LastUpdated = s.CreatedAt.DeepCopy()
+			}
+		}
+		if h.ImageDigest, err = l.imageDigest(ctx, cs); err != nil {
+			return nil, err
+		}
+		health = append(health, h)
+	}
+
+	sort.SliceStable(health, func(i, j int) bool {
+		if health[i].Healthy() != health[j].Healthy() {
+			return !health[i].Healthy()
+		}
+		if health[i].CatalogSource.Name != health[j].CatalogSource.Name {
+			return health[i].CatalogSource.Name < health[j].CatalogSource.Name
+		}
+		return health[i].CatalogSource.Namespace < health[j].CatalogSource.Namespace
+	})
+	return health, nil
+}
+
+// packagesByCatalog returns the names of the packages provided by each catalog. Packages of
+// global catalogs are listed once per namespace, so they are deduplicated by name.
+func (l *CatalogListHealth) packagesByCatalog(ctx context.Context) (map[types.NamespacedName]sets.Set[string], error) {
+	pms := operatorsv1.PackageManifestList{}
+	if err := l.config.Client.List(ctx, &pms, client.InNamespace(l.config.Namespace)); err != nil {
+		return nil, fmt.Errorf("list packagemanifests: %v", err)
+	}
+	packages := map[types.NamespacedName]sets.Set[string]{}
+	for _, pm := range pms.Items {
+		key := types.NamespacedName{Namespace: pm.Status.CatalogSourceNamespace, Name: pm.Status.CatalogSource}
+		if packages[key] == nil {
+			packages[key] = sets.New[string]()
+		}
+		packages[key].Insert(pm.Name)
+	}
+	return packages, nil
+}
+
+// imageDigest returns the image ID of the catalog's running registry pod, if any.
+func (l *CatalogListHealth) imageDigest(ctx context.Context, cs v1alpha1.CatalogSource) (string, error) {
+	if cs.Spec.SourceType != v1alpha1.SourceTypeGrpc || cs.Spec.Image == "" {
+		return "", nil
+	}
+	pods := corev1.PodList{}
+	if err := l.config.Client.List(ctx, &pods,
+		client.InNamespace(cs.Namespace),
+		client.MatchingLabels{catalogSourceLabel: cs.Name},
+	); err != nil {
+		return "", fmt.Errorf("list pods for catalogsource %q: %v", cs.Name, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, c := range pod.Status.ContainerStatuses {
+			if _, digest, ok := strings.Cut(c.ImageID, "@"); ok {
+				return digest, nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,94 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("CatalogListHealth", func() {
+	var cfg action.Configuration
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+		Expect(corev1.AddToScheme(sch)).To(Succeed())
+
+		healthy := &v1alpha1.CatalogSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "aaa", Namespace: "olm"},
+			Spec:       v1alpha1.CatalogSourceSpec{SourceType: v1alpha1.SourceTypeGrpc, Image: "quay.io/example/aaa:latest"},
+			Status: v1alpha1.CatalogSourceStatus{
+				GRPCConnectionState: &v1alpha1.GRPCConnectionState{LastObservedState: "READY"},
+				RegistryServiceStatus: &v1alpha1.RegistryServiceStatus{
+					ServiceName:      "aaa",
+					ServiceNamespace: "olm",
+					Port:             "50051",
+				},
+			},
+		}
+		healthyPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "aaa-xyz", Namespace: "olm", Labels: map[string]string{"olm.catalogSource": "aaa"}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					ImageID: "quay.io/example/aaa@sha256:0123",
+				}},
+			},
+		}
+		unhealthy := &v1alpha1.CatalogSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "zzz", Namespace: "olm"},
+			Spec:       v1alpha1.CatalogSourceSpec{SourceType: v1alpha1.SourceTypeGrpc, Image: "quay.io/example/zzz:latest"},
+			Status: v1alpha1.CatalogSourceStatus{
+				GRPCConnectionState: &v1alpha1.GRPCConnectionState{LastObservedState: "TRANSIENT_FAILURE"},
+			},
+		}
+
+		objs := []client.Object{healthy, healthyPod, unhealthy}
+		for _, name := range []string{"etcd", "redis"} {
+			for _, ns := range []string{"olm", "default"} {
+				objs = append(objs, &operatorsv1.PackageManifest{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+					Status: operatorsv1.PackageManifestStatus{
+						CatalogSource:          "aaa",
+						CatalogSourceNamespace: "olm",
+					},
+				})
+			}
+		}
+
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(objs...).
+			WithScheme(sch).
+			Build()
+		cfg.Scheme = sch
+		cfg.Namespace = "olm"
+	})
+
+	It("should sort unhealthy catalogs first", func() {
+		health, err := internalaction.NewCatalogListHealth(&cfg).Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(health).To(HaveLen(2))
+
+		Expect(health[0].CatalogSource.Name).To(Equal("zzz"))
+		Expect(health[0].Healthy()).To(BeFalse())
+		Expect(health[0].Packages).To(Equal(0))
+
+		Expect(health[1].CatalogSource.Name).To(Equal("aaa"))
+		Expect(health[1].Healthy()).To(BeTrue())
+		Expect(health[1].Address).To(Equal("aaa.olm.svc:50051"))
+		Expect(health[1].ImageDigest).To(Equal("sha256:0123"))
+		Expect(health[1].Packages).To(Equal(2))
+	})
+})
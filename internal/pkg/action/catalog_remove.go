@@ -3,21 +3,73 @@ package action
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+// ErrCatalogInUse is returned when removing a catalog source that subscriptions still use.
+type ErrCatalogInUse struct {
+	Catalog       types.NamespacedName
+	Subscriptions []v1alpha1.Subscription
+}
+
+func (e ErrCatalogInUse) Error() string {
+	return fmt.Sprintf("catalogsource %q is used by %d subscription(s)", e.Catalog, len(e.Subscriptions))
+}
+
+// ErrMigrationIncomplete is returned when some subscriptions could not be migrated to the
+// replacement catalog. The catalog is not removed.
+type ErrMigrationIncomplete struct {
+	Target   types.NamespacedName
+	Migrated []types.NamespacedName
+	Failed   []SubscriptionError
+}
+
+// SubscriptionError is the error a subscription failed with.
+type SubscriptionError struct {
+	Subscription types.NamespacedName
+	Err          error
+}
+
+func (e ErrMigrationIncomplete) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "migrated %d of %d subscription(s) to catalogsource %q", len(e.Migrated), len(e.Migrated)+len(e.Failed), e.Target)
+	for _, sub := range e.Migrated {
+		fmt.Fprintf(&sb, "\n  migrated: %s", sub)
+	}
+	for _, f := range e.Failed {
+		fmt.Fprintf(&sb, "\n  not migrated: %s: %v", f.Subscription, f.Err)
+	}
+	return sb.String()
+}
+
 type CatalogRemove struct {
 	config *action.Configuration
 
 	CatalogName string
+	// Force removes the catalog even if subscriptions use it.
+	Force bool
+	// MigrateTo is a catalog that replaces the removed catalog in the subscriptions that use it.
+	// It must provide the packages and channels of those subscriptions.
+	MigrateTo NamespacedName
+
+	Logf func(string, ...interface{})
 }
 
 func NewCatalogRemove(cfg *action.Configuration) *CatalogRemove {
 	return &CatalogRemove{
 		config: cfg,
+		Logf:   func(string, ...interface{}) {},
 	}
 }
 
@@ -25,8 +77,171 @@ func (r *CatalogRemove) Run(ctx context.Context) error {
 	cs := v1alpha1.CatalogSource{}
 	cs.SetNamespace(r.config.Namespace)
 	cs.SetName(r.CatalogName)
+
+	subs, err := r.dependentSubscriptions(ctx, objectKeyForObject(&cs))
+	if err != nil {
+		return err
+	}
+	if len(subs) > 0 {
+		switch {
+		case r.MigrateTo.Name != "":
+			if err := r.migrate(ctx, subs); err != nil {
+				return err
+			}
+		case r.Force:
+			r.Logf("removing catalogsource %q used by %d subscription(s)", cs.Name, len(subs))
+		default:
+			return &ErrCatalogInUse{Catalog: objectKeyForObject(&cs), Subscriptions: subs}
+		}
+	}
+
 	if err := r.config.Client.Delete(ctx, &cs); err != nil {
 		return fmt.Errorf("delete catalogsource %q: %v", cs.Name, err)
 	}
 	return waitForDeletion(ctx, r.config.Client, &cs)
 }
+
+// dependentSubscriptions returns the subscriptions in all namespaces that use the catalog.
+func (r *CatalogRemove) dependentSubscriptions(ctx context.Context, catalog types.NamespacedName) ([]v1alpha1.Subscription, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := r.config.Client.List(ctx, &subs, client.InNamespace(corev1.NamespaceAll)); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %v", err)
+	}
+	var dependents []v1alpha1.Subscription
+	for _, sub := range subs.Items {
+		if sub.Spec == nil {
+			continue
+		}
+		if sub.Spec.CatalogSource == catalog.Name && sub.Spec.CatalogSourceNamespace == catalog.Namespace {
+			dependents = append(dependents, sub)
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		if dependents[i].Namespace != dependents[j].Namespace {
+			return dependents[i].Namespace < dependents[j].Namespace
+		}
+		return dependents[i].Name < dependents[j].Name
+	})
+	return dependents, nil
+}
+
+// migrate points the subscriptions at the replacement catalog, after checking that each
+// subscription can resolve its package and channel from it. Subscriptions are updated one at a
+// time; if some updates fail, the others are still attempted and the outcome of each is returned
+// in an *ErrMigrationIncomplete.
+func (r *CatalogRemove) migrate(ctx context.Context, subs []v1alpha1.Subscription) error {
+	target := r.MigrateTo.NamespacedName
+	if target.Namespace == "" {
+		target.Namespace = r.config.Namespace
+	}
+	if target.Name == r.CatalogName && target.Namespace == r.config.Namespace {
+		return fmt.Errorf("cannot migrate subscriptions to the catalog being removed")
+	}
+	if err := r.config.Client.Get(ctx, target, &v1alpha1.CatalogSource{}); err != nil {
+		return fmt.Errorf("get replacement catalogsource %q: %v", target, err)
+	}
+
+	// OLM only resolves subscriptions from catalogs in their own namespace and in the global
+	// catalog namespace, and the package server lists the packages of exactly those catalogs
+	// in each namespace. The packages of the target are listed in its own namespace too, to
+	// tell an unreachable catalog from one that does not provide a package.
+	packages := map[string]map[string]operatorsv1.PackageManifest{}
+	packagesIn := func(namespace string) (map[string]operatorsv1.PackageManifest, error) {
+		if provided, ok := packages[namespace]; ok {
+			return provided, nil
+		}
+		provided, err := r.catalogPackages(ctx, target, namespace)
+		if err != nil {
+			return nil, err
+		}
+		packages[namespace] = provided
+		return provided, nil
+	}
+	own, err := packagesIn(target.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for _, sub := range subs {
+		provided, err := packagesIn(sub.Namespace)
+		if err != nil {
+			return err
+		}
+		if len(provided) == 0 && len(own) > 0 {
+			problems = append(problems, fmt.Sprintf("%s/%s: catalogsource is neither in namespace %q nor in the global catalog namespace", sub.Namespace, sub.Name, sub.Namespace))
+			continue
+		}
+		if msg := missingFromCatalog(provided, sub); msg != "" {
+			problems = append(problems, fmt.Sprintf("%s/%s: %s", sub.Namespace, sub.Name, msg))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("catalogsource %q cannot replace %q:\n  %s", target, r.CatalogName, strings.Join(problems, "\n  "))
+	}
+
+	incomplete := &ErrMigrationIncomplete{Target: target}
+	for _, sub := range subs {
+		sub := sub
+		key := objectKeyForObject(&sub)
+		if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			if err := r.config.Client.Get(ctx, key, &sub); err != nil {
+				return err
+			}
+			sub.Spec.CatalogSource = target.Name
+			sub.Spec.CatalogSourceNamespace = target.Namespace
+			return r.config.Client.Update(ctx, &sub)
+		}); err != nil {
+			incomplete.Failed = append(incomplete.Failed, SubscriptionError{Subscription: key, Err: err})
+			continue
+		}
+		incomplete.Migrated = append(incomplete.Migrated, key)
+		r.Logf("subscription %q migrated to catalogsource %q", key, target)
+	}
+	if len(incomplete.Failed) > 0 {
+		return incomplete
+	}
+	return nil
+}
+
+// catalogPackages returns the packages of the catalog that are listed in the namespace, by name.
+func (r *CatalogRemove) catalogPackages(ctx context.Context, catalog types.NamespacedName, namespace string) (map[string]operatorsv1.PackageManifest, error) {
+	pms := operatorsv1.PackageManifestList{}
+	if err := r.config.Client.List(ctx, &pms,
+		client.InNamespace(namespace),
+		client.MatchingLabels{"catalog": catalog.Name, "catalog-namespace": catalog.Namespace},
+	); err != nil {
+		return nil, fmt.Errorf("list packages of catalogsource %q in namespace %q: %v", catalog, namespace, err)
+	}
+	provided := map[string]operatorsv1.PackageManifest{}
+	for _, pm := range pms.Items {
+		name := pm.Status.PackageName
+		if name == "" {
+			name = pm.Name
+		}
+		provided[name] = pm
+	}
+	return provided, nil
+}
+
+// missingFromCatalog describes what the catalog is missing to serve the subscription, or returns
+// an empty string if it provides the subscription's package and channel.
+func missingFromCatalog(provided map[string]operatorsv1.PackageManifest, sub v1alpha1.Subscription) string {
+	pm, ok := provided[sub.Spec.Package]
+	if !ok {
+		return fmt.Sprintf("package %q not found", sub.Spec.Package)
+	}
+	channel := sub.Spec.Channel
+	if channel == "" {
+		if pm.GetDefaultChannel() == "" {
+			return fmt.Sprintf("package %q has no default channel", sub.Spec.Package)
+		}
+		return ""
+	}
+	for _, ch := range pm.Status.Channels {
+		if ch.Name == channel {
+			return ""
+		}
+	}
+	return fmt.Sprintf("channel %q of package %q not found", channel, sub.Spec.Package)
+}
@@ -0,0 +1,188 @@
+package action_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("CatalogRemove", func() {
+	var (
+		cfg        action.Configuration
+		failUpdate func(client.Object) bool
+	)
+
+	packageManifest := func(name string, catalog *v1alpha1.CatalogSource, namespace string) *operatorsv1.PackageManifest {
+		return &operatorsv1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-" + catalog.Name,
+				Namespace: namespace,
+				Labels:    map[string]string{"catalog": catalog.Name, "catalog-namespace": catalog.Namespace},
+			},
+			Status: operatorsv1.PackageManifestStatus{
+				CatalogSource:          catalog.Name,
+				CatalogSourceNamespace: catalog.Namespace,
+				PackageName:            name,
+				Channels:               []operatorsv1.PackageChannel{{Name: "stable"}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		failUpdate = nil
+
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		old := &v1alpha1.CatalogSource{ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "olm"}}
+		replacement := &v1alpha1.CatalogSource{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "olm"}}
+		local := &v1alpha1.CatalogSource{ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "other-namespace"}}
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package:                "etcd",
+				Channel:                "stable",
+				CatalogSource:          "old",
+				CatalogSourceNamespace: "olm",
+			},
+		}
+
+		// The package server lists the packages of the global catalogs in every namespace, and
+		// those of other catalogs in their own namespace only.
+		objs := []client.Object{old, replacement, local, sub}
+		for _, ns := range []string{"olm", "etcd-namespace", "other-namespace"} {
+			objs = append(objs, packageManifest("etcd", replacement, ns))
+		}
+		objs = append(objs, packageManifest("etcd", local, "other-namespace"))
+
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(objs...).
+			WithScheme(sch).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if failUpdate != nil && failUpdate(obj) {
+						return errors.New("update refused")
+					}
+					return cl.Update(ctx, obj, opts...)
+				},
+			}).
+			Build()
+		cfg.Scheme = sch
+		cfg.Namespace = "olm"
+	})
+
+	catalogExists := func(name string) bool {
+		err := cfg.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "olm"}, &v1alpha1.CatalogSource{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).To(BeNil())
+		return true
+	}
+
+	It("should refuse to remove a catalog used by subscriptions", func() {
+		remover := internalaction.NewCatalogRemove(&cfg)
+		remover.CatalogName = "old"
+		err := remover.Run(context.TODO())
+
+		inUse := &internalaction.ErrCatalogInUse{}
+		Expect(err).To(BeAssignableToTypeOf(inUse))
+		inUse = err.(*internalaction.ErrCatalogInUse)
+		Expect(inUse.Subscriptions).To(HaveLen(1))
+		Expect(inUse.Subscriptions[0].Spec.Package).To(Equal("etcd"))
+		Expect(catalogExists("old")).To(BeTrue())
+	})
+
+	It("should remove a catalog used by subscriptions with force", func() {
+		remover := internalaction.NewCatalogRemove(&cfg)
+		remover.CatalogName = "old"
+		remover.Force = true
+		Expect(remover.Run(context.TODO())).To(Succeed())
+		Expect(catalogExists("old")).To(BeFalse())
+	})
+
+	It("should migrate subscriptions to a replacement catalog", func() {
+		remover := internalaction.NewCatalogRemove(&cfg)
+		remover.CatalogName = "old"
+		Expect(remover.MigrateTo.Set("new")).To(Succeed())
+		Expect(remover.Run(context.TODO())).To(Succeed())
+		Expect(catalogExists("old")).To(BeFalse())
+
+		sub := &v1alpha1.Subscription{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "etcd", Namespace: "etcd-namespace"}, sub)).To(Succeed())
+		Expect(sub.Spec.CatalogSource).To(Equal("new"))
+		Expect(sub.Spec.CatalogSourceNamespace).To(Equal("olm"))
+	})
+
+	It("should not migrate to a catalog missing a subscribed channel", func() {
+		pm := &operatorsv1.PackageManifest{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "etcd-new", Namespace: "etcd-namespace"}, pm)).To(Succeed())
+		pm.Status.Channels = []operatorsv1.PackageChannel{{Name: "alpha"}}
+		Expect(cfg.Client.Update(context.TODO(), pm)).To(Succeed())
+
+		remover := internalaction.NewCatalogRemove(&cfg)
+		remover.CatalogName = "old"
+		Expect(remover.MigrateTo.Set("olm/new")).To(Succeed())
+		err := remover.Run(context.TODO())
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`channel "stable" of package "etcd" not found`))
+		Expect(catalogExists("old")).To(BeTrue())
+	})
+
+	It("should not migrate to a catalog the subscriptions cannot resolve from", func() {
+		remover := internalaction.NewCatalogRemove(&cfg)
+		remover.CatalogName = "old"
+		Expect(remover.MigrateTo.Set("other-namespace/local")).To(Succeed())
+		err := remover.Run(context.TODO())
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`etcd-namespace/etcd: catalogsource is neither in namespace "etcd-namespace" nor in the global catalog namespace`))
+		Expect(catalogExists("old")).To(BeTrue())
+	})
+
+	It("should report the subscriptions that were and were not migrated", func() {
+		for _, name := range []string{"etcd-a", "etcd-b"} {
+			Expect(cfg.Client.Create(context.TODO(), &v1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "etcd-namespace"},
+				Spec: &v1alpha1.SubscriptionSpec{
+					Package:                "etcd",
+					Channel:                "stable",
+					CatalogSource:          "old",
+					CatalogSourceNamespace: "olm",
+				},
+			})).To(Succeed())
+		}
+		failUpdate = func(obj client.Object) bool { return obj.GetName() == "etcd-a" }
+
+		remover := internalaction.NewCatalogRemove(&cfg)
+		remover.CatalogName = "old"
+		Expect(remover.MigrateTo.Set("new")).To(Succeed())
+		err := remover.Run(context.TODO())
+
+		var incomplete *internalaction.ErrMigrationIncomplete
+		Expect(errors.As(err, &incomplete)).To(BeTrue())
+		Expect(incomplete.Migrated).To(Equal([]types.NamespacedName{
+			{Namespace: "etcd-namespace", Name: "etcd"},
+			{Namespace: "etcd-namespace", Name: "etcd-b"},
+		}))
+		Expect(incomplete.Failed).To(HaveLen(1))
+		Expect(incomplete.Failed[0].Subscription).To(Equal(types.NamespacedName{Namespace: "etcd-namespace", Name: "etcd-a"}))
+		Expect(err.Error()).To(ContainSubstring("migrated 2 of 3 subscription(s)"))
+		Expect(err.Error()).To(ContainSubstring("not migrated: etcd-namespace/etcd-a: update refused"))
+		Expect(catalogExists("old")).To(BeTrue())
+	})
+})
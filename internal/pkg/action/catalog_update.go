@@ -0,0 +1,71 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/catalogsource"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+// ErrNoCatalogChange is returned when updating a catalog source would not change it. It wraps
+// olmv1.ErrNoChange.
+var ErrNoCatalogChange = fmt.Errorf("%w - catalogsource already in desired state", olmv1.ErrNoChange)
+
+// CatalogUpdate updates the settings of an existing catalog source.
+type CatalogUpdate struct {
+	config *action.Configuration
+
+	CatalogName string
+	Config      catalogsource.ConfigOptions
+
+	Logf func(string, ...interface{})
+}
+
+func NewCatalogUpdate(cfg *action.Configuration) *CatalogUpdate {
+	return &CatalogUpdate{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (u *CatalogUpdate) Run(ctx context.Context) (*v1alpha1.CatalogSource, error) {
+	if u.Config.IsEmpty() {
+		return nil, fmt.Errorf("no catalogsource settings provided")
+	}
+	opts, err := u.Config.Options()
+	if err != nil {
+		return nil, fmt.Errorf("catalogsource config: %v", err)
+	}
+
+	cs := &v1alpha1.CatalogSource{}
+	csKey := types.NamespacedName{Namespace: u.config.Namespace, Name: u.CatalogName}
+	changed := false
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := u.config.Client.Get(ctx, csKey, cs); err != nil {
+			return err
+		}
+		original := cs.Spec.DeepCopy()
+		for _, o := range opts {
+			o(cs)
+		}
+		if equality.Semantic.DeepEqual(*original, cs.Spec) {
+			return nil
+		}
+		changed = true
+		return u.config.Client.Update(ctx, cs)
+	}); err != nil {
+		return nil, fmt.Errorf("update catalogsource %q: %v", u.CatalogName, err)
+	}
+	if !changed {
+		return cs, ErrNoCatalogChange
+	}
+	return cs, nil
+}
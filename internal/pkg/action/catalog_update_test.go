@@ -0,0 +1,84 @@
+package action_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/catalogsource"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("CatalogUpdate", func() {
+	var cfg action.Configuration
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		cs := catalogsource.Build(types.NamespacedName{Name: "my-catalog", Namespace: "olm"},
+			catalogsource.Image("quay.io/example/catalog:latest"),
+			catalogsource.NodeSelector(map[string]string{"kubernetes.io/os": "linux"}),
+		)
+
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(cs).
+			WithScheme(sch).
+			Build()
+		cfg.Scheme = sch
+		cfg.Namespace = "olm"
+	})
+
+	It("should update the provided settings", func() {
+		priority := 10
+		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater.CatalogName = "my-catalog"
+		updater.Config = catalogsource.ConfigOptions{
+			PollInterval:          10 * time.Minute,
+			Priority:              &priority,
+			NodeSelector:          map[string]string{"node-role.kubernetes.io/infra": ""},
+			Tolerations:           []string{"node-role.kubernetes.io/infra:NoSchedule"},
+			SecurityContextConfig: "restricted",
+			MemoryTarget:          "64Mi",
+		}
+		_, err := updater.Run(context.TODO())
+		Expect(err).To(BeNil())
+
+		got := &v1alpha1.CatalogSource{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "my-catalog", Namespace: "olm"}, got)).To(Succeed())
+		Expect(got.Spec.Priority).To(Equal(10))
+		Expect(got.Spec.UpdateStrategy.RegistryPoll.Interval.Duration).To(Equal(10 * time.Minute))
+		Expect(got.Spec.GrpcPodConfig.NodeSelector).To(Equal(map[string]string{
+			"kubernetes.io/os":              "linux",
+			"node-role.kubernetes.io/infra": "",
+		}))
+		Expect(got.Spec.GrpcPodConfig.Tolerations).To(ConsistOf(corev1.Toleration{
+			Key:      "node-role.kubernetes.io/infra",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		}))
+		Expect(got.Spec.GrpcPodConfig.SecurityContextConfig).To(Equal(v1alpha1.Restricted))
+		Expect(got.Spec.GrpcPodConfig.MemoryTarget.String()).To(Equal("64Mi"))
+
+		_, err = updater.Run(context.TODO())
+		Expect(err).To(MatchError(internalaction.ErrNoCatalogChange))
+	})
+
+	It("should reject an invalid security context config", func() {
+		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater.CatalogName = "my-catalog"
+		updater.Config = catalogsource.ConfigOptions{SecurityContextConfig: "privileged"}
+		_, err := updater.Run(context.TODO())
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`invalid security context config "privileged"`))
+	})
+})
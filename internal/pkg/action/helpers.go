@@ -2,6 +2,7 @@ package action
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -22,10 +23,34 @@ func objectKeyForObject(obj client.Object) types.NamespacedName {
 	}
 }
 
+func findSubscriptionForPackage(ctx context.Context, cl client.Client, namespace, packageName string) (*v1alpha1.Subscription, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := cl.List(ctx, &subs, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %v", err)
+	}
+
+	for _, s := range subs.Items {
+		s := s
+		if packageName == s.Spec.Package {
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("subscription for package %q not found", packageName)
+}
+
+// lowerKind returns the lowercase kind of obj for messages. The kind set on typed objects is
+// cleared once they are read from the cluster again, so it is looked up in the client's scheme.
+func lowerKind(cl client.Client, obj client.Object) string {
+	if gvk, err := cl.GroupVersionKindFor(obj); err == nil {
+		return strings.ToLower(gvk.Kind)
+	}
+	return strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+}
+
 func waitForDeletion(ctx context.Context, cl client.Client, objs ...client.Object) error {
 	for _, obj := range objs {
 		obj := obj
-		lowerKind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		lowerKind := lowerKind(cl, obj)
 		key := objectKeyForObject(obj)
 		if err := wait.PollUntilContextCancel(ctx, 250*time.Millisecond, true, func(conditionCtx context.Context) (bool, error) {
 			if err := cl.Get(conditionCtx, key, obj); apierrors.IsNotFound(err) {
@@ -41,6 +66,32 @@ func waitForDeletion(ctx context.Context, cl client.Client, objs ...client.Objec
 	return nil
 }
 
+// waitForInstallPlan waits for OLM to generate an install plan for the subscription and returns it.
+func waitForInstallPlan(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription) (*v1alpha1.InstallPlan, error) {
+	subKey := objectKeyForObject(sub)
+	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
+		if err := cl.Get(conditionCtx, subKey, sub); err != nil {
+			return false, err
+		}
+		if sub.Status.InstallPlanRef != nil {
+			return true, nil
+		}
+		return false, nil
+	}); err != nil {
+		return nil, fmt.Errorf("waiting for install plan to exist: %v", err)
+	}
+
+	ip := v1alpha1.InstallPlan{}
+	ipKey := types.NamespacedName{
+		Namespace: sub.Status.InstallPlanRef.Namespace,
+		Name:      sub.Status.InstallPlanRef.Name,
+	}
+	if err := cl.Get(ctx, ipKey, &ip); err != nil {
+		return nil, fmt.Errorf("get install plan: %v", err)
+	}
+	return &ip, nil
+}
+
 func approveInstallPlan(ctx context.Context, cl client.Client, ip *v1alpha1.InstallPlan) error {
 	ipKey := objectKeyForObject(ip)
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
@@ -52,17 +103,40 @@ func approveInstallPlan(ctx context.Context, cl client.Client, ip *v1alpha1.Inst
 	})
 }
 
-func getCSV(ctx context.Context, cl client.Client, ip *v1alpha1.InstallPlan) (*v1alpha1.ClusterServiceVersion, error) {
+// getCSV waits for the install plan to complete and then for the CSV it installed to succeed,
+// reporting phase changes of both objects through logf. It fails fast if either the install
+// plan or the CSV reaches the Failed phase, or if the install plan requires an approval that
+// was not given.
+func getCSV(ctx context.Context, cl client.Client, ip *v1alpha1.InstallPlan, logf func(string, ...interface{})) (*v1alpha1.ClusterServiceVersion, error) {
 	ipKey := objectKeyForObject(ip)
+	var lastPhase v1alpha1.InstallPlanPhase
 	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
 		if err := cl.Get(conditionCtx, ipKey, ip); err != nil {
 			return false, err
 		}
-		if ip.Status.Phase == v1alpha1.InstallPlanPhaseComplete {
+		if ip.Status.Phase != lastPhase {
+			lastPhase = ip.Status.Phase
+			logf("installplan %q phase %s%s", ip.Name, ip.Status.Phase, installPlanReason(ip))
+		}
+		switch ip.Status.Phase {
+		case v1alpha1.InstallPlanPhaseComplete:
 			return true, nil
+		case v1alpha1.InstallPlanPhaseFailed:
+			return false, newInstallPlanFailedError(ip)
+		case v1alpha1.InstallPlanPhaseRequiresApproval:
+			if !ip.Spec.Approved {
+				return false, &ErrInstallPlanRequiresApproval{Name: ip.Name, Namespace: ip.Namespace}
+			}
 		}
 		return false, nil
 	}); err != nil {
+		var (
+			ipErr       *ErrInstallPlanFailed
+			approvalErr *ErrInstallPlanRequiresApproval
+		)
+		if errors.As(err, &ipErr) || errors.As(err, &approvalErr) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("waiting for operator installation to complete: %v", err)
 	}
 
@@ -77,9 +151,44 @@ func getCSV(ctx context.Context, cl client.Client, ip *v1alpha1.InstallPlan) (*v
 	if csvKey.Name == "" {
 		return nil, fmt.Errorf("could not find installed CSV in install plan")
 	}
+	return waitForCSVSucceeded(ctx, cl, csvKey, logf)
+}
+
+func waitForCSVSucceeded(ctx context.Context, cl client.Client, csvKey types.NamespacedName, logf func(string, ...interface{})) (*v1alpha1.ClusterServiceVersion, error) {
 	csv := &v1alpha1.ClusterServiceVersion{}
-	if err := cl.Get(ctx, csvKey, csv); err != nil {
-		return nil, fmt.Errorf("get clusterserviceversion: %v", err)
+	var lastPhase v1alpha1.ClusterServiceVersionPhase
+	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
+		if err := cl.Get(conditionCtx, csvKey, csv); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("get clusterserviceversion: %v", err)
+		}
+		if csv.Status.Phase != lastPhase {
+			lastPhase = csv.Status.Phase
+			logf("csv %q phase %s%s", csv.Name, csv.Status.Phase, csvReason(csv))
+		}
+		switch csv.Status.Phase {
+		case v1alpha1.CSVPhaseSucceeded:
+			return true, nil
+		case v1alpha1.CSVPhaseFailed:
+			return false, &ErrCSVFailed{
+				Name:      csv.Name,
+				Namespace: csv.Namespace,
+				Reason:    string(csv.Status.Reason),
+				Message:   csv.Status.Message,
+			}
+		}
+		return false, nil
+	}); err != nil {
+		var csvErr *ErrCSVFailed
+		if errors.As(err, &csvErr) {
+			return nil, err
+		}
+		if lastPhase != v1alpha1.CSVPhaseNone {
+			return nil, fmt.Errorf("waiting for csv %q to succeed (last phase %s%s): %v", csvKey.Name, lastPhase, csvReason(csv), err)
+		}
+		return nil, fmt.Errorf("waiting for csv %q to succeed: %v", csvKey.Name, err)
 	}
 	return csv, nil
 }
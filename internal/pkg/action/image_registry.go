@@ -0,0 +1,300 @@
+package action
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/pkg/docker/config"
+	"github.com/containers/image/v5/pkg/sysregistriesv2"
+	imagetypes "github.com/containers/image/v5/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/image"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+)
+
+// ImageRegistry configures how index images are pulled.
+type ImageRegistry struct {
+	// RegistryConfig is the path to a docker config.json or podman auth.json file holding
+	// credentials for the index image's registry. If empty, the default docker and podman
+	// locations are used.
+	RegistryConfig string
+	// RegistriesConf is the path to a registries.conf file whose mirror rules are used
+	// when pulling the index image. If empty, the system registries.conf is used.
+	RegistriesConf string
+	// CAFile is the path to a PEM bundle of additional CAs trusted when pulling the index image.
+	CAFile        string
+	SkipTLSVerify bool
+	PlainHTTP     bool
+
+	RegistryOptions []containerdregistry.RegistryOption
+}
+
+// pullSource is a location the index image can be pulled from.
+type pullSource struct {
+	ref      string
+	insecure bool
+}
+
+// withImage pulls img into a temporary local registry, trying each of its registries.conf
+// mirrors in order, and calls fn with the first image that could be pulled. The local registry
+// is destroyed once fn returns.
+func (r *ImageRegistry) withImage(ctx context.Context, img string, logf func(string, ...interface{}), fn func(context.Context, *containerdregistry.Registry, image.Reference) error) error {
+	sources, err := r.pullSources(img)
+	if err != nil {
+		return err
+	}
+
+	configDir, err := r.resolverConfigDir(sources)
+	if err != nil {
+		return err
+	}
+	if configDir != "" {
+		defer os.RemoveAll(configDir)
+	}
+
+	errs := make([]error, 0, len(sources))
+	for _, src := range sources {
+		err := r.pull(ctx, src, configDir, logf, fn)
+		if err == nil {
+			return nil
+		}
+		if len(sources) > 1 {
+			logf("pull %q: %v", src.ref, err)
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (r *ImageRegistry) pull(ctx context.Context, src pullSource, configDir string, logf func(string, ...interface{}), fn func(context.Context, *containerdregistry.Registry, image.Reference) error) error {
+	opts, err := r.registryOptions(configDir, src.insecure)
+	if err != nil {
+		return err
+	}
+	reg, err := containerdregistry.NewRegistry(opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := reg.Destroy(); err != nil {
+			logf("registry cleanup: %v", err)
+		}
+	}()
+
+	ref := image.SimpleReference(src.ref)
+	if err := reg.Pull(ctx, ref); err != nil {
+		return fmt.Errorf("pull image: %v", err)
+	}
+	return fn(ctx, reg, ref)
+}
+
+func (r *ImageRegistry) systemContext() *imagetypes.SystemContext {
+	return &imagetypes.SystemContext{
+		AuthFilePath:             r.RegistryConfig,
+		SystemRegistriesConfPath: r.RegistriesConf,
+	}
+}
+
+// credentials returns the credentials for the repository of named, read from RegistryConfig or,
+// if it is empty, from the default docker and podman locations and credential helpers.
+func (r *ImageRegistry) credentials(named reference.Named) (imagetypes.DockerAuthConfig, error) {
+	sys := r.systemContext()
+	if r.RegistryConfig == "" {
+		sys = nil
+	}
+	creds, err := config.GetCredentials(sys, named.Name())
+	if err != nil {
+		return imagetypes.DockerAuthConfig{}, fmt.Errorf("get credentials for %q: %v", named.Name(), err)
+	}
+	return creds, nil
+}
+
+// pullSources returns the locations to pull indexImage from, in the order given by the
+// mirror rules of registries.conf. The image reference itself is always included.
+func (r *ImageRegistry) pullSources(indexImage string) ([]pullSource, error) {
+	named, err := reference.ParseNormalizedNamed(indexImage)
+	if err != nil {
+		return nil, fmt.Errorf("parse image reference %q: %v", indexImage, err)
+	}
+	reg, err := sysregistriesv2.FindRegistry(r.systemContext(), named.String())
+	if err != nil {
+		return nil, fmt.Errorf("load registries.conf: %v", err)
+	}
+	if reg == nil {
+		return []pullSource{{ref: indexImage}}, nil
+	}
+	if reg.Blocked {
+		return nil, fmt.Errorf("registry %q is blocked in registries.conf", reg.Prefix)
+	}
+
+	srcs, err := reg.PullSourcesFromReference(named)
+	if err != nil {
+		return nil, fmt.Errorf("resolve mirrors for %q: %v", indexImage, err)
+	}
+	sources := make([]pullSource, 0, len(srcs))
+	for _, src := range srcs {
+		sources = append(sources, pullSource{
+			ref:      src.Reference.String(),
+			insecure: src.Endpoint.Insecure,
+		})
+	}
+	return sources, nil
+}
+
+// registryOptions returns the options used to create the registry that pulls the index image.
+// Insecure sources are pulled without TLS verification and may fall back to plain HTTP.
+func (r *ImageRegistry) registryOptions(configDir string, insecure bool) ([]containerdregistry.RegistryOption, error) {
+	opts := append([]containerdregistry.RegistryOption{}, r.RegistryOptions...)
+	if r.CAFile != "" {
+		pool, err := r.rootCAs()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, containerdregistry.WithRootCAs(pool))
+	}
+	if configDir != "" {
+		opts = append(opts, containerdregistry.WithResolverConfigDir(configDir))
+	}
+	opts = append(opts,
+		containerdregistry.SkipTLSVerify(r.SkipTLSVerify || insecure),
+		containerdregistry.WithPlainHTTP(r.PlainHTTP || insecure),
+	)
+	return opts, nil
+}
+
+func (r *ImageRegistry) rootCAs() (*x509.CertPool, error) {
+	pem, err := os.ReadFile(r.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file: %v", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file %q", r.CAFile)
+	}
+	return pool, nil
+}
+
+// resolverConfigDir returns a directory containing a config.json with the credentials for the
+// repositories of sources, which is where the registry resolver looks up credentials. The
+// credentials are looked up like those of the pull secret, so that the image is pulled with the
+// same credentials as the cluster uses. They are keyed by repository rather than registry, so
+// that sources on the same registry keep their own credentials. It returns "" if no credentials
+// are configured.
+func (r *ImageRegistry) resolverConfigDir(sources []pullSource) (string, error) {
+	auths := map[string]interface{}{}
+	for _, src := range sources {
+		named, err := reference.ParseNormalizedNamed(src.ref)
+		if err != nil {
+			return "", fmt.Errorf("parse image reference %q: %v", src.ref, err)
+		}
+		creds, err := r.credentials(named)
+		if err != nil {
+			return "", err
+		}
+		if auth := dockerConfigAuth(creds); auth != nil {
+			auths[named.Name()] = auth
+		}
+	}
+	if len(auths) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(map[string]interface{}{"auths": auths})
+	if err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp("", "kubectl-operator-registry-config-")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), data, 0600); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// dockerConfigAuth returns the docker config.json entry for creds, or nil if they are empty.
+// Identity tokens are stored along with the placeholder username they are looked up with, since
+// entries without an auth are skipped.
+func dockerConfigAuth(creds imagetypes.DockerAuthConfig) map[string]string {
+	switch {
+	case creds.IdentityToken != "":
+		return map[string]string{
+			"auth":          base64.StdEncoding.EncodeToString([]byte(creds.Username + ":")),
+			"identitytoken": creds.IdentityToken,
+		}
+	case creds.Username != "" || creds.Password != "":
+		return map[string]string{"auth": base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password))}
+	}
+	return nil
+}
+
+// pullSecretFor returns a pull secret with the credentials for indexImage's registry, so that
+// the cluster can pull the image too. It returns nil if no credentials are configured.
+func (a *CatalogAdd) pullSecretFor(csKey types.NamespacedName, indexImage string) (*corev1.Secret, error) {
+	named, err := reference.ParseNormalizedNamed(indexImage)
+	if err != nil {
+		return nil, fmt.Errorf("parse image reference %q: %v", indexImage, err)
+	}
+	creds, err := a.credentials(named)
+	if err != nil {
+		return nil, err
+	}
+	if creds.IdentityToken != "" {
+		a.Logf("credentials for %q use an identity token, which cannot be used to pull from the cluster; not creating a pull secret", named.Name())
+		return nil, nil
+	}
+	if creds.Username == "" && creds.Password == "" {
+		return nil, nil
+	}
+
+	dockerConfig := map[string]interface{}{
+		"auths": map[string]interface{}{
+			reference.Domain(named): map[string]string{
+				"auth": base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password)),
+			},
+		},
+	}
+	data, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      csKey.Name + "-pull-secret",
+			Namespace: csKey.Namespace,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: data},
+	}, nil
+}
+
+// createPullSecret creates the catalog's pull secret, owned by the catalog so that it is removed
+// along with it.
+func (a *CatalogAdd) createPullSecret(ctx context.Context, cs *v1alpha1.CatalogSource, secret *corev1.Secret) error {
+	secret.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       v1alpha1.CatalogSourceKind,
+		Name:       cs.Name,
+		UID:        cs.UID,
+	}}
+	if err := a.config.Client.Create(ctx, secret); err != nil {
+		return fmt.Errorf("create pull secret: %v", err)
+	}
+	a.Logf("created pull secret %q", secret.Name)
+	return nil
+}
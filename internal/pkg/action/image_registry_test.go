@@ -0,0 +1,273 @@
+package action
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/containers/image/v5/docker/reference"
+	imagetypes "github.com/containers/image/v5/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("ImageRegistry", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "kubectl-operator-image-registry-")
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	auth := func(username, password string) map[string]string {
+		return map[string]string{"auth": base64.StdEncoding.EncodeToString([]byte(username + ":" + password))}
+	}
+	writeJSON := func(path string, v interface{}) string {
+		path = filepath.Join(dir, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0700)).To(Succeed())
+		data, err := json.Marshal(v)
+		Expect(err).To(BeNil())
+		Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+		return path
+	}
+	writeFile := func(path, content string) string {
+		path = filepath.Join(dir, path)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+	identityToken := func(token string) map[string]string {
+		return map[string]string{"auth": base64.StdEncoding.EncodeToString([]byte("<token>:")), "identitytoken": token}
+	}
+	named := func(ref string) reference.Named {
+		n, err := reference.ParseNormalizedNamed(ref)
+		Expect(err).To(BeNil())
+		return n
+	}
+
+	Describe("credentials", func() {
+		var dockerConfig, xdgRuntimeDir string
+
+		// The podman and docker auth files are read from the directories named by these
+		// variables, which take precedence over the home directory.
+		BeforeEach(func() {
+			dockerConfig, xdgRuntimeDir = os.Getenv("DOCKER_CONFIG"), os.Getenv("XDG_RUNTIME_DIR")
+			Expect(os.MkdirAll(filepath.Join(dir, "run"), 0700)).To(Succeed())
+			Expect(os.Setenv("DOCKER_CONFIG", filepath.Join(dir, "docker"))).To(Succeed())
+			Expect(os.Setenv("XDG_RUNTIME_DIR", filepath.Join(dir, "run"))).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Setenv("DOCKER_CONFIG", dockerConfig)).To(Succeed())
+			Expect(os.Setenv("XDG_RUNTIME_DIR", xdgRuntimeDir)).To(Succeed())
+		})
+
+		DescribeTable("looks up the credentials of the image's repository",
+			func(files map[string]interface{}, registryConfig, ref string, expected imagetypes.DockerAuthConfig) {
+				for path, content := range files {
+					writeJSON(path, content)
+				}
+				r := ImageRegistry{}
+				if registryConfig != "" {
+					r.RegistryConfig = filepath.Join(dir, registryConfig)
+				}
+				creds, err := r.credentials(named(ref))
+				Expect(err).To(BeNil())
+				Expect(creds).To(Equal(expected))
+			},
+			Entry("no credentials", nil, "", "quay.io/example/index:latest", imagetypes.DockerAuthConfig{}),
+			Entry("docker config.json", map[string]interface{}{
+				"docker/config.json": map[string]interface{}{"auths": map[string]interface{}{"quay.io": auth("docker", "secret")}},
+			}, "", "quay.io/example/index:latest", imagetypes.DockerAuthConfig{Username: "docker", Password: "secret"}),
+			Entry("podman auth.json", map[string]interface{}{
+				"run/containers/auth.json": map[string]interface{}{"auths": map[string]interface{}{"quay.io": auth("podman", "secret")}},
+			}, "", "quay.io/example/index:latest", imagetypes.DockerAuthConfig{Username: "podman", Password: "secret"}),
+			Entry("podman auth.json preferred over docker config.json", map[string]interface{}{
+				"run/containers/auth.json": map[string]interface{}{"auths": map[string]interface{}{"quay.io": auth("podman", "secret")}},
+				"docker/config.json":       map[string]interface{}{"auths": map[string]interface{}{"quay.io": auth("docker", "secret")}},
+			}, "", "quay.io/example/index:latest", imagetypes.DockerAuthConfig{Username: "podman", Password: "secret"}),
+			Entry("most specific repository", map[string]interface{}{
+				"run/containers/auth.json": map[string]interface{}{"auths": map[string]interface{}{
+					"quay.io":               auth("registry", "secret"),
+					"quay.io/example":       auth("namespace", "secret"),
+					"quay.io/example/index": auth("repository", "secret"),
+				}},
+			}, "", "quay.io/example/index:latest", imagetypes.DockerAuthConfig{Username: "repository", Password: "secret"}),
+			Entry("--registry-config", map[string]interface{}{
+				"auth.json":                map[string]interface{}{"auths": map[string]interface{}{"quay.io": auth("flag", "secret")}},
+				"run/containers/auth.json": map[string]interface{}{"auths": map[string]interface{}{"quay.io": auth("podman", "secret")}},
+			}, "auth.json", "quay.io/example/index:latest", imagetypes.DockerAuthConfig{Username: "flag", Password: "secret"}),
+			Entry("--registry-config without credentials for the registry", map[string]interface{}{
+				"auth.json":                map[string]interface{}{"auths": map[string]interface{}{"registry.example.com": auth("flag", "secret")}},
+				"run/containers/auth.json": map[string]interface{}{"auths": map[string]interface{}{"quay.io": auth("podman", "secret")}},
+			}, "auth.json", "quay.io/example/index:latest", imagetypes.DockerAuthConfig{}),
+			Entry("identity token", map[string]interface{}{
+				"run/containers/auth.json": map[string]interface{}{"auths": map[string]interface{}{"quay.io": identityToken("token")}},
+			}, "", "quay.io/example/index:latest", imagetypes.DockerAuthConfig{Username: "<token>", IdentityToken: "token"}),
+		)
+	})
+
+	DescribeTable("dockerConfigAuth",
+		func(creds imagetypes.DockerAuthConfig, expected map[string]string) {
+			Expect(dockerConfigAuth(creds)).To(Equal(expected))
+		},
+		Entry("no credentials", imagetypes.DockerAuthConfig{}, nil),
+		Entry("username and password", imagetypes.DockerAuthConfig{Username: "user", Password: "secret"}, auth("user", "secret")),
+		Entry("identity token", imagetypes.DockerAuthConfig{Username: "<token>", IdentityToken: "token"}, identityToken("token")),
+	)
+
+	Describe("resolverConfigDir", func() {
+		It("returns no directory without credentials", func() {
+			r := ImageRegistry{RegistryConfig: writeJSON("auth.json", map[string]interface{}{"auths": map[string]interface{}{}})}
+			configDir, err := r.resolverConfigDir([]pullSource{{ref: "quay.io/example/index:latest"}})
+			Expect(err).To(BeNil())
+			Expect(configDir).To(BeEmpty())
+		})
+
+		It("keys the credentials of every source by repository", func() {
+			r := ImageRegistry{RegistryConfig: writeJSON("auth.json", map[string]interface{}{"auths": map[string]interface{}{
+				"quay.io/example/index": auth("source", "secret"),
+				"quay.io/mirror/index":  auth("mirror", "secret"),
+				"registry.example.com":  identityToken("token"),
+			}})}
+			configDir, err := r.resolverConfigDir([]pullSource{
+				{ref: "quay.io/mirror/index:latest"},
+				{ref: "registry.example.com/example/index:latest"},
+				{ref: "quay.io/example/index:latest"},
+				{ref: "docker.io/example/index:latest"},
+			})
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(configDir)
+
+			data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+			Expect(err).To(BeNil())
+			Expect(data).To(MatchJSON(`{"auths": {
+				"quay.io/example/index": {"auth": "` + auth("source", "secret")["auth"] + `"},
+				"quay.io/mirror/index": {"auth": "` + auth("mirror", "secret")["auth"] + `"},
+				"registry.example.com/example/index": {"auth": "` + identityToken("token")["auth"] + `", "identitytoken": "token"}
+			}}`))
+		})
+	})
+
+	Describe("pullSecretFor", func() {
+		csKey := types.NamespacedName{Namespace: "olm", Name: "example"}
+
+		It("creates a pull secret with the registry's credentials", func() {
+			a := NewCatalogAdd(nil)
+			a.RegistryConfig = writeJSON("auth.json", map[string]interface{}{"auths": map[string]interface{}{
+				"quay.io": auth("user", "secret"),
+			}})
+			secret, err := a.pullSecretFor(csKey, "quay.io/example/index:latest")
+			Expect(err).To(BeNil())
+			Expect(secret.Name).To(Equal("example-pull-secret"))
+			Expect(secret.Namespace).To(Equal("olm"))
+			Expect(secret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+			Expect(secret.Data[corev1.DockerConfigJsonKey]).To(MatchJSON(`{"auths": {
+				"quay.io": {"auth": "` + auth("user", "secret")["auth"] + `"}
+			}}`))
+		})
+
+		It("creates no pull secret without credentials", func() {
+			a := NewCatalogAdd(nil)
+			a.RegistryConfig = writeJSON("auth.json", map[string]interface{}{"auths": map[string]interface{}{}})
+			secret, err := a.pullSecretFor(csKey, "quay.io/example/index:latest")
+			Expect(err).To(BeNil())
+			Expect(secret).To(BeNil())
+		})
+
+		It("creates no pull secret for an identity token", func() {
+			var logs []string
+			a := NewCatalogAdd(nil)
+			a.Logf = func(f string, _ ...interface{}) { logs = append(logs, f) }
+			a.RegistryConfig = writeJSON("auth.json", map[string]interface{}{"auths": map[string]interface{}{
+				"quay.io": identityToken("token"),
+			}})
+			secret, err := a.pullSecretFor(csKey, "quay.io/example/index:latest")
+			Expect(err).To(BeNil())
+			Expect(secret).To(BeNil())
+			Expect(logs).To(ConsistOf(ContainSubstring("identity token")))
+		})
+	})
+
+	Describe("rootCAs", func() {
+		It("adds the certificates of the CA file to the pool", func() {
+			server := httptest.NewTLSServer(nil)
+			defer server.Close()
+			r := ImageRegistry{CAFile: writeFile("ca.pem", string(pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: server.Certificate().Raw,
+			})))}
+			pool, err := r.rootCAs()
+			Expect(err).To(BeNil())
+			_, err = server.Certificate().Verify(x509.VerifyOptions{Roots: pool})
+			Expect(err).To(BeNil())
+		})
+
+		It("fails for a file without certificates", func() {
+			r := ImageRegistry{CAFile: writeFile("ca.pem", "not a certificate")}
+			_, err := r.rootCAs()
+			Expect(err).To(MatchError(ContainSubstring("no certificates found")))
+		})
+
+		It("fails for a missing file", func() {
+			r := ImageRegistry{CAFile: filepath.Join(dir, "missing.pem")}
+			_, err := r.rootCAs()
+			Expect(err).To(MatchError(ContainSubstring("read CA file")))
+		})
+	})
+
+	Describe("pullSources", func() {
+		const registriesConf = `
+[[registry]]
+prefix = "quay.io/example"
+location = "quay.io/example"
+
+[[registry.mirror]]
+location = "mirror.example.com/example"
+
+[[registry.mirror]]
+location = "insecure.example.com/example"
+insecure = true
+
+[[registry]]
+prefix = "quay.io/blocked"
+location = "quay.io/blocked"
+blocked = true
+`
+
+		DescribeTable("orders the mirrors of registries.conf before the image",
+			func(ref string, expected []pullSource, expectedErr string) {
+				r := ImageRegistry{RegistriesConf: writeFile("registries.conf", registriesConf)}
+				sources, err := r.pullSources(ref)
+				if expectedErr != "" {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+					return
+				}
+				Expect(err).To(BeNil())
+				Expect(sources).To(Equal(expected))
+			},
+			Entry("mirrored image", "quay.io/example/index:latest", []pullSource{
+				{ref: "mirror.example.com/example/index:latest"},
+				{ref: "insecure.example.com/example/index:latest", insecure: true},
+				{ref: "quay.io/example/index:latest"},
+			}, ""),
+			Entry("image without mirrors", "quay.io/other/index:latest", []pullSource{
+				{ref: "quay.io/other/index:latest"},
+			}, ""),
+			Entry("blocked image", "quay.io/blocked/index:latest", nil, `registry "quay.io/blocked" is blocked`),
+			Entry("invalid reference", "quay.io/Example/index:latest", nil, "parse image reference"),
+		)
+	})
+})
@@ -0,0 +1,153 @@
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// ErrInstallPlanFailed is returned when an install plan reaches the Failed phase. When
+// available, it names the bundle lookup or plan step responsible for the failure.
+type ErrInstallPlanFailed struct {
+	Name      string
+	Namespace string
+	Reason    string
+	Message   string
+
+	// BundleLookup is the identifier of the bundle that could not be unpacked, if any.
+	BundleLookup string
+	// Step describes the first plan step that was not applied, if any.
+	Step string
+}
+
+func (e ErrInstallPlanFailed) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "install plan %q failed", e.Name)
+	if e.Reason != "" {
+		fmt.Fprintf(&sb, ": %s", e.Reason)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&sb, ": %s", e.Message)
+	}
+	if e.BundleLookup != "" {
+		fmt.Fprintf(&sb, " (bundle lookup %s)", e.BundleLookup)
+	}
+	if e.Step != "" {
+		fmt.Fprintf(&sb, " (step %s)", e.Step)
+	}
+	return sb.String()
+}
+
+// ErrInstallPlanRequiresApproval is returned when an install plan waits for a manual approval
+// that was not given, as OLM requires for every install plan of a namespace in which a
+// subscription uses manual approval.
+type ErrInstallPlanRequiresApproval struct {
+	Name      string
+	Namespace string
+}
+
+func (e ErrInstallPlanRequiresApproval) Error() string {
+	return fmt.Sprintf("install plan %q requires approval: approve it with 'kubectl operator approve %s -n %s', "+
+		"or use --approval=Automatic for every subscription in namespace %q", e.Name, e.Name, e.Namespace, e.Namespace)
+}
+
+// ErrCSVFailed is returned when a ClusterServiceVersion reaches the Failed phase.
+type ErrCSVFailed struct {
+	Name      string
+	Namespace string
+	Reason    string
+	Message   string
+}
+
+func (e ErrCSVFailed) Error() string {
+	msg := fmt.Sprintf("csv %q failed", e.Name)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func newInstallPlanFailedError(ip *v1alpha1.InstallPlan) *ErrInstallPlanFailed {
+	e := &ErrInstallPlanFailed{
+		Name:      ip.Name,
+		Namespace: ip.Namespace,
+	}
+	if cond, ok := latestInstallPlanCondition(ip); ok {
+		e.Reason = string(cond.Reason)
+		e.Message = cond.Message
+	}
+	for _, bl := range ip.Status.BundleLookups {
+		cond := bl.GetCondition(v1alpha1.BundleLookupFailed)
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		e.BundleLookup = fmt.Sprintf("%q from %q failed", bl.Identifier, bl.Path)
+		if cond.Message != "" {
+			e.BundleLookup += ": " + cond.Message
+		}
+		break
+	}
+	for _, step := range ip.Status.Plan {
+		if step == nil {
+			continue
+		}
+		switch step.Status {
+		case v1alpha1.StepStatusCreated, v1alpha1.StepStatusPresent:
+			continue
+		}
+		e.Step = fmt.Sprintf("%s %q for %q is %s", strings.ToLower(step.Resource.Kind), step.Resource.Name, step.Resolving, step.Status)
+		break
+	}
+	return e
+}
+
+// latestInstallPlanCondition returns the most recently transitioned install plan condition.
+func latestInstallPlanCondition(ip *v1alpha1.InstallPlan) (v1alpha1.InstallPlanCondition, bool) {
+	var (
+		latest v1alpha1.InstallPlanCondition
+		found  bool
+	)
+	for _, cond := range ip.Status.Conditions {
+		if !found || (cond.LastTransitionTime != nil && latest.LastTransitionTime != nil && latest.LastTransitionTime.Before(cond.LastTransitionTime)) {
+			latest = cond
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// installPlanReason formats the reason of the latest install plan condition for progress output.
+func installPlanReason(ip *v1alpha1.InstallPlan) string {
+	cond, ok := latestInstallPlanCondition(ip)
+	if !ok {
+		return ""
+	}
+	return formatReason(string(cond.Reason), cond.Message)
+}
+
+// csvReason formats the reason of the latest CSV condition for progress output.
+func csvReason(csv *v1alpha1.ClusterServiceVersion) string {
+	reason, message := string(csv.Status.Reason), csv.Status.Message
+	if n := len(csv.Status.Conditions); n > 0 && reason == "" && message == "" {
+		reason, message = string(csv.Status.Conditions[n-1].Reason), csv.Status.Conditions[n-1].Message
+	}
+	return formatReason(reason, message)
+}
+
+func formatReason(reason, message string) string {
+	switch {
+	case reason != "" && message != "":
+		return fmt.Sprintf(" (%s: %s)", reason, message)
+	case reason != "":
+		return fmt.Sprintf(" (%s)", reason)
+	case message != "":
+		return fmt.Sprintf(" (%s)", message)
+	}
+	return ""
+}
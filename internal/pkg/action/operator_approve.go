@@ -0,0 +1,173 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// PendingInstallPlan is a manual install plan waiting for approval, along with a summary of
+// what approving it would do.
+type PendingInstallPlan struct {
+	InstallPlan v1alpha1.InstallPlan
+
+	// Installs lists the CSVs the install plan installs.
+	Installs []string
+	// Replaces lists the CSVs that are replaced by the CSVs being installed.
+	Replaces []string
+	// ClusterScoped lists the plan steps that create or update cluster-scoped resources.
+	ClusterScoped []v1alpha1.StepResource
+}
+
+// OperatorApprove finds and approves pending manual install plans.
+type OperatorApprove struct {
+	config *action.Configuration
+
+	// InstallPlanNames restricts the install plans to those with the given names.
+	InstallPlanNames []string
+	// Selector restricts the install plans to those matching the label selector.
+	Selector labels.Selector
+
+	Logf func(string, ...interface{})
+}
+
+func NewOperatorApprove(cfg *action.Configuration) *OperatorApprove {
+	return &OperatorApprove{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+// Pending returns the install plans in the configured namespace that are waiting for manual
+// approval and match the configured names and selector.
+func (a *OperatorApprove) Pending(ctx context.Context) ([]PendingInstallPlan, error) {
+	opts := []client.ListOption{client.InNamespace(a.config.Namespace)}
+	if a.Selector != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: a.Selector})
+	}
+	ips := v1alpha1.InstallPlanList{}
+	if err := a.config.Client.List(ctx, &ips, opts...); err != nil {
+		return nil, fmt.Errorf("list install plans: %v", err)
+	}
+
+	names := sets.New[string](a.InstallPlanNames...)
+	found := sets.New[string]()
+	var pending []PendingInstallPlan
+	for _, ip := range ips.Items {
+		if names.Len() > 0 && !names.Has(ip.Name) {
+			continue
+		}
+		found.Insert(ip.Name)
+		if !isPendingApproval(ip) {
+			continue
+		}
+		pending = append(pending, a.summarize(ctx, ip))
+	}
+	if missing := names.Difference(found); missing.Len() > 0 {
+		return nil, fmt.Errorf("install plan(s) %q not found", sets.List(missing))
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].InstallPlan.Namespace != pending[j].InstallPlan.Namespace {
+			return pending[i].InstallPlan.Namespace < pending[j].InstallPlan.Namespace
+		}
+		return pending[i].InstallPlan.Name < pending[j].InstallPlan.Name
+	})
+	return pending, nil
+}
+
+// Approve approves each of the provided install plans.
+func (a *OperatorApprove) Approve(ctx context.Context, plans []PendingInstallPlan) error {
+	for _, p := range plans {
+		ip := p.InstallPlan
+		if err := approveInstallPlan(ctx, a.config.Client, &ip); err != nil {
+			return fmt.Errorf("approve install plan %q: %v", objectKeyForObject(&ip), err)
+		}
+		a.Logf("installplan %q approved", ip.Name)
+	}
+	return nil
+}
+
+func isPendingApproval(ip v1alpha1.InstallPlan) bool {
+	return ip.Spec.Approval == v1alpha1.ApprovalManual &&
+		!ip.Spec.Approved &&
+		ip.Status.Phase == v1alpha1.InstallPlanPhaseRequiresApproval
+}
+
+func (a *OperatorApprove) summarize(ctx context.Context, ip v1alpha1.InstallPlan) PendingInstallPlan {
+	p := PendingInstallPlan{
+		InstallPlan: ip,
+		Installs:    ip.Spec.ClusterServiceVersionNames,
+	}
+
+	installs := sets.New[string](ip.Spec.ClusterServiceVersionNames...)
+	replaces := sets.New[string]()
+	for _, bl := range ip.Status.BundleLookups {
+		if bl.Replaces != "" {
+			replaces.Insert(bl.Replaces)
+		}
+	}
+	// Bundle lookups are cleared once bundles are unpacked, so also consult the
+	// currently installed CSVs of the subscriptions that own the install plan.
+	for _, ref := range ip.GetOwnerReferences() {
+		if ref.Kind != v1alpha1.SubscriptionKind {
+			continue
+		}
+		sub := v1alpha1.Subscription{}
+		if err := a.config.Client.Get(ctx, types.NamespacedName{Namespace: ip.Namespace, Name: ref.Name}, &sub); err != nil {
+			continue
+		}
+		if csv := sub.Status.InstalledCSV; csv != "" && !installs.Has(csv) {
+			replaces.Insert(csv)
+		}
+	}
+	p.Replaces = sets.List(replaces)
+
+	for _, step := range ip.Status.Plan {
+		if step == nil {
+			continue
+		}
+		if !a.isNamespaced(step.Resource) {
+			p.ClusterScoped = append(p.ClusterScoped, step.Resource)
+		}
+	}
+	return p
+}
+
+// clusterScopedKinds are the cluster-scoped kinds commonly found in install plans. They are used
+// when the scope of a step resource cannot be determined from the cluster.
+var clusterScopedKinds = sets.New[string](
+	"CustomResourceDefinition",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"APIService",
+	"ValidatingWebhookConfiguration",
+	"MutatingWebhookConfiguration",
+	"PriorityClass",
+	"StorageClass",
+	"ConsoleYAMLSample",
+	"ConsoleQuickStart",
+	"ConsoleCLIDownload",
+	"ConsoleLink",
+)
+
+func (a *OperatorApprove) isNamespaced(res v1alpha1.StepResource) bool {
+	gvk := schema.GroupVersionKind{Group: res.Group, Version: res.Version, Kind: res.Kind}
+	if mapper := a.config.Client.RESTMapper(); mapper != nil {
+		if mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			return mapping.Scope.Name() == meta.RESTScopeNameNamespace
+		}
+	}
+	return !clusterScopedKinds.Has(res.Kind)
+}
@@ -0,0 +1,114 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorApprove", func() {
+	var cfg action.Configuration
+
+	newInstallPlan := func(name string, approval v1alpha1.Approval, phase v1alpha1.InstallPlanPhase, lbls map[string]string) *v1alpha1.InstallPlan {
+		return &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "etcd-namespace",
+				Labels:    lbls,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "operators.coreos.com/v1alpha1",
+					Kind:       "Subscription",
+					Name:       "etcd",
+					UID:        "1234",
+				}},
+			},
+			Spec: v1alpha1.InstallPlanSpec{
+				ClusterServiceVersionNames: []string{"etcdoperator.v0.9.4"},
+				Approval:                   approval,
+			},
+			Status: v1alpha1.InstallPlanStatus{
+				Phase: phase,
+				Plan: []*v1alpha1.Step{
+					{
+						Resolving: "etcdoperator.v0.9.4",
+						Resource:  v1alpha1.StepResource{Kind: "ClusterServiceVersion", Name: "etcdoperator.v0.9.4"},
+						Status:    v1alpha1.StepStatusUnknown,
+					},
+					{
+						Resolving: "etcdoperator.v0.9.4",
+						Resource:  v1alpha1.StepResource{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition", Name: "etcdclusters.etcd.database.coreos.com"},
+						Status:    v1alpha1.StepStatusPresent,
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd"},
+			Status:     v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v0.9.2"},
+		}
+
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(
+				sub,
+				newInstallPlan("install-manual", v1alpha1.ApprovalManual, v1alpha1.InstallPlanPhaseRequiresApproval, map[string]string{"team": "a"}),
+				newInstallPlan("install-other", v1alpha1.ApprovalManual, v1alpha1.InstallPlanPhaseRequiresApproval, map[string]string{"team": "b"}),
+				newInstallPlan("install-automatic", v1alpha1.ApprovalAutomatic, v1alpha1.InstallPlanPhaseComplete, nil),
+			).
+			WithScheme(sch).
+			Build()
+		cfg.Scheme = sch
+		cfg.Namespace = "etcd-namespace"
+	})
+
+	It("should list only pending manual install plans with a summary", func() {
+		approver := internalaction.NewOperatorApprove(&cfg)
+		pending, err := approver.Pending(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(pending).To(HaveLen(2))
+		Expect(pending[0].InstallPlan.Name).To(Equal("install-manual"))
+		Expect(pending[0].Installs).To(ConsistOf("etcdoperator.v0.9.4"))
+		Expect(pending[0].Replaces).To(ConsistOf("etcdoperator.v0.9.2"))
+		Expect(pending[0].ClusterScoped).To(HaveLen(1))
+		Expect(pending[0].ClusterScoped[0].Kind).To(Equal("CustomResourceDefinition"))
+	})
+
+	It("should approve install plans matching a selector", func() {
+		approver := internalaction.NewOperatorApprove(&cfg)
+		approver.Selector = labels.SelectorFromSet(labels.Set{"team": "a"})
+		pending, err := approver.Pending(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(pending).To(HaveLen(1))
+		Expect(approver.Approve(context.TODO(), pending)).To(Succeed())
+
+		ip := &v1alpha1.InstallPlan{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "install-manual", Namespace: "etcd-namespace"}, ip)).To(Succeed())
+		Expect(ip.Spec.Approved).To(BeTrue())
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "install-other", Namespace: "etcd-namespace"}, ip)).To(Succeed())
+		Expect(ip.Spec.Approved).To(BeFalse())
+	})
+
+	It("should fail for install plans that do not exist", func() {
+		approver := internalaction.NewOperatorApprove(&cfg)
+		approver.InstallPlanNames = []string{"install-missing"}
+		_, err := approver.Pending(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring(`"install-missing"`)))
+	})
+})
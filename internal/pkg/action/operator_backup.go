@@ -0,0 +1,214 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// Backups are laid out as follows:
+//
+//	<dir>/subscription.yaml
+//	<dir>/operatorgroup.yaml
+//	<dir>/crds/<name>.yaml
+//	<dir>/operands/<group>/<version>/<kind>/<namespace>/<name>.yaml
+//
+// Cluster-scoped operands are stored under the clusterScopedDir namespace directory, and
+// operands in the core group under the coreGroupDir group directory.
+const (
+	backupSubscriptionFile  = "subscription.yaml"
+	backupOperatorGroupFile = "operatorgroup.yaml"
+	backupCRDsDir           = "crds"
+	backupOperandsDir       = "operands"
+
+	clusterScopedDir = "_cluster"
+	coreGroupDir     = "core"
+)
+
+// operatorBackup holds the objects saved before an operator is uninstalled.
+type operatorBackup struct {
+	Subscription  *v1alpha1.Subscription
+	OperatorGroup *v1.OperatorGroup
+	CRDs          []apiextensionsv1.CustomResourceDefinition
+	Operands      []unstructured.Unstructured
+}
+
+// write saves the backup to dir, which must not already contain a backup.
+func (b *operatorBackup) write(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, backupSubscriptionFile)); err == nil {
+		return fmt.Errorf("backup directory %q already contains a backup", dir)
+	}
+
+	if b.Subscription != nil {
+		sub := b.Subscription.DeepCopy()
+		// Pin the restored subscription to the version that was installed.
+		if sub.Status.InstalledCSV != "" {
+			sub.Spec.StartingCSV = sub.Status.InstalledCSV
+		}
+		sub.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SubscriptionKind))
+		if err := writeBackupObject(filepath.Join(dir, backupSubscriptionFile), sub); err != nil {
+			return err
+		}
+	}
+	if b.OperatorGroup != nil {
+		og := b.OperatorGroup.DeepCopy()
+		og.SetGroupVersionKind(v1.GroupVersion.WithKind("OperatorGroup"))
+		if err := writeBackupObject(filepath.Join(dir, backupOperatorGroupFile), og); err != nil {
+			return err
+		}
+	}
+	for i := range b.CRDs {
+		crd := b.CRDs[i].DeepCopy()
+		crd.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+		if err := writeBackupObject(filepath.Join(dir, backupCRDsDir, crd.Name+".yaml"), crd); err != nil {
+			return err
+		}
+	}
+	for i := range b.Operands {
+		op := b.Operands[i].DeepCopy()
+		if err := writeBackupObject(operandBackupPath(dir, op), op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func operandBackupPath(dir string, op *unstructured.Unstructured) string {
+	gvk := op.GroupVersionKind()
+	group := gvk.Group
+	if group == "" {
+		group = coreGroupDir
+	}
+	namespace := op.GetNamespace()
+	if namespace == "" {
+		namespace = clusterScopedDir
+	}
+	return filepath.Join(dir, backupOperandsDir, group, gvk.Version, gvk.Kind, namespace, op.GetName()+".yaml")
+}
+
+// writeBackupObject writes obj to path as YAML, without its status and server-populated metadata.
+func writeBackupObject(path string, obj runtime.Object) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return fmt.Errorf("convert %q: %v", path, err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	stripServerFields(u)
+
+	data, err := yaml.Marshal(u.Object)
+	if err != nil {
+		return fmt.Errorf("marshal %q: %v", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create backup directory: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write %q: %v", path, err)
+	}
+	return nil
+}
+
+// stripServerFields removes the status and the metadata set by the API server, so that the
+// object can be created again. Owner references and finalizers are removed too, since they
+// refer to objects and controllers of the previous install.
+func stripServerFields(u *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(u.Object, "status")
+	for _, field := range []string{
+		"uid",
+		"resourceVersion",
+		"generation",
+		"creationTimestamp",
+		"deletionTimestamp",
+		"deletionGracePeriodSeconds",
+		"managedFields",
+		"selfLink",
+		"ownerReferences",
+		"finalizers",
+	} {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+}
+
+// readOperatorBackup reads a backup written by operatorBackup.write.
+func readOperatorBackup(dir string) (*operatorBackup, error) {
+	b := &operatorBackup{}
+
+	b.Subscription = &v1alpha1.Subscription{}
+	if err := readBackupObject(filepath.Join(dir, backupSubscriptionFile), b.Subscription); err != nil {
+		return nil, err
+	}
+	if b.Subscription.Spec == nil {
+		return nil, fmt.Errorf("subscription in backup %q has no spec", dir)
+	}
+
+	og := &v1.OperatorGroup{}
+	if err := readBackupObject(filepath.Join(dir, backupOperatorGroupFile), og); err == nil {
+		b.OperatorGroup = og
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	crdFiles, err := backupFiles(filepath.Join(dir, backupCRDsDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range crdFiles {
+		crd := apiextensionsv1.CustomResourceDefinition{}
+		if err := readBackupObject(path, &crd); err != nil {
+			return nil, err
+		}
+		b.CRDs = append(b.CRDs, crd)
+	}
+
+	operandFiles, err := backupFiles(filepath.Join(dir, backupOperandsDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range operandFiles {
+		op := unstructured.Unstructured{}
+		if err := readBackupObject(path, &op.Object); err != nil {
+			return nil, err
+		}
+		b.Operands = append(b.Operands, op)
+	}
+	return b, nil
+}
+
+func readBackupObject(path string, obj interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read backup: %w", err)
+	}
+	if err := yaml.Unmarshal(data, obj); err != nil {
+		return fmt.Errorf("parse %q: %v", path, err)
+	}
+	return nil
+}
+
+// backupFiles returns the YAML files below dir in lexical order. A missing dir has no files.
+func backupFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(path) == ".yaml" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read backup: %v", err)
+	}
+	return files, nil
+}
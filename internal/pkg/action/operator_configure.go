@@ -0,0 +1,77 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+// ErrNoConfigChange is returned when configuring a subscription would not change it. It wraps
+// olmv1.ErrNoChange.
+var ErrNoConfigChange = fmt.Errorf("%w - subscription config already in desired state", olmv1.ErrNoChange)
+
+// OperatorConfigure updates the config of the subscription for an installed operator.
+type OperatorConfigure struct {
+	config *action.Configuration
+
+	Package string
+	Config  subscription.ConfigOptions
+
+	Logf func(string, ...interface{})
+}
+
+func NewOperatorConfigure(cfg *action.Configuration) *OperatorConfigure {
+	return &OperatorConfigure{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+func (c *OperatorConfigure) Run(ctx context.Context) (*v1alpha1.Subscription, error) {
+	if c.Config.IsEmpty() {
+		return nil, fmt.Errorf("no subscription config provided")
+	}
+	opts, err := c.Config.Options()
+	if err != nil {
+		return nil, fmt.Errorf("subscription config: %v", err)
+	}
+
+	sub, err := findSubscriptionForPackage(ctx, c.config.Client, c.config.Namespace, c.Package)
+	if err != nil {
+		return nil, err
+	}
+
+	subKey := objectKeyForObject(sub)
+	changed := false
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := c.config.Client.Get(ctx, subKey, sub); err != nil {
+			return err
+		}
+		var original *v1alpha1.SubscriptionConfig
+		if sub.Spec.Config != nil {
+			original = sub.Spec.Config.DeepCopy()
+		}
+		for _, o := range opts {
+			o(sub)
+		}
+		if equality.Semantic.DeepEqual(original, sub.Spec.Config) {
+			return nil
+		}
+		changed = true
+		return c.config.Client.Update(ctx, sub)
+	}); err != nil {
+		return nil, fmt.Errorf("update subscription %q: %v", sub.Name, err)
+	}
+	if !changed {
+		return sub, ErrNoConfigChange
+	}
+	return sub, nil
+}
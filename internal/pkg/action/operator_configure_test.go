@@ -0,0 +1,115 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/internal/pkg/subscription"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorConfigure", func() {
+	const etcd = "etcd"
+	var (
+		cfg action.Configuration
+		sub *v1alpha1.Subscription
+	)
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		sub = &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-sub",
+				Namespace: "etcd-namespace",
+			},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package: etcd,
+				Config: &v1alpha1.SubscriptionConfig{
+					Env: []corev1.EnvVar{
+						{Name: "HTTP_PROXY", Value: "http://old-proxy:3128"},
+						{Name: "LOG_LEVEL", Value: "info"},
+					},
+				},
+			},
+		}
+
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(sub).
+			WithScheme(sch).
+			Build()
+		cfg.Scheme = sch
+		cfg.Namespace = "etcd-namespace"
+	})
+
+	It("should merge config into the existing subscription", func() {
+		configurer := internalaction.NewOperatorConfigure(&cfg)
+		configurer.Package = etcd
+		configurer.Config = subscription.ConfigOptions{
+			Env:            []string{"HTTP_PROXY=http://proxy:3128", "NO_PROXY=.svc"},
+			ResourceLimits: map[string]string{"memory": "256Mi"},
+			NodeSelector:   map[string]string{"node-role.kubernetes.io/infra": ""},
+			Tolerations:    []string{"node-role.kubernetes.io/infra:NoSchedule"},
+		}
+		_, err := configurer.Run(context.TODO())
+		Expect(err).To(BeNil())
+
+		got := &v1alpha1.Subscription{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "etcd-sub", Namespace: "etcd-namespace"}, got)).To(Succeed())
+		Expect(got.Spec.Config.Env).To(Equal([]corev1.EnvVar{
+			{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+			{Name: "LOG_LEVEL", Value: "info"},
+			{Name: "NO_PROXY", Value: ".svc"},
+		}))
+		Expect(got.Spec.Config.Resources.Limits.Memory().Equal(resource.MustParse("256Mi"))).To(BeTrue())
+		Expect(got.Spec.Config.NodeSelector).To(HaveKey("node-role.kubernetes.io/infra"))
+		Expect(got.Spec.Config.Tolerations).To(ConsistOf(corev1.Toleration{
+			Key:      "node-role.kubernetes.io/infra",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		}))
+	})
+
+	It("should report when nothing changed", func() {
+		configurer := internalaction.NewOperatorConfigure(&cfg)
+		configurer.Package = etcd
+		configurer.Config = subscription.ConfigOptions{
+			Env: []string{"LOG_LEVEL=info"},
+		}
+		_, err := configurer.Run(context.TODO())
+		Expect(err).To(MatchError(internalaction.ErrNoConfigChange))
+	})
+
+	It("should reject invalid settings", func() {
+		configurer := internalaction.NewOperatorConfigure(&cfg)
+		configurer.Package = etcd
+		configurer.Config = subscription.ConfigOptions{
+			Tolerations: []string{"key=value:Sometimes"},
+		}
+		_, err := configurer.Run(context.TODO())
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`unknown effect "Sometimes"`))
+	})
+
+	It("should fail for a package without a subscription", func() {
+		configurer := internalaction.NewOperatorConfigure(&cfg)
+		configurer.Package = "redis"
+		configurer.Config = subscription.ConfigOptions{
+			Env: []string{"LOG_LEVEL=debug"},
+		}
+		_, err := configurer.Run(context.TODO())
+		Expect(err).To(MatchError(`subscription for package "redis" not found`))
+	})
+})
@@ -2,14 +2,15 @@ package action
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/operator-framework/api/pkg/operators/v1"
@@ -31,6 +32,7 @@ type OperatorInstall struct {
 	WatchNamespaces     []string
 	CleanupTimeout      time.Duration
 	CreateOperatorGroup bool
+	Config              subscription.ConfigOptions
 
 	Logf func(string, ...interface{})
 }
@@ -43,9 +45,58 @@ func NewOperatorInstall(cfg *action.Configuration) *OperatorInstall {
 }
 
 func (i *OperatorInstall) Run(ctx context.Context) (*v1alpha1.ClusterServiceVersion, error) {
+	var created installObjects
+	csv, err := i.install(ctx, &created)
+	if err != nil {
+		// An install plan waiting for approval is left in place so that it can be approved.
+		var approvalErr *ErrInstallPlanRequiresApproval
+		if created.empty() || errors.As(err, &approvalErr) {
+			return nil, err
+		}
+		i.Logf("failed to install operator %q: %v; cleaning up", i.Package, err)
+		cleanupCtx, cancelCleanup := context.WithTimeout(context.Background(), i.CleanupTimeout)
+		defer cancelCleanup()
+		return nil, errors.Join(err, i.cleanup(cleanupCtx, created))
+	}
+	return csv, nil
+}
+
+// installObjects tracks the objects that were created as a result of an install, so that
+// they can be rolled back if the install fails.
+type installObjects struct {
+	operatorGroup *v1.OperatorGroup
+	subscription  *v1alpha1.Subscription
+	installPlan   *v1alpha1.InstallPlan
+	csv           *v1alpha1.ClusterServiceVersion
+}
+
+func (o installObjects) empty() bool {
+	return o.operatorGroup == nil && o.subscription == nil && o.installPlan == nil && o.csv == nil
+}
+
+// rollbackOrder returns the created objects in the order they should be deleted. The
+// subscription goes first so that OLM does not resolve it again while we are cleaning up.
+func (o installObjects) rollbackOrder() []client.Object {
+	var objs []client.Object
+	if o.subscription != nil {
+		objs = append(objs, o.subscription)
+	}
+	if o.installPlan != nil {
+		objs = append(objs, o.installPlan)
+	}
+	if o.csv != nil {
+		objs = append(objs, o.csv)
+	}
+	if o.operatorGroup != nil {
+		objs = append(objs, o.operatorGroup)
+	}
+	return objs
+}
+
+func (i *OperatorInstall) install(ctx context.Context, created *installObjects) (*v1alpha1.ClusterServiceVersion, error) {
 	pm, err := i.getPackageManifest(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("get package manifest: %v", err)
+		return nil, fmt.Errorf("get package manifest: %w", err)
 	}
 
 	pc, err := pm.GetChannel(i.Channel)
@@ -53,20 +104,42 @@ func (i *OperatorInstall) Run(ctx context.Context) (*v1alpha1.ClusterServiceVers
 		return nil, fmt.Errorf("get package channel: %v", err)
 	}
 
-	if _, err := i.ensureOperatorGroup(ctx, pm, pc); err != nil {
+	og, ogCreated, err := i.ensureOperatorGroup(ctx, pm, pc)
+	if err != nil {
 		return nil, err
 	}
+	if ogCreated {
+		og.SetGroupVersionKind(v1.GroupVersion.WithKind("OperatorGroup"))
+		created.operatorGroup = og
+	}
 
 	sub, err := i.createSubscription(ctx, pm, pc)
 	if err != nil {
 		return nil, err
 	}
 	i.Logf("subscription %q created", sub.Name)
+	sub.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SubscriptionKind))
+	created.subscription = sub
 
-	ip, err := i.getInstallPlan(ctx, sub)
+	ip, err := waitForInstallPlan(ctx, i.config.Client, sub)
 	if err != nil {
 		return nil, err
 	}
+	// Install plans can be shared by several subscriptions in the same namespace, so only roll
+	// back the install plan if it was generated solely for our subscription.
+	if ownedOnlyBy(ip, sub) {
+		ip.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.InstallPlanKind))
+		created.installPlan = ip
+	}
+
+	// The subscription's current CSV is the one the install plan is going to create.
+	if sub.Status.CurrentCSV != "" {
+		csv := &v1alpha1.ClusterServiceVersion{}
+		csv.SetName(sub.Status.CurrentCSV)
+		csv.SetNamespace(sub.Namespace)
+		csv.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(csvKind))
+		created.csv = csv
+	}
 
 	// We need to approve the initial install plan
 	if i.Approval.Approval == v1alpha1.ApprovalManual {
@@ -75,13 +148,44 @@ func (i *OperatorInstall) Run(ctx context.Context) (*v1alpha1.ClusterServiceVers
 		}
 	}
 
-	csv, err := getCSV(ctx, i.config.Client, ip)
+	csv, err := getCSV(ctx, i.config.Client, ip, i.Logf)
 	if err != nil {
-		return nil, fmt.Errorf("get clusterserviceversion: %v", err)
+		return nil, fmt.Errorf("get clusterserviceversion: %w", err)
 	}
 	return csv, nil
 }
 
+// ownedOnlyBy reports whether every subscription that owns obj is sub.
+func ownedOnlyBy(obj client.Object, sub *v1alpha1.Subscription) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == v1alpha1.SubscriptionKind && ref.Name != sub.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// cleanup deletes the objects created by a failed install and waits for them to be removed.
+// Objects that no longer exist are skipped.
+func (i *OperatorInstall) cleanup(ctx context.Context, created installObjects) error {
+	var errs []error
+	for _, obj := range created.rollbackOrder() {
+		lowerKind := lowerKind(i.config.Client, obj)
+		if err := i.config.Client.Delete(ctx, obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("delete %s %q: %v", lowerKind, obj.GetName(), err))
+			}
+			continue
+		}
+		if err := waitForDeletion(ctx, i.config.Client, obj); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		i.Logf("%s %q removed", lowerKind, obj.GetName())
+	}
+	return errors.Join(errs...)
+}
+
 func (i *OperatorInstall) possibleInstallModes(watchNamespaces []string) sets.Set[string] {
 	switch len(watchNamespaces) {
 	case 0:
@@ -115,22 +219,24 @@ func (i *OperatorInstall) getPackageManifest(ctx context.Context) (*operator.Pac
 	return &operator.PackageManifest{PackageManifest: *pm}, nil
 }
 
-func (i *OperatorInstall) ensureOperatorGroup(ctx context.Context, pm *operator.PackageManifest, pc *operator.PackageChannel) (*v1.OperatorGroup, error) {
+// ensureOperatorGroup returns the operator group for the install namespace, creating it if
+// necessary. The returned bool reports whether the operator group was created.
+func (i *OperatorInstall) ensureOperatorGroup(ctx context.Context, pm *operator.PackageManifest, pc *operator.PackageChannel) (*v1.OperatorGroup, bool, error) {
 	og, err := i.getOperatorGroup(ctx)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	operatorInstallModes := pc.GetSupportedInstallModes()
 	if operatorInstallModes.Len() == 0 {
-		return nil, fmt.Errorf("operator %q is not installable: operator defined no supported install modes", pm.Name)
+		return nil, false, fmt.Errorf("operator %q is not installable: operator defined no supported install modes", pm.Name)
 	}
 
 	desired := i.possibleInstallModes(i.WatchNamespaces)
 
 	supported := operatorInstallModes.Intersection(desired)
 	if supported.Len() == 0 {
-		return nil, fmt.Errorf("operator %q is not installable: install modes supported by operator (%q) not compatible with install modes supported by desired watches (%q)",
+		return nil, false, fmt.Errorf("operator %q is not installable: install modes supported by operator (%q) not compatible with install modes supported by desired watches (%q)",
 			pm.Name,
 			strings.Join(sets.List[string](operatorInstallModes), ","),
 			strings.Join(sets.List[string](desired), ","),
@@ -139,20 +245,20 @@ func (i *OperatorInstall) ensureOperatorGroup(ctx context.Context, pm *operator.
 
 	if og != nil {
 		if err := i.validateOperatorGroup(*og, operatorInstallModes, desired); err != nil {
-			return nil, fmt.Errorf("operator %q not installable: %v", pm.Name, err)
+			return nil, false, fmt.Errorf("operator %q not installable: %v", pm.Name, err)
 		}
-		return og, nil
+		return og, false, nil
 	}
 
 	if !i.CreateOperatorGroup {
-		return nil, fmt.Errorf("namespace %q has no existing operator group; use --create-operator-group to create one automatically", i.config.Namespace)
+		return nil, false, fmt.Errorf("namespace %q has no existing operator group; use --create-operator-group to create one automatically", i.config.Namespace)
 	}
 	targetNamespaces := i.getTargetNamespaces(supported)
 	if og, err = i.createOperatorGroup(ctx, targetNamespaces); err != nil {
-		return nil, fmt.Errorf("create operator group: %v", err)
+		return nil, false, fmt.Errorf("create operator group: %v", err)
 	}
 	i.Logf("operatorgroup %q created", og.Name)
-	return og, nil
+	return og, true, nil
 }
 
 func (i OperatorInstall) validateOperatorGroup(og v1.OperatorGroup, operatorInstallModes, desired sets.Set[string]) error {
@@ -234,6 +340,12 @@ func (i *OperatorInstall) createSubscription(ctx context.Context, pm *operator.P
 		opts = append(opts, subscription.StartingCSV(startingCSV))
 	}
 
+	configOpts, err := i.Config.Options()
+	if err != nil {
+		return nil, fmt.Errorf("subscription config: %v", err)
+	}
+	opts = append(opts, configOpts...)
+
 	subKey := types.NamespacedName{
 		Namespace: i.config.Namespace,
 		Name:      i.Package,
@@ -283,28 +395,3 @@ func guessStartingCSV(csvNameExample, desiredVersion string) (string, error) {
 }
 
 var semverRegexp = regexp.MustCompile(`(?P<major>0|[1-9]\d*)\.(?P<minor>0|[1-9]\d*)\.(?P<patch>0|[1-9]\d*)(?:-(?P<prerelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+(?P<buildmetadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`) //nolint:lll
-
-func (i *OperatorInstall) getInstallPlan(ctx context.Context, sub *v1alpha1.Subscription) (*v1alpha1.InstallPlan, error) {
-	subKey := objectKeyForObject(sub)
-	if err := wait.PollUntilContextCancel(ctx, time.Millisecond*250, true, func(conditionCtx context.Context) (bool, error) {
-		if err := i.config.Client.Get(conditionCtx, subKey, sub); err != nil {
-			return false, err
-		}
-		if sub.Status.InstallPlanRef != nil {
-			return true, nil
-		}
-		return false, nil
-	}); err != nil {
-		return nil, fmt.Errorf("waiting for install plan to exist: %v", err)
-	}
-
-	ip := v1alpha1.InstallPlan{}
-	ipKey := types.NamespacedName{
-		Namespace: sub.Status.InstallPlanRef.Namespace,
-		Name:      sub.Status.InstallPlanRef.Name,
-	}
-	if err := i.config.Client.Get(ctx, ipKey, &ip); err != nil {
-		return nil, fmt.Errorf("get install plan: %v", err)
-	}
-	return &ip, nil
-}
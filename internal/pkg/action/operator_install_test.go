@@ -0,0 +1,128 @@
+package action_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorInstall", func() {
+	const etcd = "etcd"
+	var (
+		cfg action.Configuration
+		pm  *operatorsv1.PackageManifest
+	)
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		pm = &operatorsv1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      etcd,
+				Namespace: "etcd-namespace",
+			},
+			Status: operatorsv1.PackageManifestStatus{
+				CatalogSource:          "operatorhubio",
+				CatalogSourceNamespace: "olm",
+				DefaultChannel:         "singlenamespace-alpha",
+				Channels: []operatorsv1.PackageChannel{
+					{
+						Name:       "singlenamespace-alpha",
+						CurrentCSV: "etcdoperator.v0.9.4",
+						CurrentCSVDesc: operatorsv1.CSVDescription{
+							InstallModes: []v1alpha1.InstallMode{
+								{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		cl := fake.NewClientBuilder().
+			WithObjects(pm).
+			WithScheme(sch).
+			Build()
+		cfg.Scheme = sch
+		cfg.Client = cl
+		cfg.Namespace = "etcd-namespace"
+	})
+
+	It("should remove the subscription and operator group it created when the install plan never appears", func() {
+		installer := internalaction.NewOperatorInstall(&cfg)
+		installer.Package = etcd
+		installer.CreateOperatorGroup = true
+		installer.CleanupTimeout = time.Minute
+
+		var logs, messages []string
+		installer.Logf = func(f string, a ...interface{}) {
+			logs = append(logs, f)
+			messages = append(messages, fmt.Sprintf(f, a...))
+		}
+
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+		_, err := installer.Run(ctx)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("waiting for install plan to exist"))
+
+		subKey := types.NamespacedName{Name: etcd, Namespace: "etcd-namespace"}
+		Expect(cfg.Client.Get(context.TODO(), subKey, &v1alpha1.Subscription{})).To(WithTransform(apierrors.IsNotFound, BeTrue()))
+
+		ogKey := types.NamespacedName{Name: "etcd-namespace", Namespace: "etcd-namespace"}
+		Expect(cfg.Client.Get(context.TODO(), ogKey, &v1.OperatorGroup{})).To(WithTransform(apierrors.IsNotFound, BeTrue()))
+
+		Expect(logs).To(ContainElement("%s %q removed"))
+		Expect(messages).To(ContainElements(`subscription "etcd" removed`, `operatorgroup "etcd-namespace" removed`))
+	})
+
+	It("should not remove an operator group that already existed", func() {
+		og := &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "existing",
+				Namespace: "etcd-namespace",
+			},
+			Status: v1.OperatorGroupStatus{Namespaces: []string{"etcd-namespace"}},
+		}
+		Expect(cfg.Client.Create(context.TODO(), og)).To(Succeed())
+
+		installer := internalaction.NewOperatorInstall(&cfg)
+		installer.Package = etcd
+		installer.CleanupTimeout = time.Minute
+
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+		_, err := installer.Run(ctx)
+		Expect(err).NotTo(BeNil())
+
+		subKey := types.NamespacedName{Name: etcd, Namespace: "etcd-namespace"}
+		Expect(cfg.Client.Get(context.TODO(), subKey, &v1alpha1.Subscription{})).To(WithTransform(apierrors.IsNotFound, BeTrue()))
+
+		ogKey := types.NamespacedName{Name: "existing", Namespace: "etcd-namespace"}
+		Expect(cfg.Client.Get(context.TODO(), ogKey, &v1.OperatorGroup{})).To(Succeed())
+	})
+
+	It("should not clean up when nothing was created", func() {
+		installer := internalaction.NewOperatorInstall(&cfg)
+		installer.Package = "missing"
+		_, err := installer.Run(context.TODO())
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("get package manifest"))
+	})
+})
@@ -0,0 +1,146 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// OperatorHealth joins a subscription with the state of its CSV and pending install plan.
+type OperatorHealth struct {
+	Subscription v1alpha1.Subscription
+
+	// CSVPhase and CSVReason describe the subscription's installed CSV. CSVPhase is empty if
+	// the CSV could not be found.
+	CSVPhase  v1alpha1.ClusterServiceVersionPhase
+	CSVReason string
+
+	// PendingInstallPlan is the name of an install plan waiting for approval, if any.
+	PendingInstallPlan string
+	Approval           v1alpha1.Approval
+	Approved           bool
+
+	// UnhealthyCatalogs lists the catalogs the subscription depends on that OLM reports as unhealthy.
+	UnhealthyCatalogs []types.NamespacedName
+
+	// ResolutionFailed is the message of the subscription's ResolutionFailed condition, if set.
+	ResolutionFailed string
+}
+
+// Problems returns a human-readable description of each problem found for the operator.
+func (h OperatorHealth) Problems() []string {
+	var problems []string
+	if h.ResolutionFailed != "" {
+		problems = append(problems, fmt.Sprintf("resolution failed: %s", h.ResolutionFailed))
+	}
+	switch h.CSVPhase {
+	case v1alpha1.CSVPhaseSucceeded:
+	case v1alpha1.CSVPhaseNone:
+		if name := csvNameFromSubscription(&h.Subscription); name != "" {
+			problems = append(problems, fmt.Sprintf("csv %q not found", name))
+		} else {
+			problems = append(problems, "no csv installed")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("csv phase %s%s", h.CSVPhase, formatReason(h.CSVReason, "")))
+	}
+	if h.PendingInstallPlan != "" && !h.Approved {
+		problems = append(problems, fmt.Sprintf("installplan %q requires approval", h.PendingInstallPlan))
+	}
+	for _, c := range h.UnhealthyCatalogs {
+		problems = append(problems, fmt.Sprintf("catalog %s unhealthy", c))
+	}
+	return problems
+}
+
+// OperatorListHealth lists installed operators along with their health.
+type OperatorListHealth struct {
+	config *action.Configuration
+}
+
+func NewOperatorListHealth(cfg *action.Configuration) *OperatorListHealth {
+	return &OperatorListHealth{cfg}
+}
+
+// Run returns the health of every installed operator. Operators with problems are sorted first,
+// followed by healthy operators, each group ordered by package name.
+func (l *OperatorListHealth) Run(ctx context.Context) ([]OperatorHealth, error) {
+	subs, err := NewOperatorList(l.config).Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := make([]OperatorHealth, 0, len(subs))
+	for _, sub := range subs {
+		h, err := l.healthFor(ctx, sub)
+		if err != nil {
+			return nil, err
+		}
+		health = append(health, *h)
+	}
+
+	sort.SliceStable(health, func(i, j int) bool {
+		pi, pj := len(health[i].Problems()) > 0, len(health[j].Problems()) > 0
+		if pi != pj {
+			return pi
+		}
+		if health[i].Subscription.Spec.Package != health[j].Subscription.Spec.Package {
+			return health[i].Subscription.Spec.Package < health[j].Subscription.Spec.Package
+		}
+		return health[i].Subscription.Namespace < health[j].Subscription.Namespace
+	})
+	return health, nil
+}
+
+func (l *OperatorListHealth) healthFor(ctx context.Context, sub v1alpha1.Subscription) (*OperatorHealth, error) {
+	h := &OperatorHealth{Subscription: sub}
+
+	if name := csvNameFromSubscription(&sub); name != "" {
+		csv := v1alpha1.ClusterServiceVersion{}
+		key := types.NamespacedName{Namespace: sub.Namespace, Name: name}
+		if err := l.config.Client.Get(ctx, key, &csv); err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("get csv %q: %v", key, err)
+		} else if err == nil {
+			h.CSVPhase = csv.Status.Phase
+			h.CSVReason = string(csv.Status.Reason)
+		}
+	}
+
+	if ref := sub.Status.InstallPlanRef; ref != nil && sub.Status.State == v1alpha1.SubscriptionStateUpgradePending {
+		ip := v1alpha1.InstallPlan{}
+		key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		if err := l.config.Client.Get(ctx, key, &ip); err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("get install plan %q: %v", key, err)
+		} else if err == nil && ip.Status.Phase == v1alpha1.InstallPlanPhaseRequiresApproval {
+			h.PendingInstallPlan = ip.Name
+			h.Approval = ip.Spec.Approval
+			h.Approved = ip.Spec.Approved
+		}
+	}
+
+	for _, ch := range sub.Status.CatalogHealth {
+		if ch.Healthy || ch.CatalogSourceRef == nil {
+			continue
+		}
+		h.UnhealthyCatalogs = append(h.UnhealthyCatalogs, types.NamespacedName{
+			Namespace: ch.CatalogSourceRef.Namespace,
+			Name:      ch.CatalogSourceRef.Name,
+		})
+	}
+
+	if cond := sub.Status.GetCondition(v1alpha1.SubscriptionResolutionFailed); cond.Status == corev1.ConditionTrue {
+		h.ResolutionFailed = cond.Message
+		if h.ResolutionFailed == "" {
+			h.ResolutionFailed = cond.Reason
+		}
+	}
+	return h, nil
+}
@@ -0,0 +1,97 @@
+package action_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorListHealth", func() {
+	var cfg action.Configuration
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		healthy := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "aaa", Namespace: "ns"},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "aaa"},
+			Status: v1alpha1.SubscriptionStatus{
+				InstalledCSV: "aaa.v1.0.0",
+				CurrentCSV:   "aaa.v1.0.0",
+				State:        v1alpha1.SubscriptionStateAtLatest,
+			},
+		}
+		healthyCSV := &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "aaa.v1.0.0", Namespace: "ns"},
+			Status:     v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseSucceeded},
+		}
+
+		pending := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "zzz", Namespace: "ns"},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "zzz"},
+			Status: v1alpha1.SubscriptionStatus{
+				InstalledCSV:   "zzz.v1.0.0",
+				CurrentCSV:     "zzz.v1.1.0",
+				State:          v1alpha1.SubscriptionStateUpgradePending,
+				InstallPlanRef: &corev1.ObjectReference{Name: "install-zzz", Namespace: "ns"},
+				CatalogHealth: []v1alpha1.SubscriptionCatalogHealth{{
+					CatalogSourceRef: &corev1.ObjectReference{Name: "broken", Namespace: "olm"},
+					Healthy:          false,
+				}},
+				Conditions: []v1alpha1.SubscriptionCondition{{
+					Type:    v1alpha1.SubscriptionResolutionFailed,
+					Status:  corev1.ConditionTrue,
+					Message: "constraints not satisfiable",
+				}},
+			},
+		}
+		pendingCSV := &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "zzz.v1.0.0", Namespace: "ns"},
+			Status: v1alpha1.ClusterServiceVersionStatus{
+				Phase:  v1alpha1.CSVPhaseFailed,
+				Reason: v1alpha1.CSVReasonComponentUnhealthy,
+			},
+		}
+		pendingIP := &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "install-zzz", Namespace: "ns"},
+			Spec:       v1alpha1.InstallPlanSpec{Approval: v1alpha1.ApprovalManual},
+			Status:     v1alpha1.InstallPlanStatus{Phase: v1alpha1.InstallPlanPhaseRequiresApproval},
+		}
+
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(healthy, healthyCSV, pending, pendingCSV, pendingIP).
+			WithScheme(sch).
+			Build()
+		cfg.Scheme = sch
+		cfg.Namespace = "ns"
+	})
+
+	It("should sort operators with problems first", func() {
+		health, err := internalaction.NewOperatorListHealth(&cfg).Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(health).To(HaveLen(2))
+
+		Expect(health[0].Subscription.Name).To(Equal("zzz"))
+		Expect(health[0].CSVPhase).To(Equal(v1alpha1.CSVPhaseFailed))
+		Expect(health[0].PendingInstallPlan).To(Equal("install-zzz"))
+		Expect(health[0].Approval).To(Equal(v1alpha1.ApprovalManual))
+		Expect(health[0].UnhealthyCatalogs).To(ConsistOf(types.NamespacedName{Name: "broken", Namespace: "olm"}))
+		Expect(health[0].ResolutionFailed).To(Equal("constraints not satisfiable"))
+		Expect(health[0].Problems()).To(HaveLen(4))
+
+		Expect(health[1].Subscription.Name).To(Equal("aaa"))
+		Expect(health[1].Problems()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,115 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// OperatorRestore reinstalls an operator from a backup written by OperatorUninstall and
+// re-applies the saved operands.
+type OperatorRestore struct {
+	config *action.Configuration
+
+	BackupDir string
+
+	Logf func(string, ...interface{})
+}
+
+func NewOperatorRestore(cfg *action.Configuration) *OperatorRestore {
+	return &OperatorRestore{
+		config: cfg,
+		Logf:   func(string, ...interface{}) {},
+	}
+}
+
+// Run restores the operator into the namespace it was backed up from. Objects that already
+// exist on the cluster are left untouched.
+func (r *OperatorRestore) Run(ctx context.Context) (*v1alpha1.ClusterServiceVersion, error) {
+	b, err := readOperatorBackup(r.BackupDir)
+	if err != nil {
+		return nil, err
+	}
+	sub := b.Subscription
+	namespace := sub.GetNamespace()
+
+	if existing, err := findSubscriptionForPackage(ctx, r.config.Client, namespace, sub.Spec.Package); err == nil {
+		return nil, fmt.Errorf("operator %q is already installed in namespace %q by subscription %q", sub.Spec.Package, namespace, existing.Name)
+	}
+
+	if err := r.ensureOperatorGroup(ctx, namespace, b.OperatorGroup); err != nil {
+		return nil, err
+	}
+
+	if err := r.config.Client.Create(ctx, sub); err != nil {
+		return nil, fmt.Errorf("create subscription: %v", err)
+	}
+	r.Logf("subscription %q created", sub.Name)
+
+	ip, err := waitForInstallPlan(ctx, r.config.Client, sub)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Spec.InstallPlanApproval == v1alpha1.ApprovalManual {
+		if err := approveInstallPlan(ctx, r.config.Client, ip); err != nil {
+			return nil, fmt.Errorf("approve install plan: %v", err)
+		}
+	}
+	csv, err := getCSV(ctx, r.config.Client, ip, r.Logf)
+	if err != nil {
+		return nil, fmt.Errorf("get clusterserviceversion: %w", err)
+	}
+
+	// OLM installs the CRDs owned by the CSV, so saved CRDs are only created if OLM did not.
+	for i := range b.CRDs {
+		if err := r.createIfMissing(ctx, &b.CRDs[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range b.Operands {
+		if err := r.createIfMissing(ctx, &b.Operands[i]); err != nil {
+			return nil, fmt.Errorf("restore operand: %v", err)
+		}
+	}
+	return csv, nil
+}
+
+// ensureOperatorGroup creates the saved operator group unless the namespace already has one.
+func (r *OperatorRestore) ensureOperatorGroup(ctx context.Context, namespace string, og *v1.OperatorGroup) error {
+	ogs := v1.OperatorGroupList{}
+	if err := r.config.Client.List(ctx, &ogs, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("list operatorgroups: %v", err)
+	}
+	if len(ogs.Items) > 0 {
+		return nil
+	}
+	if og == nil {
+		return fmt.Errorf("namespace %q has no operator group and the backup does not contain one", namespace)
+	}
+	if err := r.config.Client.Create(ctx, og); err != nil {
+		return fmt.Errorf("create operatorgroup: %v", err)
+	}
+	r.Logf("operatorgroup %q created", og.Name)
+	return nil
+}
+
+func (r *OperatorRestore) createIfMissing(ctx context.Context, obj client.Object) error {
+	lowerKind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+	if err := r.config.Client.Create(ctx, obj); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			r.Logf("%s %q already exists", lowerKind, obj.GetName())
+			return nil
+		}
+		return fmt.Errorf("create %s %q: %v", lowerKind, obj.GetName(), err)
+	}
+	r.Logf("%s %q restored", lowerKind, obj.GetName())
+	return nil
+}
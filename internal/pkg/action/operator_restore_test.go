@@ -0,0 +1,95 @@
+package action_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorRestore", func() {
+	const backup = `apiVersion: operators.coreos.com/v1alpha1
+kind: Subscription
+metadata:
+  name: etcd-sub
+  namespace: etcd-namespace
+spec:
+  channel: alpha
+  name: etcd
+  source: operatorhubio
+  sourceNamespace: olm
+  startingCSV: etcdoperator.v0.9.4
+`
+	const operatorGroup = `apiVersion: operators.coreos.com/v1
+kind: OperatorGroup
+metadata:
+  name: etcd
+  namespace: etcd-namespace
+`
+	var (
+		cfg       action.Configuration
+		backupDir string
+	)
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		backupDir, err = os.MkdirTemp("", "operator-restore-")
+		Expect(err).To(BeNil())
+		Expect(os.WriteFile(filepath.Join(backupDir, "subscription.yaml"), []byte(backup), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(backupDir, "operatorgroup.yaml"), []byte(operatorGroup), 0600)).To(Succeed())
+
+		cfg.Scheme = sch
+		cfg.Client = fake.NewClientBuilder().WithScheme(sch).Build()
+		cfg.Namespace = "default"
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(backupDir)).To(Succeed())
+	})
+
+	It("should recreate the operator group and subscription from the backup", func() {
+		ctx, cancel := context.WithTimeout(context.TODO(), 500*time.Millisecond)
+		defer cancel()
+
+		restorer := internalaction.NewOperatorRestore(&cfg)
+		restorer.BackupDir = backupDir
+		_, err := restorer.Run(ctx)
+		// The fake client never generates an install plan.
+		Expect(err).To(MatchError(ContainSubstring("waiting for install plan to exist")))
+
+		og := &v1.OperatorGroup{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "etcd", Namespace: "etcd-namespace"}, og)).To(Succeed())
+
+		sub := &v1alpha1.Subscription{}
+		Expect(cfg.Client.Get(context.TODO(), types.NamespacedName{Name: "etcd-sub", Namespace: "etcd-namespace"}, sub)).To(Succeed())
+		Expect(sub.Spec.StartingCSV).To(Equal("etcdoperator.v0.9.4"))
+	})
+
+	It("should fail if the operator is already installed", func() {
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd"},
+		}
+		Expect(cfg.Client.Create(context.TODO(), sub)).To(Succeed())
+
+		restorer := internalaction.NewOperatorRestore(&cfg)
+		restorer.BackupDir = backupDir
+		_, err := restorer.Run(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring("already installed")))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,7 +29,23 @@ type OperatorUninstall struct {
 	DeleteOperator           bool
 	DeleteOperatorGroups     bool
 	DeleteOperatorGroupNames []string
-	Logf                     func(string, ...interface{})
+
+	// OperandSelector restricts the operands deleted by the operand strategy to those matching
+	// any of the label selectors or kinds. Other operands are orphaned.
+	OperandSelector []string
+	// OperandFinalizerTimeout is how long to wait for each deleted operand to finalize. Zero
+	// waits until the context is done.
+	OperandFinalizerTimeout time.Duration
+	// StripFinalizers removes the finalizers of operands that did not finalize within
+	// OperandFinalizerTimeout.
+	StripFinalizers bool
+	// BackupDir is a directory to save the subscription, operator group, owned CRDs and operands
+	// to before anything is deleted. The backup can be restored with OperatorRestore.
+	BackupDir string
+
+	Logf func(string, ...interface{})
+
+	operandSelector *operand.Selector
 }
 
 func NewOperatorUninstall(cfg *action.Configuration) *OperatorUninstall {
@@ -47,22 +64,71 @@ func (e ErrPackageNotFound) Error() string {
 	return fmt.Sprintf("package %q not found", e.PackageName)
 }
 
-func (u *OperatorUninstall) Run(ctx context.Context) error {
+// StuckOperand is an operand that did not finalize in time, along with the finalizers that
+// block its deletion.
+type StuckOperand struct {
+	Kind       string
+	Namespace  string
+	Name       string
+	Finalizers []string
+}
+
+// ErrOperandsStuck is returned when deleted operands do not finalize within the operand
+// finalizer timeout.
+type ErrOperandsStuck struct {
+	Operands []StuckOperand
+}
+
+func (e ErrOperandsStuck) Error() string {
+	stuck := make([]string, 0, len(e.Operands))
+	for _, op := range e.Operands {
+		name := op.Name
+		if op.Namespace != "" {
+			name = op.Namespace + "/" + op.Name
+		}
+		stuck = append(stuck, fmt.Sprintf("%s %q (finalizers: %s)", strings.ToLower(op.Kind), name, strings.Join(op.Finalizers, ", ")))
+	}
+	return fmt.Sprintf("%d operand(s) did not finalize in time: %s", len(e.Operands), strings.Join(stuck, "; "))
+}
+
+// uninstallTargets are the objects found on-cluster for the operator being uninstalled.
+type uninstallTargets struct {
+	sub      *v1alpha1.Subscription
+	csv      *v1alpha1.ClusterServiceVersion
+	operands *unstructured.UnstructuredList
+}
+
+// targets validates the uninstall options and looks up the objects to uninstall.
+func (u *OperatorUninstall) targets(ctx context.Context) (*uninstallTargets, error) {
 	if u.DeleteAll {
 		u.DeleteOperator = true
 		u.DeleteOperatorGroups = true
 	}
 	if u.DeleteOperator {
+		if len(u.OperandSelector) > 0 {
+			return nil, fmt.Errorf("operand selector cannot be used when deleting the operator, which deletes all operands")
+		}
 		u.OperandStrategy = operand.Delete
 	}
 
+	if len(u.OperandSelector) > 0 {
+		sel, err := operand.ParseSelector(u.OperandSelector)
+		if err != nil {
+			return nil, err
+		}
+		u.operandSelector = sel
+		if u.OperandStrategy == operand.Abort {
+			u.OperandStrategy = operand.Delete
+		}
+	}
+
 	if err := u.OperandStrategy.Valid(); err != nil {
-		return err
+		return nil, err
 	}
 
 	subs := v1alpha1.SubscriptionList{}
 	if err := u.config.Client.List(ctx, &subs, client.InNamespace(u.config.Namespace)); err != nil {
-		return fmt.Errorf("list subscriptions: %v", err)
+		return nil, fmt.Errorf("list subscriptions: %v", err)
 	}
 
 	var sub *v1alpha1.Subscription
@@ -74,30 +140,47 @@ func (u *OperatorUninstall) Run(ctx context.Context) error {
 		}
 	}
 	if sub == nil {
-		return &ErrPackageNotFound{u.Package}
+		return nil, &ErrPackageNotFound{u.Package}
 	}
 
 	csv, csvName, err := u.getSubscriptionCSV(ctx, sub)
 	if err != nil && !apierrors.IsNotFound(err) {
 		if csvName == "" {
-			return fmt.Errorf("get subscription csv: %v", err)
+			return nil, fmt.Errorf("get subscription csv: %v", err)
 		}
-		return fmt.Errorf("get subscription csv %q: %v", csvName, err)
+		return nil, fmt.Errorf("get subscription csv %q: %v", csvName, err)
 	}
 
 	// find operands related to the operator on cluster
 	lister := action.NewOperatorListOperands(u.config)
+	lister.Logf = u.Logf
 	operands, err := lister.Run(ctx, u.Package)
 	if err != nil {
-		return fmt.Errorf("list operands for operator %q: %v", u.Package, err)
+		return nil, fmt.Errorf("list operands for operator %q: %v", u.Package, err)
 	}
 	// validate the provided deletion strategy before proceeding to deletion
 	if err := u.validStrategy(operands); err != nil {
-		return fmt.Errorf("could not proceed with deletion of %q: %w", u.Package, err)
+		return nil, fmt.Errorf("could not proceed with deletion of %q: %w", u.Package, err)
+	}
+	return &uninstallTargets{sub: sub, csv: csv, operands: operands}, nil
+}
+
+func (u *OperatorUninstall) Run(ctx context.Context) error {
+	t, err := u.targets(ctx)
+	if err != nil {
+		return err
+	}
+	sub, csv, operands := t.sub, t.csv, t.operands
+
+	if u.BackupDir != "" {
+		if err := u.backup(ctx, sub, csv, operands); err != nil {
+			return fmt.Errorf("back up operator %q: %v", u.Package, err)
+		}
+		u.Logf("operator %q backed up to %q", u.Package, u.BackupDir)
 	}
 
 	/*
-		Deletion order:
+		Deletion order, which Plan mirrors:
 			1. Subscription to prevent further installs or upgrades of the operator while cleaning up.
 
 			If the CSV exists:
@@ -113,6 +196,7 @@ func (u *OperatorUninstall) Run(ctx context.Context) error {
 	*/
 
 	// Subscriptions can be deleted asynchronously.
+	sub.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SubscriptionKind))
 	if err := u.deleteObjects(ctx, sub); err != nil {
 		return err
 	}
@@ -142,6 +226,37 @@ func (u *OperatorUninstall) Run(ctx context.Context) error {
 	return nil
 }
 
+// backup saves the subscription, the namespace's operator group, the CRDs owned by the CSV
+// and the operands to the backup directory.
+func (u *OperatorUninstall) backup(ctx context.Context, sub *v1alpha1.Subscription, csv *v1alpha1.ClusterServiceVersion, operands *unstructured.UnstructuredList) error {
+	b := operatorBackup{
+		Subscription: sub,
+		Operands:     operands.Items,
+	}
+
+	ogs := v1.OperatorGroupList{}
+	if err := u.config.Client.List(ctx, &ogs, client.InNamespace(u.config.Namespace)); err != nil {
+		return fmt.Errorf("list operatorgroups: %v", err)
+	}
+	if len(ogs.Items) == 1 {
+		b.OperatorGroup = &ogs.Items[0]
+	}
+
+	if csv != nil {
+		for _, desc := range csv.Spec.CustomResourceDefinitions.Owned {
+			crd := apiextensionsv1.CustomResourceDefinition{}
+			if err := u.config.Client.Get(ctx, types.NamespacedName{Name: desc.Name}, &crd); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("get crd %q: %v", desc.Name, err)
+			}
+			b.CRDs = append(b.CRDs, crd)
+		}
+	}
+	return b.write(u.BackupDir)
+}
+
 func (u *OperatorUninstall) operatorName() string {
 	return fmt.Sprintf("%s.%s", u.Package, u.config.Namespace)
 }
@@ -255,6 +370,7 @@ func (u *OperatorUninstall) deleteOperatorGroup(ctx context.Context) error {
 		}
 		for _, og := range ogs.Items {
 			og := og
+			og.SetGroupVersionKind(v1.GroupVersion.WithKind(v1.OperatorGroupKind))
 			if len(u.DeleteOperatorGroupNames) == 0 || contains(u.DeleteOperatorGroupNames, og.GetName()) {
 				if err := u.deleteObjects(ctx, &og); err != nil {
 					return err
@@ -279,12 +395,9 @@ func (u *OperatorUninstall) deleteCSVRelatedResources(ctx context.Context, csv *
 		for _, op := range operands.Items {
 			u.Logf("%s %q orphaned", strings.ToLower(op.GetKind()), prettyPrint(op))
 		}
-	case operand.Delete:
-		for _, op := range operands.Items {
-			op := op
-			if err := u.deleteObjects(ctx, &op); err != nil {
-				return fmt.Errorf("delete operand: %v", err)
-			}
+	case operand.Delete, operand.DeleteNamespaced:
+		if err := u.deleteOperands(ctx, operands); err != nil {
+			return err
 		}
 	}
 
@@ -298,6 +411,95 @@ func (u *OperatorUninstall) deleteCSVRelatedResources(ctx context.Context, csv *
 	return nil
 }
 
+// deleteOperands deletes the operands selected by the operand strategy and selector, orphaning
+// the rest. Operands that do not finalize within the finalizer timeout are reported, or have
+// their finalizers stripped.
+func (u *OperatorUninstall) deleteOperands(ctx context.Context, operands *unstructured.UnstructuredList) error {
+	var toDelete []unstructured.Unstructured
+	for _, op := range operands.Items {
+		if !u.deletesOperand(op) {
+			u.Logf("%s %q orphaned", strings.ToLower(op.GetKind()), prettyPrint(op))
+			continue
+		}
+		toDelete = append(toDelete, op)
+	}
+
+	var stuck []StuckOperand
+	for i := range toDelete {
+		op := &toDelete[i]
+		lowerKind := strings.ToLower(op.GetKind())
+		if err := u.config.Client.Delete(ctx, op); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete operand: delete %s %q: %v", lowerKind, op.GetName(), err)
+		} else if err == nil {
+			u.Logf("%s %q deleted", lowerKind, op.GetName())
+		}
+
+		finalized, err := u.waitForOperandDeletion(ctx, op)
+		if err != nil {
+			return fmt.Errorf("delete operand: %v", err)
+		}
+		if finalized {
+			continue
+		}
+		if !u.StripFinalizers {
+			stuck = append(stuck, StuckOperand{
+				Kind:       op.GetKind(),
+				Namespace:  op.GetNamespace(),
+				Name:       op.GetName(),
+				Finalizers: op.GetFinalizers(),
+			})
+			continue
+		}
+		if err := u.stripFinalizers(ctx, op); err != nil {
+			return fmt.Errorf("delete operand: %v", err)
+		}
+	}
+	if len(stuck) > 0 {
+		return &ErrOperandsStuck{Operands: stuck}
+	}
+	return nil
+}
+
+// deletesOperand returns true if the operand strategy and selector select op for deletion.
+func (u *OperatorUninstall) deletesOperand(op unstructured.Unstructured) bool {
+	return u.OperandStrategy.Deletes(op.GetNamespace() != "") && u.operandSelector.Matches(op)
+}
+
+// waitForOperandDeletion waits for the operand to be deleted. It returns false if the operand
+// still exists after the finalizer timeout, in which case op holds its latest state.
+func (u *OperatorUninstall) waitForOperandDeletion(ctx context.Context, op *unstructured.Unstructured) (bool, error) {
+	if u.OperandFinalizerTimeout <= 0 {
+		return true, waitForDeletion(ctx, u.config.Client, op)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, u.OperandFinalizerTimeout)
+	defer cancel()
+	err := waitForDeletion(waitCtx, u.config.Client, op)
+	if err == nil {
+		return true, nil
+	}
+	if ctx.Err() != nil || waitCtx.Err() == nil {
+		return false, err
+	}
+	if err := u.config.Client.Get(ctx, objectKeyForObject(op), op); apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+func (u *OperatorUninstall) stripFinalizers(ctx context.Context, op *unstructured.Unstructured) error {
+	lowerKind := strings.ToLower(op.GetKind())
+	finalizers := op.GetFinalizers()
+	patch := client.MergeFrom(op.DeepCopy())
+	op.SetFinalizers(nil)
+	if err := u.config.Client.Patch(ctx, op, patch); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("remove finalizers from %s %q: %v", lowerKind, op.GetName(), err)
+	}
+	u.Logf("%s %q finalizers removed: %s", lowerKind, op.GetName(), strings.Join(finalizers, ", "))
+	return waitForDeletion(ctx, u.config.Client, op)
+}
+
 func csvNameFromSubscription(subscription *v1alpha1.Subscription) string {
 	if subscription.Status.InstalledCSV != "" {
 		return subscription.Status.InstalledCSV
@@ -0,0 +1,157 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// UninstallPlanAction is what an uninstall does with an object.
+type UninstallPlanAction string
+
+const (
+	PlanDelete UninstallPlanAction = "delete"
+	PlanOrphan UninstallPlanAction = "orphan"
+)
+
+// UninstallPlanStep is a single object affected by an uninstall.
+type UninstallPlanStep struct {
+	Action     UninstallPlanAction `json:"action"`
+	APIVersion string              `json:"apiVersion"`
+	Kind       string              `json:"kind"`
+	Namespace  string              `json:"namespace,omitempty"`
+	Name       string              `json:"name"`
+}
+
+// UninstallPlan lists the objects an uninstall affects, in the order they are processed.
+type UninstallPlan struct {
+	Package string              `json:"package"`
+	Steps   []UninstallPlanStep `json:"steps"`
+}
+
+// Plan returns the objects that Run would delete or orphan, without changing the cluster.
+// Objects are listed in deletion order:
+//  1. the subscription
+//  2. the operands, if the CSV exists
+//  3. the CSV
+//  4. the objects referenced by the operator object and the operator itself, if the operator
+//     is deleted
+//  5. the operator groups, if they are deleted and no other subscriptions remain
+func (u *OperatorUninstall) Plan(ctx context.Context) (*UninstallPlan, error) {
+	t, err := u.targets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planBuilder{plan: &UninstallPlan{Package: u.Package}, seen: map[planKey]struct{}{}}
+	p.add(PlanDelete, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SubscriptionKind), t.sub)
+
+	if t.csv != nil {
+		for _, op := range t.operands.Items {
+			op := op
+			action := PlanOrphan
+			if u.deletesOperand(op) {
+				action = PlanDelete
+			}
+			p.add(action, op.GroupVersionKind(), &op)
+		}
+		p.add(PlanDelete, v1alpha1.SchemeGroupVersion.WithKind(csvKind), t.csv)
+	}
+
+	if u.DeleteOperator {
+		if err := u.planOperator(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+
+	if u.DeleteOperatorGroups {
+		if err := u.planOperatorGroups(ctx, p, t.sub); err != nil {
+			return nil, err
+		}
+	}
+	return p.plan, nil
+}
+
+func (u *OperatorUninstall) planOperator(ctx context.Context, p *planBuilder) error {
+	var op v1.Operator
+	if err := u.config.Client.Get(ctx, types.NamespacedName{Name: u.operatorName()}, &op); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get operator: %v", err)
+	}
+	if op.Status.Components != nil {
+		for _, ref := range op.Status.Components.Refs {
+			if ref.ObjectReference == nil {
+				continue
+			}
+			p.addRef(PlanDelete, ref.GroupVersionKind(), ref.Namespace, ref.Name)
+		}
+	}
+	p.add(PlanDelete, v1.GroupVersion.WithKind("Operator"), &op)
+	return nil
+}
+
+func (u *OperatorUninstall) planOperatorGroups(ctx context.Context, p *planBuilder, sub *v1alpha1.Subscription) error {
+	subs := v1alpha1.SubscriptionList{}
+	if err := u.config.Client.List(ctx, &subs, client.InNamespace(u.config.Namespace)); err != nil {
+		return fmt.Errorf("list subscriptions: %v", err)
+	}
+	for _, s := range subs.Items {
+		if s.Name != sub.Name {
+			return nil
+		}
+	}
+
+	ogs := v1.OperatorGroupList{}
+	if err := u.config.Client.List(ctx, &ogs, client.InNamespace(u.config.Namespace)); err != nil {
+		return fmt.Errorf("list operatorgroups: %v", err)
+	}
+	for _, og := range ogs.Items {
+		og := og
+		if len(u.DeleteOperatorGroupNames) == 0 || contains(u.DeleteOperatorGroupNames, og.GetName()) {
+			p.add(PlanDelete, v1.GroupVersion.WithKind("OperatorGroup"), &og)
+		}
+	}
+	return nil
+}
+
+type planKey struct {
+	gk        schema.GroupKind
+	namespace string
+	name      string
+}
+
+// planBuilder adds each object to the plan once, since the operator object references the
+// subscription, CSV and operands that are planned separately.
+type planBuilder struct {
+	plan *UninstallPlan
+	seen map[planKey]struct{}
+}
+
+func (p *planBuilder) add(action UninstallPlanAction, gvk schema.GroupVersionKind, obj client.Object) {
+	p.addRef(action, gvk, obj.GetNamespace(), obj.GetName())
+}
+
+func (p *planBuilder) addRef(action UninstallPlanAction, gvk schema.GroupVersionKind, namespace, name string) {
+	key := planKey{gk: gvk.GroupKind(), namespace: namespace, name: name}
+	if _, ok := p.seen[key]; ok {
+		return
+	}
+	p.seen[key] = struct{}{}
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	p.plan.Steps = append(p.plan.Steps, UninstallPlanStep{
+		Action:     action,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+	})
+}
@@ -2,6 +2,10 @@ package action_test
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -259,4 +263,158 @@ var _ = Describe("OperatorUninstall", func() {
 		og := &v1.OperatorGroup{}
 		Expect(cfg.Client.Get(context.TODO(), ogKey, og)).To(WithTransform(apierrors.IsNotFound, BeTrue()))
 	})
+	It("should keep cluster-scoped operands when delete-namespaced strategy is set", func() {
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.OperandStrategy = operand.DeleteNamespaced
+		Expect(uninstaller.Run(context.TODO())).To(Succeed())
+
+		etcd1Key := types.NamespacedName{Name: "cluster1", Namespace: "ns1"}
+		Expect(cfg.Client.Get(context.TODO(), etcd1Key, etcdcluster1)).To(WithTransform(apierrors.IsNotFound, BeTrue()))
+
+		etcd3Key := types.NamespacedName{Name: "cluster3"}
+		Expect(cfg.Client.Get(context.TODO(), etcd3Key, etcdcluster3)).To(Succeed())
+	})
+	It("should only delete operands matching the operand selector", func() {
+		etcdcluster2.SetLabels(map[string]string{"app": "delete-me"})
+		Expect(cfg.Client.Update(context.TODO(), etcdcluster2)).To(Succeed())
+
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.OperandSelector = []string{"app=delete-me"}
+		Expect(uninstaller.Run(context.TODO())).To(Succeed())
+
+		etcd1Key := types.NamespacedName{Name: "cluster1", Namespace: "ns1"}
+		Expect(cfg.Client.Get(context.TODO(), etcd1Key, etcdcluster1)).To(Succeed())
+
+		etcd2Key := types.NamespacedName{Name: "cluster2", Namespace: "ns2"}
+		Expect(cfg.Client.Get(context.TODO(), etcd2Key, etcdcluster2)).To(WithTransform(apierrors.IsNotFound, BeTrue()))
+
+		etcd3Key := types.NamespacedName{Name: "cluster3"}
+		Expect(cfg.Client.Get(context.TODO(), etcd3Key, etcdcluster3)).To(Succeed())
+	})
+	It("should fail when an operand selector is used with operator deletion", func() {
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.DeleteOperator = true
+		uninstaller.OperandSelector = []string{"EtcdCluster"}
+		Expect(uninstaller.Run(context.TODO())).NotTo(Succeed())
+	})
+	It("should report operands stuck on finalizers", func() {
+		etcdcluster1.SetFinalizers([]string{"etcd.database.coreos.com/backup"})
+		Expect(cfg.Client.Update(context.TODO(), etcdcluster1)).To(Succeed())
+
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.OperandStrategy = operand.Delete
+		uninstaller.OperandFinalizerTimeout = 500 * time.Millisecond
+		err := uninstaller.Run(context.TODO())
+
+		stuckErr := &internalaction.ErrOperandsStuck{}
+		Expect(errors.As(err, &stuckErr)).To(BeTrue())
+		Expect(stuckErr.Operands).To(Equal([]internalaction.StuckOperand{{
+			Kind:       "EtcdCluster",
+			Namespace:  "ns1",
+			Name:       "cluster1",
+			Finalizers: []string{"etcd.database.coreos.com/backup"},
+		}}))
+
+		csvKey := types.NamespacedName{Name: "etcdoperator.v0.9.4-clusterwide", Namespace: "etcd-namespace"}
+		Expect(cfg.Client.Get(context.TODO(), csvKey, &v1alpha1.ClusterServiceVersion{})).To(Succeed())
+	})
+	It("should strip finalizers of stuck operands when requested", func() {
+		etcdcluster1.SetFinalizers([]string{"etcd.database.coreos.com/backup"})
+		Expect(cfg.Client.Update(context.TODO(), etcdcluster1)).To(Succeed())
+
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.OperandStrategy = operand.Delete
+		uninstaller.OperandFinalizerTimeout = 500 * time.Millisecond
+		uninstaller.StripFinalizers = true
+		Expect(uninstaller.Run(context.TODO())).To(Succeed())
+
+		etcd1Key := types.NamespacedName{Name: "cluster1", Namespace: "ns1"}
+		Expect(cfg.Client.Get(context.TODO(), etcd1Key, etcdcluster1)).To(WithTransform(apierrors.IsNotFound, BeTrue()))
+	})
+	It("should back up the operator and operands before deleting them", func() {
+		etcdcluster1.SetFinalizers([]string{"etcd.database.coreos.com/backup"})
+		etcdcluster1.Object["status"] = map[string]interface{}{"phase": "Running"}
+		Expect(cfg.Client.Update(context.TODO(), etcdcluster1)).To(Succeed())
+
+		backupDir, err := os.MkdirTemp("", "operator-backup-")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(backupDir)
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.OperandStrategy = operand.Delete
+		uninstaller.StripFinalizers = true
+		uninstaller.OperandFinalizerTimeout = 500 * time.Millisecond
+		uninstaller.BackupDir = backupDir
+		Expect(uninstaller.Run(context.TODO())).To(Succeed())
+
+		for _, path := range []string{
+			"subscription.yaml",
+			"operatorgroup.yaml",
+			"crds/etcdclusters.etcd.database.coreos.com.yaml",
+			"operands/etcd.database.coreos.com/v1beta2/EtcdCluster/ns2/cluster2.yaml",
+			"operands/etcd.database.coreos.com/v1beta2/EtcdCluster/_cluster/cluster3.yaml",
+		} {
+			Expect(filepath.Join(backupDir, path)).To(BeARegularFile())
+		}
+
+		data, err := os.ReadFile(filepath.Join(backupDir, "operands/etcd.database.coreos.com/v1beta2/EtcdCluster/ns1/cluster1.yaml"))
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring("name: cluster1"))
+		Expect(string(data)).NotTo(ContainSubstring("resourceVersion"))
+		Expect(string(data)).NotTo(ContainSubstring("finalizers"))
+		Expect(string(data)).NotTo(ContainSubstring("status"))
+
+		data, err = os.ReadFile(filepath.Join(backupDir, "subscription.yaml"))
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring("startingCSV: etcdoperator.v0.9.4-clusterwide"))
+	})
+	It("should not overwrite an existing backup", func() {
+		backupDir, err := os.MkdirTemp("", "operator-backup-")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(backupDir)
+		Expect(os.WriteFile(filepath.Join(backupDir, "subscription.yaml"), nil, 0o600)).To(Succeed())
+
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.OperandStrategy = operand.Delete
+		uninstaller.BackupDir = backupDir
+		Expect(uninstaller.Run(context.TODO())).NotTo(Succeed())
+
+		subKey := types.NamespacedName{Name: "etcd-sub", Namespace: "etcd-namespace"}
+		Expect(cfg.Client.Get(context.TODO(), subKey, &v1alpha1.Subscription{})).To(Succeed())
+	})
+	It("should plan the uninstall without deleting anything", func() {
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.DeleteAll = true
+		plan, err := uninstaller.Plan(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(plan.Steps).To(Equal([]internalaction.UninstallPlanStep{
+			{Action: internalaction.PlanDelete, APIVersion: "operators.coreos.com/v1alpha1", Kind: "Subscription", Namespace: "etcd-namespace", Name: "etcd-sub"},
+			{Action: internalaction.PlanDelete, APIVersion: "etcd.database.coreos.com/v1beta2", Kind: "EtcdCluster", Name: "cluster3"},
+			{Action: internalaction.PlanDelete, APIVersion: "etcd.database.coreos.com/v1beta2", Kind: "EtcdCluster", Namespace: "ns1", Name: "cluster1"},
+			{Action: internalaction.PlanDelete, APIVersion: "etcd.database.coreos.com/v1beta2", Kind: "EtcdCluster", Namespace: "ns2", Name: "cluster2"},
+			{Action: internalaction.PlanDelete, APIVersion: "operators.coreos.com/v1alpha1", Kind: "ClusterServiceVersion", Namespace: "etcd-namespace", Name: "etcdoperator.v0.9.4-clusterwide"},
+			{Action: internalaction.PlanDelete, APIVersion: "operators.coreos.com/v1", Kind: "Operator", Name: "etcd.etcd-namespace"},
+			{Action: internalaction.PlanDelete, APIVersion: "operators.coreos.com/v1", Kind: "OperatorGroup", Namespace: "etcd-namespace", Name: "etcd"},
+		}))
+
+		subKey := types.NamespacedName{Name: "etcd-sub", Namespace: "etcd-namespace"}
+		Expect(cfg.Client.Get(context.TODO(), subKey, &v1alpha1.Subscription{})).To(Succeed())
+	})
+	It("should plan orphaned operands", func() {
+		uninstaller := internalaction.NewOperatorUninstall(&cfg)
+		uninstaller.Package = etcd
+		uninstaller.OperandStrategy = operand.DeleteNamespaced
+		plan, err := uninstaller.Plan(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(plan.Steps).To(ContainElement(internalaction.UninstallPlanStep{
+			Action: internalaction.PlanOrphan, APIVersion: "etcd.database.coreos.com/v1beta2", Kind: "EtcdCluster", Name: "cluster3",
+		}))
+	})
 })
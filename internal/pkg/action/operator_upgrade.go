@@ -5,23 +5,30 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
+// ErrOperatorUpToDate is returned when upgrading an operator whose installed CSV is already the
+// subscription's current CSV. It wraps olmv1.ErrNoChange.
+var ErrOperatorUpToDate = fmt.Errorf("%w - operator is already at latest version", olmv1.ErrNoChange)
+
 type OperatorUpgrade struct {
 	config *action.Configuration
 
 	Package string
 	Channel string
+
+	Logf func(string, ...interface{})
 }
 
 func NewOperatorUpgrade(cfg *action.Configuration) *OperatorUpgrade {
 	return &OperatorUpgrade{
 		config: cfg,
+		Logf:   func(string, ...interface{}) {},
 	}
 }
 
@@ -40,26 +47,15 @@ func (u *OperatorUpgrade) Run(ctx context.Context) (*v1alpha1.ClusterServiceVers
 		return nil, fmt.Errorf("approve install plan: %v", err)
 	}
 
-	csv, err := getCSV(ctx, u.config.Client, ip)
+	csv, err := getCSV(ctx, u.config.Client, ip, u.Logf)
 	if err != nil {
-		return nil, fmt.Errorf("get clusterserviceversion: %v", err)
+		return nil, fmt.Errorf("get clusterserviceversion: %w", err)
 	}
 	return csv, nil
 }
 
 func (u *OperatorUpgrade) findSubscriptionForPackage(ctx context.Context) (*v1alpha1.Subscription, error) {
-	subs := v1alpha1.SubscriptionList{}
-	if err := u.config.Client.List(ctx, &subs, client.InNamespace(u.config.Namespace)); err != nil {
-		return nil, fmt.Errorf("list subscriptions: %v", err)
-	}
-
-	for _, s := range subs.Items {
-		s := s
-		if u.Package == s.Spec.Package {
-			return &s, nil
-		}
-	}
-	return nil, fmt.Errorf("subscription for package %q not found", u.Package)
+	return findSubscriptionForPackage(ctx, u.config.Client, u.config.Namespace, u.Package)
 }
 
 func (u *OperatorUpgrade) getInstallPlan(ctx context.Context, sub *v1alpha1.Subscription) (*v1alpha1.InstallPlan, error) {
@@ -67,7 +63,7 @@ func (u *OperatorUpgrade) getInstallPlan(ctx context.Context, sub *v1alpha1.Subs
 		return nil, fmt.Errorf("subscription does not reference an install plan")
 	}
 	if sub.Status.InstalledCSV == sub.Status.CurrentCSV {
-		return nil, fmt.Errorf("operator is already at latest version")
+		return nil, ErrOperatorUpToDate
 	}
 
 	ip := v1alpha1.InstallPlan{}
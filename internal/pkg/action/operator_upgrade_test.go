@@ -0,0 +1,151 @@
+package action_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+var _ = Describe("OperatorUpgrade", func() {
+	const etcd = "etcd"
+	var (
+		cfg action.Configuration
+		sub *v1alpha1.Subscription
+		ip  *v1alpha1.InstallPlan
+		csv *v1alpha1.ClusterServiceVersion
+	)
+
+	BeforeEach(func() {
+		sch, err := action.NewScheme()
+		Expect(err).To(BeNil())
+
+		sub = &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      etcd,
+				Namespace: "etcd-namespace",
+			},
+			Spec: &v1alpha1.SubscriptionSpec{
+				Package: etcd,
+			},
+			Status: v1alpha1.SubscriptionStatus{
+				InstalledCSV: "etcdoperator.v0.9.2",
+				CurrentCSV:   "etcdoperator.v0.9.4",
+				InstallPlanRef: &corev1.ObjectReference{
+					Name:      "install-abcde",
+					Namespace: "etcd-namespace",
+				},
+			},
+		}
+
+		ip = &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "install-abcde",
+				Namespace: "etcd-namespace",
+			},
+			Status: v1alpha1.InstallPlanStatus{
+				Phase: v1alpha1.InstallPlanPhaseComplete,
+				Plan: []*v1alpha1.Step{
+					{
+						Resolving: "etcdoperator.v0.9.4",
+						Resource: v1alpha1.StepResource{
+							Kind: "ClusterServiceVersion",
+							Name: "etcdoperator.v0.9.4",
+						},
+						Status: v1alpha1.StepStatusCreated,
+					},
+				},
+			},
+		}
+
+		csv = &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcdoperator.v0.9.4",
+				Namespace: "etcd-namespace",
+			},
+			Status: v1alpha1.ClusterServiceVersionStatus{
+				Phase: v1alpha1.CSVPhaseSucceeded,
+			},
+		}
+
+		cfg.Scheme = sch
+		cfg.Namespace = "etcd-namespace"
+	})
+
+	build := func() {
+		cfg.Client = fake.NewClientBuilder().
+			WithObjects(sub, ip, csv).
+			WithScheme(cfg.Scheme).
+			Build()
+	}
+
+	It("should return the csv once it has succeeded", func() {
+		build()
+		upgrader := internalaction.NewOperatorUpgrade(&cfg)
+		upgrader.Package = etcd
+		got, err := upgrader.Run(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(got.Name).To(Equal("etcdoperator.v0.9.4"))
+	})
+
+	It("should fail fast when the install plan fails", func() {
+		ip.Status.Phase = v1alpha1.InstallPlanPhaseFailed
+		ip.Status.Conditions = []v1alpha1.InstallPlanCondition{{
+			Type:    v1alpha1.InstallPlanInstalled,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1alpha1.InstallPlanReasonComponentFailed,
+			Message: "bundle unpacking failed",
+		}}
+		ip.Status.BundleLookups = []v1alpha1.BundleLookup{{
+			Path:       "quay.io/example/etcd-bundle:v0.9.4",
+			Identifier: "etcdoperator.v0.9.4",
+			Conditions: []v1alpha1.BundleLookupCondition{{
+				Type:    v1alpha1.BundleLookupFailed,
+				Status:  corev1.ConditionTrue,
+				Message: "image pull failed",
+			}},
+		}}
+		ip.Status.Plan[0].Status = v1alpha1.StepStatusNotCreated
+		build()
+
+		upgrader := internalaction.NewOperatorUpgrade(&cfg)
+		upgrader.Package = etcd
+		_, err := upgrader.Run(context.TODO())
+
+		var ipErr *internalaction.ErrInstallPlanFailed
+		Expect(errors.As(err, &ipErr)).To(BeTrue())
+		Expect(ipErr.Reason).To(Equal(string(v1alpha1.InstallPlanReasonComponentFailed)))
+		Expect(ipErr.BundleLookup).To(ContainSubstring("image pull failed"))
+		Expect(ipErr.Step).To(ContainSubstring(`clusterserviceversion "etcdoperator.v0.9.4"`))
+	})
+
+	It("should fail fast when the csv fails", func() {
+		csv.Status.Phase = v1alpha1.CSVPhaseFailed
+		csv.Status.Reason = v1alpha1.CSVReasonComponentFailed
+		csv.Status.Message = "install timeout"
+		build()
+
+		var logs []string
+		upgrader := internalaction.NewOperatorUpgrade(&cfg)
+		upgrader.Package = etcd
+		upgrader.Logf = func(f string, a ...interface{}) {
+			logs = append(logs, f)
+		}
+		_, err := upgrader.Run(context.TODO())
+
+		var csvErr *internalaction.ErrCSVFailed
+		Expect(errors.As(err, &csvErr)).To(BeTrue())
+		Expect(csvErr.Error()).To(ContainSubstring("InstallComponentFailed: install timeout"))
+		Expect(logs).To(ContainElement("csv %q phase %s%s"))
+	})
+})
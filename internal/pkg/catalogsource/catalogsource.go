@@ -1,10 +1,16 @@
 package catalogsource
 
 import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/toleration"
 )
 
 type Option func(*v1alpha1.CatalogSource)
@@ -26,6 +32,95 @@ func Publisher(v string) Option {
 	}
 }
 
+func Secrets(v ...string) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		cs.Spec.Secrets = v
+	}
+}
+
+// PollInterval makes OLM poll the catalog's image for updates at the given interval.
+func PollInterval(v time.Duration) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		cs.Spec.UpdateStrategy = &v1alpha1.UpdateStrategy{
+			RegistryPoll: &v1alpha1.RegistryPoll{
+				RawInterval: v.String(),
+				Interval:    &metav1.Duration{Duration: v},
+			},
+		}
+	}
+}
+
+// Priority sets the weight the dependency resolver gives the catalog.
+func Priority(v int) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		cs.Spec.Priority = v
+	}
+}
+
+func ensureGrpcPodConfig(cs *v1alpha1.CatalogSource) *v1alpha1.GrpcPodConfig {
+	if cs.Spec.GrpcPodConfig == nil {
+		cs.Spec.GrpcPodConfig = &v1alpha1.GrpcPodConfig{}
+	}
+	return cs.Spec.GrpcPodConfig
+}
+
+// NodeSelector adds node selector labels to the catalog's pod.
+func NodeSelector(v map[string]string) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureGrpcPodConfig(cs)
+		if cfg.NodeSelector == nil {
+			cfg.NodeSelector = make(map[string]string, len(v))
+		}
+		for k, val := range v {
+			cfg.NodeSelector[k] = val
+		}
+	}
+}
+
+// Tolerations adds tolerations to the catalog's pod.
+func Tolerations(v ...corev1.Toleration) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureGrpcPodConfig(cs)
+		cfg.Tolerations = toleration.Merge(cfg.Tolerations, v...)
+	}
+}
+
+func PriorityClassName(v string) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		ensureGrpcPodConfig(cs).PriorityClassName = &v
+	}
+}
+
+func SecurityContextConfig(v v1alpha1.SecurityConfig) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		ensureGrpcPodConfig(cs).SecurityContextConfig = v
+	}
+}
+
+// MemoryTarget sets the soft memory limit of the catalog's gRPC server.
+func MemoryTarget(v resource.Quantity) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		ensureGrpcPodConfig(cs).MemoryTarget = &v
+	}
+}
+
+// ExtractContent makes the catalog's pod serve the file-based catalog in catalogDir of the
+// index image with OLM's own opm server, using the cache in cacheDir.
+func ExtractContent(catalogDir, cacheDir string) Option {
+	return func(cs *v1alpha1.CatalogSource) {
+		ensureGrpcPodConfig(cs).ExtractContent = &v1alpha1.ExtractContentConfig{
+			CatalogDir: catalogDir,
+			CacheDir:   cacheDir,
+		}
+	}
+}
+
 func Build(key types.NamespacedName, opts ...Option) *v1alpha1.CatalogSource {
 	cs := &v1alpha1.CatalogSource{
 		ObjectMeta: metav1.ObjectMeta{
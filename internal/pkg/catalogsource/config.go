@@ -0,0 +1,98 @@
+package catalogsource
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/toleration"
+)
+
+const (
+	DefaultExtractContentCatalogDir = "/configs"
+	DefaultExtractContentCacheDir   = "/tmp/cache"
+)
+
+// ConfigOptions holds the catalog source settings that can be provided on the command line.
+// Unset settings leave the catalog source unchanged.
+type ConfigOptions struct {
+	PollInterval          time.Duration
+	Priority              *int
+	NodeSelector          map[string]string
+	Tolerations           []string
+	PriorityClassName     string
+	SecurityContextConfig string
+	MemoryTarget          string
+
+	ExtractContent           bool
+	ExtractContentCatalogDir string
+	ExtractContentCacheDir   string
+}
+
+// Options converts the settings into catalog source options.
+func (o ConfigOptions) Options() ([]Option, error) {
+	var opts []Option
+	if o.PollInterval < 0 {
+		return nil, fmt.Errorf("invalid poll interval %s: must not be negative", o.PollInterval)
+	}
+	if o.PollInterval > 0 {
+		opts = append(opts, PollInterval(o.PollInterval))
+	}
+	if o.Priority != nil {
+		opts = append(opts, Priority(*o.Priority))
+	}
+
+	tolerations := make([]corev1.Toleration, 0, len(o.Tolerations))
+	for _, t := range o.Tolerations {
+		tol, err := toleration.Parse(t)
+		if err != nil {
+			return nil, err
+		}
+		tolerations = append(tolerations, *tol)
+	}
+	opts = append(opts,
+		NodeSelector(o.NodeSelector),
+		Tolerations(tolerations...),
+	)
+
+	if o.PriorityClassName != "" {
+		opts = append(opts, PriorityClassName(o.PriorityClassName))
+	}
+	if o.SecurityContextConfig != "" {
+		switch sc := v1alpha1.SecurityConfig(o.SecurityContextConfig); sc {
+		case v1alpha1.Legacy, v1alpha1.Restricted:
+			opts = append(opts, SecurityContextConfig(sc))
+		default:
+			return nil, fmt.Errorf("invalid security context config %q: must be one of %s|%s", o.SecurityContextConfig, v1alpha1.Legacy, v1alpha1.Restricted)
+		}
+	}
+	if o.MemoryTarget != "" {
+		q, err := resource.ParseQuantity(o.MemoryTarget)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory target %q: %v", o.MemoryTarget, err)
+		}
+		opts = append(opts, MemoryTarget(q))
+	}
+	if o.ExtractContent {
+		catalogDir, cacheDir := o.ExtractContentCatalogDir, o.ExtractContentCacheDir
+		if catalogDir == "" {
+			catalogDir = DefaultExtractContentCatalogDir
+		}
+		if cacheDir == "" {
+			cacheDir = DefaultExtractContentCacheDir
+		}
+		opts = append(opts, ExtractContent(catalogDir, cacheDir))
+	}
+	return opts, nil
+}
+
+// IsEmpty returns true if no settings were provided.
+func (o ConfigOptions) IsEmpty() bool {
+	return o.PollInterval == 0 && o.Priority == nil && len(o.NodeSelector) == 0 &&
+		len(o.Tolerations) == 0 && o.PriorityClassName == "" && o.SecurityContextConfig == "" &&
+		o.MemoryTarget == "" && !o.ExtractContent
+}
@@ -0,0 +1,150 @@
+package fakecluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1/catalogd"
+)
+
+// The catalogd simulation unpacks a ClusterCatalog as soon as it is created or updated if its
+// image is the index image of a known catalog, and serves its contents at the base URL in its
+// status.
+
+func (c *Cluster) clusterCatalog(cat *Catalog) *olmv1.ClusterCatalog {
+	cc := &olmv1.ClusterCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: cat.Name},
+		Spec: olmv1.ClusterCatalogSpec{
+			Source: olmv1.CatalogSource{
+				Type:  olmv1.SourceTypeImage,
+				Image: &olmv1.ImageSource{Ref: c.Image(cat.Name)},
+			},
+			AvailabilityMode: olmv1.AvailabilityModeAvailable,
+		},
+	}
+	c.setClusterCatalogStatus(cc)
+	return cc
+}
+
+func (c *Cluster) reconcileClusterCatalog(ctx context.Context, cl client.Client, cc *olmv1.ClusterCatalog) error {
+	c.setClusterCatalogStatus(cc)
+	return cl.Update(ctx, cc)
+}
+
+// setClusterCatalogStatus sets the status catalogd would set on a ClusterCatalog.
+func (c *Cluster) setClusterCatalogStatus(cc *olmv1.ClusterCatalog) {
+	if cc.Labels == nil {
+		cc.Labels = map[string]string{}
+	}
+	cc.Labels[olmv1.MetadataNameLabel] = cc.Name
+
+	if cc.Spec.AvailabilityMode == olmv1.AvailabilityModeUnavailable {
+		cc.Status = olmv1.ClusterCatalogStatus{}
+		c.setCondition(&cc.Status.Conditions, olmv1.TypeProgressing, metav1.ConditionTrue, olmv1.ReasonSucceeded, "")
+		c.setCondition(&cc.Status.Conditions, olmv1.TypeServing, metav1.ConditionFalse, olmv1.ReasonUserSpecifiedUnavailable, "catalog contents have been removed because the catalog is unavailable")
+		return
+	}
+
+	var cat *Catalog
+	if cc.Spec.Source.Image != nil {
+		cat = c.catalogForImage(cc.Spec.Source.Image.Ref)
+	}
+	if cat == nil {
+		c.setCondition(&cc.Status.Conditions, olmv1.TypeProgressing, metav1.ConditionTrue, olmv1.ReasonRetrying, "source catalog content resolution failed: image not found")
+		c.setCondition(&cc.Status.Conditions, olmv1.TypeServing, metav1.ConditionFalse, olmv1.ReasonUnavailable, "")
+		return
+	}
+
+	ref := cc.Spec.Source.Image.Ref
+	cc.Status.ResolvedSource = &olmv1.ResolvedCatalogSource{
+		Type:  olmv1.SourceTypeImage,
+		Image: &olmv1.ResolvedImageSource{Ref: ref[:strings.LastIndex(ref, ":")] + "@" + cat.image.manifestDigest.String()},
+	}
+	cc.Status.URLs = &olmv1.ClusterCatalogURLs{Base: c.catalogd.URL + "/catalogs/" + cc.Name}
+	cc.Status.LastUnpacked = c.created.DeepCopy()
+	c.setCondition(&cc.Status.Conditions, olmv1.TypeProgressing, metav1.ConditionTrue, olmv1.ReasonSucceeded, "Successfully unpacked and stored content from resolved source")
+	c.setCondition(&cc.Status.Conditions, olmv1.TypeServing, metav1.ConditionTrue, olmv1.ReasonAvailable, "Serving desired content from resolved source")
+}
+
+func (c *Cluster) setCondition(conditions *[]metav1.Condition, typ string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               typ,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: c.created,
+	})
+}
+
+// startCatalogd starts the server of the catalogd HTTP API, which serves the contents of the
+// ClusterCatalogs at /catalogs/<name>/api/v1/all.
+func (c *Cluster) startCatalogd() {
+	c.catalogd = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := strings.CutPrefix(r.URL.Path, "/catalogs/")
+		if !ok || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+		name, ok = strings.CutSuffix(name, "/api/v1/all")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		cc := &olmv1.ClusterCatalog{}
+		if err := c.Client.Get(r.Context(), types.NamespacedName{Name: name}, cc); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if !meta.IsStatusConditionTrue(cc.Status.Conditions, olmv1.TypeServing) {
+			http.NotFound(w, r)
+			return
+		}
+		cat := c.catalogForImage(cc.Spec.Source.Image.Ref)
+		if cat == nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/jsonl")
+		if err := declcfg.WriteJSON(*cat.fbc, w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+}
+
+// CatalogdClient returns a client that fetches the contents of ClusterCatalogs from the catalogd
+// server of the cluster, at the base URL in their status, instead of port forwarding to it.
+func (c *Cluster) CatalogdClient() catalogd.Client {
+	return catalogdClient{}
+}
+
+type catalogdClient struct{}
+
+func (c catalogdClient) V1() catalogd.V1Client {
+	return c
+}
+
+func (catalogdClient) All(ctx context.Context, cc *olmv1.ClusterCatalog) (io.ReadCloser, error) {
+	if cc.Status.URLs == nil {
+		return nil, fmt.Errorf("cluster catalog %q has no base URL", cc.Name)
+	}
+	baseURL, err := url.Parse(cc.Status.URLs.Base)
+	if err != nil {
+		return nil, err
+	}
+	live := &catalogd.LiveClient{HTTPClient: http.DefaultClient, BaseURL: baseURL}
+	return live.V1().All(ctx, cc)
+}
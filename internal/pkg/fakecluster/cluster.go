@@ -0,0 +1,332 @@
+// Package fakecluster runs kubectl operator against an in-process cluster. The cluster is built
+// on the controller-runtime fake client and simulates the status transitions OLM,
+// operator-controller and catalogd make, so that commands waiting on them complete. The contents
+// of its catalogs are served through a catalogd HTTP API and as index images from an OCI
+// registry.
+package fakecluster
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/model"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// GlobalNamespace is the namespace of the CatalogSources whose packages are available in every
+// namespace.
+const GlobalNamespace = "olm"
+
+// Catalog is a file-based catalog served by the cluster.
+type Catalog struct {
+	// Name is the name of the catalog's index image in the registry, and of the CatalogSource and
+	// ClusterCatalog the cluster starts with.
+	Name        string
+	DisplayName string
+	Publisher   string
+	// FS holds the file-based catalog.
+	FS fs.FS
+
+	fbc   *declcfg.DeclarativeConfig
+	model model.Model
+	image *indexImage
+}
+
+// Cluster is an in-process cluster. Every catalog gets a CatalogSource in GlobalNamespace and a
+// ClusterCatalog when the cluster is created.
+type Cluster struct {
+	// Client reads and writes the cluster's objects, including the simulated status changes.
+	Client client.Client
+	Scheme *runtime.Scheme
+
+	catalogs []*Catalog
+	catalogd *httptest.Server
+	registry *httptest.Server
+	// created is the creation time of every object, an hour before the cluster was created so
+	// that the ages printed by commands are stable.
+	created metav1.Time
+
+	// mu serializes the simulated controllers.
+	mu   sync.Mutex
+	uids int
+	ips  int
+}
+
+// New returns a cluster serving catalogs that holds objs in addition to the objects of the
+// catalogs. It must be closed once it is no longer used.
+func New(catalogs []Catalog, objs ...client.Object) (*Cluster, error) {
+	c := &Cluster{
+		created: metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second)),
+	}
+	for i := range catalogs {
+		cat := catalogs[i]
+		fbc, err := declcfg.LoadFS(context.Background(), cat.FS)
+		if err != nil {
+			return nil, fmt.Errorf("load catalog %q: %v", cat.Name, err)
+		}
+		if cat.model, err = declcfg.ConvertToModel(*fbc); err != nil {
+			return nil, fmt.Errorf("load catalog %q: %v", cat.Name, err)
+		}
+		cat.fbc = fbc
+		c.catalogs = append(c.catalogs, &cat)
+	}
+
+	sch, err := c.newScheme()
+	if err != nil {
+		return nil, err
+	}
+	c.Scheme = sch
+
+	if err := c.startRegistry(); err != nil {
+		return nil, err
+	}
+	c.startCatalogd()
+
+	var seeds []client.Object
+	for _, cat := range c.catalogs {
+		seeds = append(seeds, c.catalogSource(cat), c.clusterCatalog(cat))
+	}
+	seeds = append(seeds, objs...)
+	for _, obj := range seeds {
+		c.stamp(obj)
+	}
+
+	c.Client = fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(seeds...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get:    c.get,
+			List:   c.list,
+			Create: c.create,
+			Update: c.update,
+			Delete: c.delete,
+		}).
+		Build()
+	return c, nil
+}
+
+// Close stops the catalogd server and the registry.
+func (c *Cluster) Close() {
+	c.catalogd.Close()
+	c.registry.Close()
+}
+
+// Configure makes cfg use the cluster.
+func (c *Cluster) Configure(cfg *action.Configuration) {
+	cfg.Client = c.Client
+	cfg.Scheme = c.Scheme
+}
+
+// CatalogdURL returns the URL of the catalogd server.
+func (c *Cluster) CatalogdURL() string {
+	return c.catalogd.URL
+}
+
+// RegistryHost returns the host of the registry, for use in image references.
+func (c *Cluster) RegistryHost() string {
+	return strings.TrimPrefix(c.registry.URL, "http://")
+}
+
+// Image returns the reference of the index image of the named catalog.
+func (c *Cluster) Image(catalog string) string {
+	return fmt.Sprintf("%s/catalogs/%s:latest", c.RegistryHost(), catalog)
+}
+
+// catalogForImage returns the catalog whose index image is ref, or nil.
+func (c *Cluster) catalogForImage(ref string) *Catalog {
+	for _, cat := range c.catalogs {
+		if ref == c.Image(cat.Name) {
+			return cat
+		}
+	}
+	return nil
+}
+
+// newScheme returns the scheme of the cluster, in which the APIs owned by the catalogs' bundles
+// are registered as unstructured objects.
+func (c *Cluster) newScheme() (*runtime.Scheme, error) {
+	sch, err := action.NewScheme()
+	if err != nil {
+		return nil, err
+	}
+	if err := clientgoscheme.AddToScheme(sch); err != nil {
+		return nil, err
+	}
+	for _, cat := range c.catalogs {
+		for _, pkg := range cat.model {
+			for _, ch := range pkg.Channels {
+				for _, b := range ch.Bundles {
+					for _, crd := range ownedCRDs(b) {
+						gv := schema.GroupVersion{Group: crdGroup(crd.Name), Version: crd.Version}
+						sch.AddKnownTypeWithName(gv.WithKind(crd.Kind), &unstructured.Unstructured{})
+						sch.AddKnownTypeWithName(gv.WithKind(crd.Kind+"List"), &unstructured.UnstructuredList{})
+					}
+				}
+			}
+		}
+	}
+	return sch, nil
+}
+
+// stamp sets the UID and creation time the API server would set on a new object.
+func (c *Cluster) stamp(obj client.Object) {
+	if obj.GetUID() == "" {
+		c.uids++
+		obj.SetUID(types.UID(fmt.Sprintf("00000000-0000-0000-0000-%012d", c.uids)))
+	}
+	if obj.GetCreationTimestamp().Time.IsZero() {
+		obj.SetCreationTimestamp(c.created)
+	}
+}
+
+// clusterScoped holds the cluster-scoped kinds the commands use. The fake client stores objects
+// by the namespace they are given, so the namespace of requests for these kinds is dropped like
+// the API server does.
+var clusterScoped = map[schema.GroupKind]bool{
+	{Group: "", Kind: "Namespace"}:                                    true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: true,
+	{Group: "operators.coreos.com", Kind: "Operator"}:                 true,
+	{Group: "olm.operatorframework.io", Kind: "ClusterCatalog"}:       true,
+	{Group: "olm.operatorframework.io", Kind: "ClusterExtension"}:     true,
+}
+
+func (c *Cluster) isClusterScoped(obj runtime.Object) bool {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme)
+	if err != nil {
+		return false
+	}
+	return clusterScoped[schema.GroupKind{Group: gvk.Group, Kind: strings.TrimSuffix(gvk.Kind, "List")}]
+}
+
+func (c *Cluster) get(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if pm, ok := obj.(*operatorsv1.PackageManifest); ok {
+		return c.getPackageManifest(ctx, cl, key, pm)
+	}
+	if c.isClusterScoped(obj) {
+		key.Namespace = ""
+	}
+	return cl.Get(ctx, key, obj, opts...)
+}
+
+func (c *Cluster) list(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+	if pms, ok := list.(*operatorsv1.PackageManifestList); ok {
+		return c.listPackageManifests(ctx, cl, pms, opts...)
+	}
+	if c.isClusterScoped(list) {
+		listOpts := &client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		listOpts.Namespace = ""
+		opts = []client.ListOption{listOpts}
+	}
+	return cl.List(ctx, list, opts...)
+}
+
+func (c *Cluster) create(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	createOpts := &client.CreateOptions{}
+	createOpts.ApplyOptions(opts)
+	if len(createOpts.DryRun) == 0 {
+		c.stamp(obj)
+	}
+	if err := cl.Create(ctx, obj, opts...); err != nil || len(createOpts.DryRun) > 0 {
+		return err
+	}
+	return c.reconcile(ctx, cl, obj)
+}
+
+func (c *Cluster) update(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	updateOpts := &client.UpdateOptions{}
+	updateOpts.ApplyOptions(opts)
+	if err := cl.Update(ctx, obj, opts...); err != nil || len(updateOpts.DryRun) > 0 {
+		return err
+	}
+	return c.reconcile(ctx, cl, obj)
+}
+
+func (c *Cluster) delete(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := cl.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	gvk, err := cl.GroupVersionKindFor(obj)
+	if err != nil {
+		return err
+	}
+	return c.removeOperatorComponent(ctx, cl, gvk, obj)
+}
+
+// reconcile runs the simulated controller of obj's kind, and refreshes obj with the status it
+// set.
+func (c *Cluster) reconcile(ctx context.Context, cl client.WithWatch, obj client.Object) error {
+	var err error
+	switch o := obj.(type) {
+	case *v1alpha1.CatalogSource:
+		err = c.reconcileCatalogSource(ctx, cl, o)
+	case *v1alpha1.Subscription:
+		err = c.reconcileSubscription(ctx, cl, o)
+	case *v1alpha1.InstallPlan:
+		err = c.reconcileInstallPlan(ctx, cl, o)
+	case *olmv1.ClusterCatalog:
+		err = c.reconcileClusterCatalog(ctx, cl, o)
+	case *olmv1.ClusterExtension:
+		err = c.reconcileClusterExtension(ctx, cl, o)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("simulate %T %q: %v", obj, obj.GetName(), err)
+	}
+	return cl.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+}
+
+// ownedCRDs returns the CRDs owned by a bundle according to its CSV metadata.
+func ownedCRDs(b *model.Bundle) []v1alpha1.CRDDescription {
+	if meta := csvMetadata(b); meta != nil {
+		return meta.CustomResourceDefinitions.Owned
+	}
+	return nil
+}
+
+// csvMetadata returns the CSV metadata property of a bundle, or nil.
+func csvMetadata(b *model.Bundle) *property.CSVMetadata {
+	props, err := property.Parse(b.Properties)
+	if err != nil || len(props.CSVMetadatas) == 0 {
+		return nil
+	}
+	return &props.CSVMetadatas[0]
+}
+
+// crdGroup returns the API group of the CRD with the given name.
+func crdGroup(name string) string {
+	_, group, _ := strings.Cut(name, ".")
+	return group
+}
@@ -0,0 +1,513 @@
+package fakecluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/alpha/model"
+)
+
+// The OLM simulation resolves a Subscription to an InstallPlan as soon as it is created. The
+// install plan completes at once if it is approved, and installs the CSV of its bundle along
+// with the CRDs the bundle owns. Once a bundle is installed, the bundle that replaces it in the
+// subscription's channel is resolved next, like OLM does when an update is available.
+
+func (c *Cluster) catalogSource(cat *Catalog) *v1alpha1.CatalogSource {
+	cs := &v1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cat.Name,
+			Namespace: GlobalNamespace,
+		},
+		Spec: v1alpha1.CatalogSourceSpec{
+			SourceType:  v1alpha1.SourceTypeGrpc,
+			Image:       c.Image(cat.Name),
+			DisplayName: cat.DisplayName,
+			Publisher:   cat.Publisher,
+		},
+	}
+	c.setCatalogSourceStatus(cs)
+	return cs
+}
+
+func (c *Cluster) reconcileCatalogSource(ctx context.Context, cl client.Client, cs *v1alpha1.CatalogSource) error {
+	c.setCatalogSourceStatus(cs)
+	return cl.Update(ctx, cs)
+}
+
+// setCatalogSourceStatus marks a CatalogSource ready if it serves a known catalog.
+func (c *Cluster) setCatalogSourceStatus(cs *v1alpha1.CatalogSource) {
+	state := "TRANSIENT_FAILURE"
+	if c.catalogForImage(cs.Spec.Image) != nil {
+		state = "READY"
+	}
+	cs.Status.GRPCConnectionState = &v1alpha1.GRPCConnectionState{
+		Address:           fmt.Sprintf("%s.%s.svc:50051", cs.Name, cs.Namespace),
+		LastObservedState: state,
+		LastConnectTime:   c.created,
+	}
+	cs.Status.RegistryServiceStatus = &v1alpha1.RegistryServiceStatus{
+		Protocol:         "grpc",
+		ServiceName:      cs.Name,
+		ServiceNamespace: cs.Namespace,
+		Port:             "50051",
+		CreatedAt:        c.created,
+	}
+}
+
+func (c *Cluster) reconcileSubscription(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription) error {
+	if sub.Status.InstallPlanRef != nil {
+		return nil
+	}
+	if err := c.setCatalogHealth(ctx, cl, sub); err != nil {
+		return err
+	}
+	ch, err := c.subscriptionChannel(ctx, cl, sub)
+	if err != nil {
+		sub.Status.SetCondition(v1alpha1.SubscriptionCondition{
+			Type:    v1alpha1.SubscriptionResolutionFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "ConstraintsNotSatisfiable",
+			Message: err.Error(),
+		})
+		sub.Status.LastUpdated = c.created
+		return cl.Update(ctx, sub)
+	}
+
+	var bundle *model.Bundle
+	if sub.Spec.StartingCSV != "" {
+		bundle = ch.Bundles[sub.Spec.StartingCSV]
+	} else {
+		bundle, err = ch.Head()
+	}
+	if bundle == nil || err != nil {
+		sub.Status.SetCondition(v1alpha1.SubscriptionCondition{
+			Type:    v1alpha1.SubscriptionResolutionFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "ConstraintsNotSatisfiable",
+			Message: fmt.Sprintf("no operators found in channel %s of package %s", ch.Name, ch.Package.Name),
+		})
+		sub.Status.LastUpdated = c.created
+		return cl.Update(ctx, sub)
+	}
+	return c.resolve(ctx, cl, sub, bundle)
+}
+
+// setCatalogHealth records the health of the CatalogSources a Subscription can resolve from.
+func (c *Cluster) setCatalogHealth(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription) error {
+	css := v1alpha1.CatalogSourceList{}
+	if err := cl.List(ctx, &css); err != nil {
+		return err
+	}
+	sub.Status.CatalogHealth = nil
+	for _, cs := range css.Items {
+		if cs.Namespace != sub.Namespace && cs.Namespace != GlobalNamespace {
+			continue
+		}
+		sub.Status.CatalogHealth = append(sub.Status.CatalogHealth, v1alpha1.SubscriptionCatalogHealth{
+			CatalogSourceRef: &corev1.ObjectReference{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       v1alpha1.CatalogSourceKind,
+				Namespace:  cs.Namespace,
+				Name:       cs.Name,
+				UID:        cs.UID,
+			},
+			LastUpdated: c.created.DeepCopy(),
+			Healthy:     c.catalogForImage(cs.Spec.Image) != nil,
+		})
+	}
+	return nil
+}
+
+// subscriptionChannel returns the channel a Subscription follows.
+func (c *Cluster) subscriptionChannel(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription) (*model.Channel, error) {
+	cs := &v1alpha1.CatalogSource{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: sub.Spec.CatalogSourceNamespace, Name: sub.Spec.CatalogSource}, cs); err != nil {
+		return nil, fmt.Errorf("catalogsource %s/%s: %v", sub.Spec.CatalogSourceNamespace, sub.Spec.CatalogSource, err)
+	}
+	cat := c.catalogForImage(cs.Spec.Image)
+	if cat == nil {
+		return nil, fmt.Errorf("catalogsource %s/%s is not serving", cs.Namespace, cs.Name)
+	}
+	pkg, ok := cat.model[sub.Spec.Package]
+	if !ok {
+		return nil, fmt.Errorf("no operators found in package %s in the catalog referenced by subscription %s", sub.Spec.Package, sub.Name)
+	}
+	name := sub.Spec.Channel
+	if name == "" && pkg.DefaultChannel != nil {
+		name = pkg.DefaultChannel.Name
+	}
+	ch, ok := pkg.Channels[name]
+	if !ok {
+		return nil, fmt.Errorf("no operators found in channel %s of package %s in the catalog referenced by subscription %s", name, pkg.Name, sub.Name)
+	}
+	return ch, nil
+}
+
+// resolve creates the InstallPlan that installs bundle for a Subscription, and completes it if
+// it does not need to be approved. Like OLM, it requires approval if any Subscription in the
+// namespace uses manual approval.
+func (c *Cluster) resolve(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription, bundle *model.Bundle) error {
+	approval, err := c.installPlanApproval(ctx, cl, sub)
+	if err != nil {
+		return err
+	}
+	c.ips++
+	ip := &v1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("install-%d", c.ips),
+			Namespace: sub.Namespace,
+			Labels:    map[string]string{operatorLabel(sub): ""},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       v1alpha1.SubscriptionKind,
+				Name:       sub.Name,
+				UID:        sub.UID,
+			}},
+		},
+		Spec: v1alpha1.InstallPlanSpec{
+			CatalogSource:              sub.Spec.CatalogSource,
+			CatalogSourceNamespace:     sub.Spec.CatalogSourceNamespace,
+			ClusterServiceVersionNames: []string{bundle.Name},
+			Approval:                   approval,
+			Approved:                   approval == v1alpha1.ApprovalAutomatic,
+			Generation:                 c.ips,
+		},
+		Status: v1alpha1.InstallPlanStatus{
+			Phase:          v1alpha1.InstallPlanPhaseRequiresApproval,
+			CatalogSources: []string{sub.Spec.CatalogSource},
+			Plan:           planSteps(sub, bundle),
+			BundleLookups: []v1alpha1.BundleLookup{{
+				Path:       bundle.Image,
+				Identifier: bundle.Name,
+				Replaces:   bundle.Replaces,
+				CatalogSourceRef: &corev1.ObjectReference{
+					Namespace: sub.Spec.CatalogSourceNamespace,
+					Name:      sub.Spec.CatalogSource,
+				},
+			}},
+		},
+	}
+	c.stamp(ip)
+	if err := cl.Create(ctx, ip); err != nil {
+		return err
+	}
+
+	sub.Status.CurrentCSV = bundle.Name
+	sub.Status.InstallPlanRef = &corev1.ObjectReference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       v1alpha1.InstallPlanKind,
+		Namespace:  ip.Namespace,
+		Name:       ip.Name,
+		UID:        ip.UID,
+	}
+	sub.Status.InstallPlanGeneration = ip.Spec.Generation
+	sub.Status.Install = &v1alpha1.InstallPlanReference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       v1alpha1.InstallPlanKind,
+		Name:       ip.Name,
+		UID:        ip.UID,
+	}
+	sub.Status.State = v1alpha1.SubscriptionStateUpgradePending
+	sub.Status.LastUpdated = c.created
+	if err := cl.Update(ctx, sub); err != nil {
+		return err
+	}
+
+	if ip.Spec.Approved {
+		return c.completeInstallPlan(ctx, cl, ip)
+	}
+	return nil
+}
+
+// installPlanApproval returns the approval of the InstallPlans created for sub, which is manual
+// if any Subscription in its namespace uses manual approval.
+func (c *Cluster) installPlanApproval(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription) (v1alpha1.Approval, error) {
+	subs := &v1alpha1.SubscriptionList{}
+	if err := cl.List(ctx, subs, client.InNamespace(sub.Namespace)); err != nil {
+		return "", err
+	}
+	for _, s := range subs.Items {
+		if s.Spec != nil && s.Spec.InstallPlanApproval == v1alpha1.ApprovalManual {
+			return v1alpha1.ApprovalManual, nil
+		}
+	}
+	return v1alpha1.ApprovalAutomatic, nil
+}
+
+func planSteps(sub *v1alpha1.Subscription, bundle *model.Bundle) []*v1alpha1.Step {
+	step := func(group, version, kind, name string) *v1alpha1.Step {
+		return &v1alpha1.Step{
+			Resolving: bundle.Name,
+			Resource: v1alpha1.StepResource{
+				CatalogSource:          sub.Spec.CatalogSource,
+				CatalogSourceNamespace: sub.Spec.CatalogSourceNamespace,
+				Group:                  group,
+				Version:                version,
+				Kind:                   kind,
+				Name:                   name,
+			},
+			Status: v1alpha1.StepStatusUnknown,
+		}
+	}
+	steps := []*v1alpha1.Step{step(v1alpha1.GroupName, v1alpha1.GroupVersion, v1alpha1.ClusterServiceVersionKind, bundle.Name)}
+	for _, crd := range ownedCRDs(bundle) {
+		steps = append(steps, step(apiextensionsv1.GroupName, "v1", "CustomResourceDefinition", crd.Name))
+	}
+	return steps
+}
+
+func (c *Cluster) reconcileInstallPlan(ctx context.Context, cl client.Client, ip *v1alpha1.InstallPlan) error {
+	if !ip.Spec.Approved || ip.Status.Phase != v1alpha1.InstallPlanPhaseRequiresApproval {
+		return nil
+	}
+	return c.completeInstallPlan(ctx, cl, ip)
+}
+
+// completeInstallPlan installs the bundles of an InstallPlan, updates the Subscriptions that
+// own it and resolves their next update.
+func (c *Cluster) completeInstallPlan(ctx context.Context, cl client.Client, ip *v1alpha1.InstallPlan) error {
+	ip.Status.Phase = v1alpha1.InstallPlanPhaseInstalling
+	for _, step := range ip.Status.Plan {
+		step.Status = v1alpha1.StepStatusCreated
+	}
+	ip.Status.Phase = v1alpha1.InstallPlanPhaseComplete
+	ip.Status.BundleLookups = nil
+	if err := cl.Update(ctx, ip); err != nil {
+		return err
+	}
+
+	for _, ref := range ip.GetOwnerReferences() {
+		if ref.Kind != v1alpha1.SubscriptionKind {
+			continue
+		}
+		sub := &v1alpha1.Subscription{}
+		if err := cl.Get(ctx, types.NamespacedName{Namespace: ip.Namespace, Name: ref.Name}, sub); err != nil {
+			return err
+		}
+		ch, err := c.subscriptionChannel(ctx, cl, sub)
+		if err != nil {
+			return err
+		}
+		for _, name := range ip.Spec.ClusterServiceVersionNames {
+			bundle, ok := ch.Bundles[name]
+			if !ok {
+				return fmt.Errorf("bundle %q not found in channel %s", name, ch.Name)
+			}
+			if err := c.installBundle(ctx, cl, sub, bundle); err != nil {
+				return err
+			}
+			sub.Status.InstalledCSV = bundle.Name
+			sub.Status.CurrentCSV = bundle.Name
+		}
+		sub.Status.State = v1alpha1.SubscriptionStateAtLatest
+		sub.Status.LastUpdated = c.created
+		if err := cl.Update(ctx, sub); err != nil {
+			return err
+		}
+		if next := replacement(ch, sub.Status.InstalledCSV); next != nil {
+			if err := c.resolve(ctx, cl, sub, next); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replacement returns the bundle that replaces the named bundle in a channel, or nil.
+func replacement(ch *model.Channel, name string) *model.Bundle {
+	for _, b := range ch.Bundles {
+		if b.Replaces == name {
+			return b
+		}
+		for _, skip := range b.Skips {
+			if skip == name {
+				return b
+			}
+		}
+	}
+	return nil
+}
+
+// installBundle creates the CSV of bundle and the CRDs it owns, removes the CSV it replaces,
+// and records them as components of the operator.
+func (c *Cluster) installBundle(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription, bundle *model.Bundle) error {
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bundle.Name,
+			Namespace: sub.Namespace,
+			Labels:    map[string]string{operatorLabel(sub): ""},
+		},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			Replaces: sub.Status.InstalledCSV,
+		},
+		Status: v1alpha1.ClusterServiceVersionStatus{
+			Phase:   v1alpha1.CSVPhaseSucceeded,
+			Reason:  v1alpha1.CSVReasonInstallSuccessful,
+			Message: "install strategy completed with no errors",
+		},
+	}
+	csv.Spec.Version.Version = bundle.Version
+	if meta := csvMetadata(bundle); meta != nil {
+		csv.Spec.DisplayName = meta.DisplayName
+		csv.Spec.Description = meta.Description
+		csv.Spec.Provider = meta.Provider
+		csv.Spec.InstallModes = meta.InstallModes
+		csv.Spec.CustomResourceDefinitions = meta.CustomResourceDefinitions
+		csv.Annotations = meta.Annotations
+	}
+	c.stamp(csv)
+	if err := cl.Create(ctx, csv); err != nil {
+		return err
+	}
+	components := []client.Object{sub, csv}
+
+	for _, desc := range ownedCRDs(bundle) {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: desc.Name}, crd); apierrors.IsNotFound(err) {
+			crd = newCRD(desc)
+			c.stamp(crd)
+			if err := cl.Create(ctx, crd); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		components = append(components, crd)
+	}
+
+	if replaced := sub.Status.InstalledCSV; replaced != "" {
+		old := &v1alpha1.ClusterServiceVersion{}
+		old.SetName(replaced)
+		old.SetNamespace(sub.Namespace)
+		if err := cl.Delete(ctx, old); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err := c.removeOperatorComponent(ctx, cl, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.ClusterServiceVersionKind), old); err != nil {
+			return err
+		}
+	}
+	return c.addOperatorComponents(ctx, cl, sub, components...)
+}
+
+func newCRD(desc v1alpha1.CRDDescription) *apiextensionsv1.CustomResourceDefinition {
+	plural, group, _ := strings.Cut(desc.Name, ".")
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: desc.Name},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   plural,
+				Kind:     desc.Kind,
+				ListKind: desc.Kind + "List",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    desc.Version,
+				Served:  true,
+				Storage: true,
+			}},
+		},
+	}
+}
+
+// operatorLabel returns the label OLM puts on the objects of the operator a Subscription installs.
+func operatorLabel(sub *v1alpha1.Subscription) string {
+	return fmt.Sprintf("operators.coreos.com/%s.%s", sub.Spec.Package, sub.Namespace)
+}
+
+// addOperatorComponents adds objs to the components of the Operator of a Subscription, creating
+// it if needed.
+func (c *Cluster) addOperatorComponents(ctx context.Context, cl client.Client, sub *v1alpha1.Subscription, objs ...client.Object) error {
+	op := &v1.Operator{}
+	name := fmt.Sprintf("%s.%s", sub.Spec.Package, sub.Namespace)
+	err := cl.Get(ctx, types.NamespacedName{Name: name}, op)
+	if apierrors.IsNotFound(err) {
+		op.SetName(name)
+		c.stamp(op)
+		if err := cl.Create(ctx, op); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if op.Status.Components == nil {
+		op.Status.Components = &v1.Components{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{operatorLabel(sub): ""}},
+		}
+	}
+	for _, obj := range objs {
+		gvk, err := cl.GroupVersionKindFor(obj)
+		if err != nil {
+			return err
+		}
+		if hasComponent(op, gvk, obj) {
+			continue
+		}
+		apiVersion, kind := gvk.ToAPIVersionAndKind()
+		op.Status.Components.Refs = append(op.Status.Components.Refs, v1.RichReference{
+			ObjectReference: &corev1.ObjectReference{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Namespace:  obj.GetNamespace(),
+				Name:       obj.GetName(),
+			},
+		})
+	}
+	return cl.Update(ctx, op)
+}
+
+// removeOperatorComponent removes a deleted object from the components of every Operator.
+func (c *Cluster) removeOperatorComponent(ctx context.Context, cl client.Client, gvk schema.GroupVersionKind, obj client.Object) error {
+	ops := v1.OperatorList{}
+	if err := cl.List(ctx, &ops); err != nil {
+		return err
+	}
+	for i := range ops.Items {
+		op := &ops.Items[i]
+		if !hasComponent(op, gvk, obj) {
+			continue
+		}
+		refs := op.Status.Components.Refs[:0]
+		for _, ref := range op.Status.Components.Refs {
+			if !isReferenceTo(ref, gvk, obj) {
+				refs = append(refs, ref)
+			}
+		}
+		op.Status.Components.Refs = refs
+		if err := cl.Update(ctx, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasComponent(op *v1.Operator, gvk schema.GroupVersionKind, obj client.Object) bool {
+	if op.Status.Components == nil {
+		return false
+	}
+	for _, ref := range op.Status.Components.Refs {
+		if isReferenceTo(ref, gvk, obj) {
+			return true
+		}
+	}
+	return false
+}
+
+func isReferenceTo(ref v1.RichReference, gvk schema.GroupVersionKind, obj client.Object) bool {
+	return ref.ObjectReference != nil &&
+		ref.GroupVersionKind() == gvk &&
+		ref.Namespace == obj.GetNamespace() &&
+		ref.Name == obj.GetName()
+}
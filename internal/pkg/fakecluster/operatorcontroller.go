@@ -0,0 +1,93 @@
+package fakecluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-registry/alpha/model"
+)
+
+// The operator-controller simulation installs the highest version of a ClusterExtension's
+// package that the serving ClusterCatalogs it selects provide in its channels and version range,
+// as soon as the extension is created or updated.
+
+func (c *Cluster) reconcileClusterExtension(ctx context.Context, cl client.Client, ext *olmv1.ClusterExtension) error {
+	bundle, err := c.resolveExtension(ctx, cl, ext)
+	if err != nil {
+		return err
+	}
+	if bundle == nil {
+		c.setCondition(&ext.Status.Conditions, olmv1.TypeProgressing, metav1.ConditionTrue, olmv1.ReasonRetrying,
+			fmt.Sprintf("no bundles found for package %q", ext.Spec.Source.Catalog.PackageName))
+		return cl.Update(ctx, ext)
+	}
+
+	ext.Status.Install = &olmv1.ClusterExtensionInstallStatus{
+		Bundle: olmv1.BundleMetadata{Name: bundle.Name, Version: bundle.Version.String()},
+	}
+	c.setCondition(&ext.Status.Conditions, olmv1.TypeInstalled, metav1.ConditionTrue, olmv1.ReasonSucceeded,
+		fmt.Sprintf("Installed bundle %s successfully", bundle.Image))
+	c.setCondition(&ext.Status.Conditions, olmv1.TypeProgressing, metav1.ConditionTrue, olmv1.ReasonSucceeded,
+		"Desired state reached")
+	return cl.Update(ctx, ext)
+}
+
+// resolveExtension returns the bundle a ClusterExtension installs, or nil if there is none.
+func (c *Cluster) resolveExtension(ctx context.Context, cl client.Client, ext *olmv1.ClusterExtension) (*model.Bundle, error) {
+	filter := ext.Spec.Source.Catalog
+	if filter == nil {
+		return nil, nil
+	}
+	selector := labels.Everything()
+	if filter.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(filter.Selector); err != nil {
+			return nil, err
+		}
+	}
+	inRange := func(semver.Version) bool { return true }
+	if filter.Version != "" {
+		var err error
+		if inRange, err = semver.ParseRange(filter.Version); err != nil {
+			return nil, nil
+		}
+	}
+
+	ccs := olmv1.ClusterCatalogList{}
+	if err := cl.List(ctx, &ccs, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	var best *model.Bundle
+	for _, cc := range ccs.Items {
+		if !meta.IsStatusConditionTrue(cc.Status.Conditions, olmv1.TypeServing) || cc.Spec.Source.Image == nil {
+			continue
+		}
+		cat := c.catalogForImage(cc.Spec.Source.Image.Ref)
+		if cat == nil {
+			continue
+		}
+		pkg, ok := cat.model[filter.PackageName]
+		if !ok {
+			continue
+		}
+		for _, ch := range pkg.Channels {
+			if len(filter.Channels) > 0 && !slices.Contains(filter.Channels, ch.Name) {
+				continue
+			}
+			for _, b := range ch.Bundles {
+				if inRange(b.Version) && (best == nil || b.Version.GT(best.Version)) {
+					best = b
+				}
+			}
+		}
+	}
+	return best, nil
+}
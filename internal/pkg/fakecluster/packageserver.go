@@ -0,0 +1,174 @@
+package fakecluster
+
+import (
+	"context"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/operator-framework/operator-registry/alpha/model"
+)
+
+// The package manifests are served like packageserver does: they are computed from the
+// CatalogSources that serve a known catalog, which are visible in their own namespace and, for
+// those in GlobalNamespace, in every namespace.
+
+func (c *Cluster) getPackageManifest(ctx context.Context, cl client.Reader, key client.ObjectKey, pm *operatorsv1.PackageManifest) error {
+	pms, err := c.packageManifests(ctx, cl, key.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, p := range pms {
+		if p.Name == key.Name {
+			p.DeepCopyInto(pm)
+			return nil
+		}
+	}
+	return apierrors.NewNotFound(operatorsv1.Resource("packagemanifests"), key.Name)
+}
+
+func (c *Cluster) listPackageManifests(ctx context.Context, cl client.Reader, list *operatorsv1.PackageManifestList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	pms, err := c.packageManifests(ctx, cl, listOpts.Namespace)
+	if err != nil {
+		return err
+	}
+	list.Items = nil
+	for _, pm := range pms {
+		if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Matches(labels.Set(pm.Labels)) {
+			continue
+		}
+		list.Items = append(list.Items, pm)
+	}
+	return nil
+}
+
+// packageManifests returns the package manifests visible in namespace, or in every namespace if
+// namespace is empty.
+func (c *Cluster) packageManifests(ctx context.Context, cl client.Reader, namespace string) ([]operatorsv1.PackageManifest, error) {
+	css := v1alpha1.CatalogSourceList{}
+	if err := cl.List(ctx, &css); err != nil {
+		return nil, err
+	}
+	sort.Slice(css.Items, func(i, j int) bool {
+		return css.Items[i].Namespace+"/"+css.Items[i].Name < css.Items[j].Namespace+"/"+css.Items[j].Name
+	})
+
+	var pms []operatorsv1.PackageManifest
+	for _, cs := range css.Items {
+		if namespace != "" && cs.Namespace != namespace && cs.Namespace != GlobalNamespace {
+			continue
+		}
+		cat := c.catalogForImage(cs.Spec.Image)
+		if cat == nil {
+			continue
+		}
+		pmNamespace := namespace
+		if pmNamespace == "" {
+			pmNamespace = cs.Namespace
+		}
+		for _, name := range sortedPackages(cat.model) {
+			pms = append(pms, c.packageManifest(cs, cat.model[name], pmNamespace))
+		}
+	}
+	return pms, nil
+}
+
+func (c *Cluster) packageManifest(cs v1alpha1.CatalogSource, pkg *model.Package, namespace string) operatorsv1.PackageManifest {
+	pm := operatorsv1.PackageManifest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              pkg.Name,
+			Namespace:         namespace,
+			CreationTimestamp: c.created,
+			Labels: map[string]string{
+				"catalog":           cs.Name,
+				"catalog-namespace": cs.Namespace,
+			},
+		},
+		Status: operatorsv1.PackageManifestStatus{
+			CatalogSource:            cs.Name,
+			CatalogSourceDisplayName: cs.Spec.DisplayName,
+			CatalogSourcePublisher:   cs.Spec.Publisher,
+			CatalogSourceNamespace:   cs.Namespace,
+			PackageName:              pkg.Name,
+		},
+	}
+	if pkg.DefaultChannel != nil {
+		pm.Status.DefaultChannel = pkg.DefaultChannel.Name
+	}
+
+	channels := make([]string, 0, len(pkg.Channels))
+	for name := range pkg.Channels {
+		channels = append(channels, name)
+	}
+	sort.Strings(channels)
+	for _, name := range channels {
+		ch := pkg.Channels[name]
+		head, err := ch.Head()
+		if err != nil {
+			continue
+		}
+		pc := operatorsv1.PackageChannel{
+			Name:           ch.Name,
+			CurrentCSV:     head.Name,
+			CurrentCSVDesc: csvDescription(head),
+		}
+		for _, b := range bundlesByVersion(ch) {
+			pc.Entries = append(pc.Entries, operatorsv1.ChannelEntry{Name: b.Name, Version: b.Version.String()})
+		}
+		pm.Status.Channels = append(pm.Status.Channels, pc)
+		if ch.Name == pm.Status.DefaultChannel {
+			pm.Status.Provider = pc.CurrentCSVDesc.Provider
+			pm.Labels["provider"] = pc.CurrentCSVDesc.Provider.Name
+		}
+	}
+	return pm
+}
+
+func csvDescription(b *model.Bundle) operatorsv1.CSVDescription {
+	desc := operatorsv1.CSVDescription{
+		Version: version.OperatorVersion{Version: b.Version},
+	}
+	meta := csvMetadata(b)
+	if meta == nil {
+		return desc
+	}
+	desc.DisplayName = meta.DisplayName
+	desc.Provider = operatorsv1.AppLink{Name: meta.Provider.Name, URL: meta.Provider.URL}
+	desc.Annotations = meta.Annotations
+	desc.Keywords = meta.Keywords
+	desc.Maturity = meta.Maturity
+	desc.LongDescription = meta.Description
+	desc.InstallModes = meta.InstallModes
+	desc.CustomResourceDefinitions = meta.CustomResourceDefinitions
+	desc.MinKubeVersion = meta.MinKubeVersion
+	return desc
+}
+
+// bundlesByVersion returns the bundles of a channel from the newest to the oldest.
+func bundlesByVersion(ch *model.Channel) []*model.Bundle {
+	bundles := make([]*model.Bundle, 0, len(ch.Bundles))
+	for _, b := range ch.Bundles {
+		bundles = append(bundles, b)
+	}
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].Version.GT(bundles[j].Version)
+	})
+	return bundles
+}
+
+func sortedPackages(m model.Model) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
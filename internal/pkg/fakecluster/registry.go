@@ -0,0 +1,165 @@
+package fakecluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/containertools"
+)
+
+const (
+	alphaDisplayNameLabel = "alpha.operators.operatorframework.io.index.display-name.v1"
+	alphaPublisherLabel   = "alpha.operators.operatorframework.io.index.publisher.v1"
+)
+
+// indexImage is the file-based catalog index image of a catalog.
+type indexImage struct {
+	manifest       []byte
+	manifestDigest digest.Digest
+	blobs          map[digest.Digest][]byte
+}
+
+// newIndexImage builds the index image of cat, which holds its file-based catalog in
+// /configs/catalog.json.
+func newIndexImage(cat *Catalog) (*indexImage, error) {
+	var fbc bytes.Buffer
+	if err := declcfg.WriteJSON(*cat.fbc, &fbc); err != nil {
+		return nil, err
+	}
+	var layer, gzipped bytes.Buffer
+	tw := tar.NewWriter(&layer)
+	for _, hdr := range []*tar.Header{
+		{Name: "configs/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "configs/catalog.json", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(fbc.Len())},
+	} {
+		hdr.ModTime = time.Unix(0, 0)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := tw.Write(fbc.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	gw := gzip.NewWriter(&gzipped)
+	if _, err := gw.Write(layer.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+
+	config, err := json.Marshal(ocispec.Image{
+		Platform: ocispec.Platform{OS: "linux", Architecture: runtime.GOARCH},
+		Config: ocispec.ImageConfig{
+			Labels: map[string]string{
+				containertools.ConfigsLocationLabel: "/configs",
+				alphaDisplayNameLabel:               cat.DisplayName,
+				alphaPublisherLabel:                 cat.Publisher,
+			},
+		},
+		RootFS: ocispec.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{digest.FromBytes(layer.Bytes())},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	img := &indexImage{blobs: map[digest.Digest][]byte{}}
+	descriptor := func(mediaType string, data []byte) ocispec.Descriptor {
+		d := digest.FromBytes(data)
+		img.blobs[d] = data
+		return ocispec.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(data))}
+	}
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    descriptor(ocispec.MediaTypeImageConfig, config),
+		Layers:    []ocispec.Descriptor{descriptor(ocispec.MediaTypeImageLayerGzip, gzipped.Bytes())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	img.manifest = manifest
+	img.manifestDigest = digest.FromBytes(manifest)
+	return img, nil
+}
+
+// startRegistry starts a registry serving the index images of the catalogs as
+// catalogs/<name>:latest, over plain HTTP.
+func (c *Cluster) startRegistry() error {
+	for _, cat := range c.catalogs {
+		img, err := newIndexImage(cat)
+		if err != nil {
+			return fmt.Errorf("build index image of catalog %q: %v", cat.Name, err)
+		}
+		cat.image = img
+	}
+
+	c.registry = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path == "/v2/" {
+			return
+		}
+		path, ok := strings.CutPrefix(r.URL.Path, "/v2/catalogs/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		name, rest, _ := strings.Cut(path, "/")
+		var img *indexImage
+		for _, cat := range c.catalogs {
+			if cat.Name == name {
+				img = cat.image
+			}
+		}
+		if img == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		var data []byte
+		var mediaType string
+		switch kind, ref, _ := strings.Cut(rest, "/"); {
+		case kind == "manifests" && (ref == "latest" || ref == img.manifestDigest.String()):
+			data, mediaType = img.manifest, ocispec.MediaTypeImageManifest
+		case kind == "blobs":
+			data, ok = img.blobs[digest.Digest(ref)]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			mediaType = "application/octet-stream"
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(data).String())
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	}))
+	return nil
+}
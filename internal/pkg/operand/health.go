@@ -0,0 +1,94 @@
+package operand
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Health summarizes the state an operand reports in its status.
+type Health struct {
+	// Status is a short description of the operand's state, such as Ready, Degraded or the
+	// operand's phase.
+	Status string
+	// Healthy is false if the operand has not been reconciled or reports an error.
+	Healthy bool
+	// Message explains why the operand is unhealthy.
+	Message string
+}
+
+// HealthOf derives the health of an operand from its status conventions: an observedGeneration
+// behind metadata.generation, Ready/Available/Degraded conditions, and a phase. Operands
+// without a status are reported as Unknown, since many APIs do not report one.
+func HealthOf(obj unstructured.Unstructured) Health {
+	status, ok, _ := unstructured.NestedMap(obj.Object, "status")
+	if !ok || len(status) == 0 {
+		return Health{Status: "Unknown", Healthy: true}
+	}
+
+	if observed, ok, _ := unstructured.NestedInt64(status, "observedGeneration"); ok && observed < obj.GetGeneration() {
+		return Health{
+			Status:  "Reconciling",
+			Message: fmt.Sprintf("observed generation %d is behind generation %d", observed, obj.GetGeneration()),
+		}
+	}
+
+	conditions := conditionsOf(status)
+	if c, ok := conditions["Degraded"]; ok && c.status == "True" {
+		return Health{Status: "Degraded", Message: c.message}
+	}
+	if c, ok := conditions["Ready"]; ok && c.status == "False" {
+		return Health{Status: "NotReady", Message: c.message}
+	}
+	if c, ok := conditions["Available"]; ok && c.status == "False" {
+		return Health{Status: "Unavailable", Message: c.message}
+	}
+
+	phase, _, _ := unstructured.NestedString(status, "phase")
+	if isFailedPhase(phase) {
+		message, _, _ := unstructured.NestedString(status, "message")
+		return Health{Status: phase, Message: message}
+	}
+
+	for _, t := range []string{"Ready", "Available"} {
+		if c, ok := conditions[t]; ok && c.status == "True" {
+			return Health{Status: t, Healthy: true}
+		}
+	}
+	if phase != "" {
+		return Health{Status: phase, Healthy: true}
+	}
+	return Health{Status: "Unknown", Healthy: true}
+}
+
+type condition struct {
+	status  string
+	message string
+}
+
+func conditionsOf(status map[string]interface{}) map[string]condition {
+	items, _, _ := unstructured.NestedSlice(status, "conditions")
+	conditions := make(map[string]condition, len(items))
+	for _, item := range items {
+		c, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		t, _, _ := unstructured.NestedString(c, "type")
+		s, _, _ := unstructured.NestedString(c, "status")
+		m, _, _ := unstructured.NestedString(c, "message")
+		conditions[t] = condition{status: s, message: m}
+	}
+	return conditions
+}
+
+func isFailedPhase(phase string) bool {
+	phase = strings.ToLower(phase)
+	for _, s := range []string{"fail", "error", "degraded"} {
+		if strings.Contains(phase, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package operand
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("HealthOf", func() {
+	operand := func(generation int64, status map[string]interface{}) unstructured.Unstructured {
+		obj := unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "etcd.database.coreos.com/v1beta2",
+			"kind":       "EtcdCluster",
+			"metadata":   map[string]interface{}{"name": "example", "namespace": "default"},
+		}}
+		obj.SetGeneration(generation)
+		if status != nil {
+			obj.Object["status"] = status
+		}
+		return obj
+	}
+	condition := func(t, status, message string) interface{} {
+		return map[string]interface{}{"type": t, "status": status, "message": message}
+	}
+	conditions := func(c ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"conditions": c}
+	}
+
+	DescribeTable("derives the health from the operand's status",
+		func(obj unstructured.Unstructured, expected Health) {
+			Expect(HealthOf(obj)).To(Equal(expected))
+		},
+		Entry("no status", operand(1, nil), Health{Status: "Unknown", Healthy: true}),
+		Entry("empty status", operand(1, map[string]interface{}{}), Health{Status: "Unknown", Healthy: true}),
+		Entry("status without conditions or phase", operand(1, map[string]interface{}{"size": int64(3)}),
+			Health{Status: "Unknown", Healthy: true}),
+		Entry("observed generation behind", operand(2, map[string]interface{}{"observedGeneration": int64(1)}),
+			Health{Status: "Reconciling", Message: "observed generation 1 is behind generation 2"}),
+		Entry("observed generation current", operand(2, map[string]interface{}{"observedGeneration": int64(2), "phase": "Running"}),
+			Health{Status: "Running", Healthy: true}),
+		Entry("ready", operand(1, conditions(condition("Ready", "True", ""))),
+			Health{Status: "Ready", Healthy: true}),
+		Entry("not ready", operand(1, conditions(condition("Ready", "False", "waiting for members"))),
+			Health{Status: "NotReady", Message: "waiting for members"}),
+		Entry("available", operand(1, conditions(condition("Available", "True", ""))),
+			Health{Status: "Available", Healthy: true}),
+		Entry("unavailable", operand(1, conditions(condition("Available", "False", "no members"))),
+			Health{Status: "Unavailable", Message: "no members"}),
+		Entry("ready preferred over available", operand(1, conditions(condition("Available", "True", ""), condition("Ready", "True", ""))),
+			Health{Status: "Ready", Healthy: true}),
+		Entry("degraded", operand(1, conditions(condition("Ready", "True", ""), condition("Degraded", "True", "backup failed"))),
+			Health{Status: "Degraded", Message: "backup failed"}),
+		Entry("not degraded", operand(1, conditions(condition("Degraded", "False", ""), condition("Ready", "True", ""))),
+			Health{Status: "Ready", Healthy: true}),
+		Entry("failed phase", operand(1, map[string]interface{}{"phase": "Failed", "message": "member crashed"}),
+			Health{Status: "Failed", Message: "member crashed"}),
+		Entry("error phase", operand(1, map[string]interface{}{"phase": "ReconcileError"}),
+			Health{Status: "ReconcileError"}),
+		Entry("failed phase with ready condition", operand(1, map[string]interface{}{
+			"phase":      "Failed",
+			"conditions": []interface{}{condition("Ready", "True", "")},
+		}), Health{Status: "Failed"}),
+		Entry("phase only", operand(1, map[string]interface{}{"phase": "Running"}),
+			Health{Status: "Running", Healthy: true}),
+	)
+})
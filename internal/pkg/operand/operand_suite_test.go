@@ -0,0 +1,13 @@
+package operand
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestOperand(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Operand Suite")
+}
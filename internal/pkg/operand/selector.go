@@ -0,0 +1,98 @@
+package operand
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Selector selects operands by label or by kind. An operand is selected if it matches any of
+// the selector's label selectors or kinds.
+type Selector struct {
+	labels []labels.Selector
+	kinds  []kindMatcher
+}
+
+// kindMatcher matches a kind. An empty version matches any version, and anyGroup matches the
+// kind in any group.
+type kindMatcher struct {
+	gvk      schema.GroupVersionKind
+	anyGroup bool
+}
+
+func (m kindMatcher) matches(gvk schema.GroupVersionKind) bool {
+	if !strings.EqualFold(m.gvk.Kind, gvk.Kind) {
+		return false
+	}
+	if !m.anyGroup && m.gvk.Group != gvk.Group {
+		return false
+	}
+	return m.gvk.Version == "" || m.gvk.Version == gvk.Version
+}
+
+// ParseSelector parses selector expressions. Each expression is either a kind in the form
+// Kind[.version][.group], or a label selector using the kubectl syntax (e.g. app=etcd,tier!=db).
+// A kind without a group matches the kind in any group.
+func ParseSelector(exprs []string) (*Selector, error) {
+	s := &Selector{}
+	for _, expr := range exprs {
+		if isLabelSelector(expr) {
+			sel, err := labels.Parse(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid operand selector %q: %v", expr, err)
+			}
+			s.labels = append(s.labels, sel)
+			continue
+		}
+		if !strings.Contains(expr, ".") {
+			s.kinds = append(s.kinds, kindMatcher{gvk: schema.GroupVersionKind{Kind: expr}, anyGroup: true})
+			continue
+		}
+		// Kind.version.group is ambiguous with Kind.group when the group has several
+		// segments, so match either interpretation.
+		gvk, gk := schema.ParseKindArg(expr)
+		if gvk != nil {
+			s.kinds = append(s.kinds, kindMatcher{gvk: *gvk})
+		}
+		s.kinds = append(s.kinds, kindMatcher{gvk: gk.WithVersion("")})
+	}
+	return s, nil
+}
+
+// isLabelSelector returns true if expr uses label selector operators or names a label
+// existence requirement (key or !key), as opposed to a kind.
+func isLabelSelector(expr string) bool {
+	if strings.ContainsAny(expr, "=!,() ") {
+		return true
+	}
+	// Label keys with a prefix contain a slash, kinds never do.
+	return strings.Contains(expr, "/")
+}
+
+// Empty returns true if the selector has no expressions.
+func (s *Selector) Empty() bool {
+	return s == nil || (len(s.labels) == 0 && len(s.kinds) == 0)
+}
+
+// Matches returns true if the operand is selected. An empty selector matches every operand.
+func (s *Selector) Matches(obj unstructured.Unstructured) bool {
+	if s.Empty() {
+		return true
+	}
+	objLabels := labels.Set(obj.GetLabels())
+	for _, sel := range s.labels {
+		if sel.Matches(objLabels) {
+			return true
+		}
+	}
+	gvk := obj.GroupVersionKind()
+	for _, k := range s.kinds {
+		if k.matches(gvk) {
+			return true
+		}
+	}
+	return false
+}
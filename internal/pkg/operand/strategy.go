@@ -18,6 +18,8 @@ const (
 	Ignore DeletionStrategy = "ignore"
 	// Delete will delete the operands associated with the operator before deleting the operator, allowing finalizers to run.
 	Delete DeletionStrategy = "delete"
+	// DeleteNamespaced will delete only the namespaced operands associated with the operator, orphaning cluster-scoped operands.
+	DeleteNamespaced DeletionStrategy = "delete-namespaced"
 )
 
 func (d *DeletionStrategy) Set(str string) error {
@@ -31,12 +33,23 @@ func (d DeletionStrategy) String() string {
 
 func (d DeletionStrategy) Valid() error {
 	switch d {
-	case Abort, Ignore, Delete:
+	case Abort, Ignore, Delete, DeleteNamespaced:
 		return nil
 	}
 	return fmt.Errorf("unknown operand deletion strategy %q", d)
 }
 
+// Deletes returns true if the strategy deletes the operand.
+func (d DeletionStrategy) Deletes(namespaced bool) bool {
+	switch d {
+	case Delete:
+		return true
+	case DeleteNamespaced:
+		return namespaced
+	}
+	return false
+}
+
 func (d DeletionStrategy) Type() string {
 	return "DeletionStrategy"
 }
@@ -0,0 +1,344 @@
+package subscription
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/kubectl-operator/internal/pkg/toleration"
+)
+
+func ensureConfig(s *v1alpha1.Subscription) *v1alpha1.SubscriptionConfig {
+	if s.Spec.Config == nil {
+		s.Spec.Config = &v1alpha1.SubscriptionConfig{}
+	}
+	return s.Spec.Config
+}
+
+// Config merges v into the subscription's config. Fields set in v take precedence over
+// fields already present on the subscription.
+func Config(v v1alpha1.SubscriptionConfig) Option {
+	return func(s *v1alpha1.Subscription) {
+		for _, o := range []Option{
+			Env(v.Env...),
+			EnvFrom(v.EnvFrom...),
+			NodeSelector(v.NodeSelector),
+			Tolerations(v.Tolerations...),
+			Volumes(v.Volumes...),
+			VolumeMounts(v.VolumeMounts...),
+			Annotations(v.Annotations),
+		} {
+			o(s)
+		}
+		if v.Resources != nil {
+			Resources(*v.Resources)(s)
+		}
+		if v.Affinity != nil {
+			Affinity(v.Affinity)(s)
+		}
+		if v.Selector != nil {
+			ensureConfig(s).Selector = v.Selector
+		}
+	}
+}
+
+// Env sets environment variables on the operator's containers, replacing any existing
+// variables with the same name.
+func Env(v ...corev1.EnvVar) Option {
+	return func(s *v1alpha1.Subscription) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureConfig(s)
+	next:
+		for _, env := range v {
+			for i := range cfg.Env {
+				if cfg.Env[i].Name == env.Name {
+					cfg.Env[i] = env
+					continue next
+				}
+			}
+			cfg.Env = append(cfg.Env, env)
+		}
+	}
+}
+
+// EnvFrom adds sources of environment variables to the operator's containers.
+func EnvFrom(v ...corev1.EnvFromSource) Option {
+	return func(s *v1alpha1.Subscription) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureConfig(s)
+	next:
+		for _, src := range v {
+			for _, existing := range cfg.EnvFrom {
+				if envFromSourceEqual(existing, src) {
+					continue next
+				}
+			}
+			cfg.EnvFrom = append(cfg.EnvFrom, src)
+		}
+	}
+}
+
+func envFromSourceEqual(a, b corev1.EnvFromSource) bool {
+	if a.Prefix != b.Prefix {
+		return false
+	}
+	switch {
+	case a.ConfigMapRef != nil && b.ConfigMapRef != nil:
+		return a.ConfigMapRef.Name == b.ConfigMapRef.Name
+	case a.SecretRef != nil && b.SecretRef != nil:
+		return a.SecretRef.Name == b.SecretRef.Name
+	}
+	return false
+}
+
+// Resources sets resource limits and requests on the operator's containers. Only the
+// resources named in v are changed.
+func Resources(v corev1.ResourceRequirements) Option {
+	return func(s *v1alpha1.Subscription) {
+		if len(v.Limits) == 0 && len(v.Requests) == 0 {
+			return
+		}
+		cfg := ensureConfig(s)
+		if cfg.Resources == nil {
+			cfg.Resources = &corev1.ResourceRequirements{}
+		}
+		cfg.Resources.Limits = mergeResourceList(cfg.Resources.Limits, v.Limits)
+		cfg.Resources.Requests = mergeResourceList(cfg.Resources.Requests, v.Requests)
+	}
+}
+
+func mergeResourceList(dst, src corev1.ResourceList) corev1.ResourceList {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = corev1.ResourceList{}
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// NodeSelector adds node selector labels to the operator's pods.
+func NodeSelector(v map[string]string) Option {
+	return func(s *v1alpha1.Subscription) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureConfig(s)
+		cfg.NodeSelector = mergeStringMap(cfg.NodeSelector, v)
+	}
+}
+
+// Tolerations adds tolerations to the operator's pods.
+func Tolerations(v ...corev1.Toleration) Option {
+	return func(s *v1alpha1.Subscription) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureConfig(s)
+		cfg.Tolerations = toleration.Merge(cfg.Tolerations, v...)
+	}
+}
+
+// Volumes adds volumes to the operator's pods, replacing any existing volumes with the same name.
+func Volumes(v ...corev1.Volume) Option {
+	return func(s *v1alpha1.Subscription) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureConfig(s)
+	next:
+		for _, vol := range v {
+			for i := range cfg.Volumes {
+				if cfg.Volumes[i].Name == vol.Name {
+					cfg.Volumes[i] = vol
+					continue next
+				}
+			}
+			cfg.Volumes = append(cfg.Volumes, vol)
+		}
+	}
+}
+
+// VolumeMounts adds volume mounts to the operator's containers, replacing any existing mounts
+// at the same path.
+func VolumeMounts(v ...corev1.VolumeMount) Option {
+	return func(s *v1alpha1.Subscription) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureConfig(s)
+	next:
+		for _, m := range v {
+			for i := range cfg.VolumeMounts {
+				if cfg.VolumeMounts[i].MountPath == m.MountPath {
+					cfg.VolumeMounts[i] = m
+					continue next
+				}
+			}
+			cfg.VolumeMounts = append(cfg.VolumeMounts, m)
+		}
+	}
+}
+
+// Affinity sets the affinity of the operator's pods.
+func Affinity(v *corev1.Affinity) Option {
+	return func(s *v1alpha1.Subscription) {
+		ensureConfig(s).Affinity = v
+	}
+}
+
+// Annotations adds annotations to the operator's deployments and pods.
+func Annotations(v map[string]string) Option {
+	return func(s *v1alpha1.Subscription) {
+		if len(v) == 0 {
+			return
+		}
+		cfg := ensureConfig(s)
+		cfg.Annotations = mergeStringMap(cfg.Annotations, v)
+	}
+}
+
+func mergeStringMap(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// ConfigOptions holds the subscription config settings that can be provided on the command line.
+// Settings that do not have a flag representation, like volumes and affinity, can be provided
+// in a config file containing a SubscriptionConfig in YAML or JSON format.
+type ConfigOptions struct {
+	ConfigFile       string
+	Env              []string
+	EnvFrom          []string
+	ResourceLimits   map[string]string
+	ResourceRequests map[string]string
+	NodeSelector     map[string]string
+	Tolerations      []string
+	Annotations      map[string]string
+}
+
+// Options converts the config settings into subscription options. Settings from the config file
+// are applied first, so that flags can override them.
+func (o ConfigOptions) Options() ([]Option, error) {
+	var opts []Option
+	if o.ConfigFile != "" {
+		cfg, err := readConfigFile(o.ConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, Config(*cfg))
+	}
+
+	env := make([]corev1.EnvVar, 0, len(o.Env))
+	for _, e := range o.Env {
+		name, value, ok := strings.Cut(e, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid env %q: expected NAME=VALUE", e)
+		}
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	envFrom := make([]corev1.EnvFromSource, 0, len(o.EnvFrom))
+	for _, e := range o.EnvFrom {
+		src, err := parseEnvFrom(e)
+		if err != nil {
+			return nil, err
+		}
+		envFrom = append(envFrom, *src)
+	}
+
+	limits, err := parseResourceList(o.ResourceLimits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource limits: %v", err)
+	}
+	requests, err := parseResourceList(o.ResourceRequests)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource requests: %v", err)
+	}
+
+	tolerations := make([]corev1.Toleration, 0, len(o.Tolerations))
+	for _, t := range o.Tolerations {
+		tol, err := toleration.Parse(t)
+		if err != nil {
+			return nil, err
+		}
+		tolerations = append(tolerations, *tol)
+	}
+
+	opts = append(opts,
+		Env(env...),
+		EnvFrom(envFrom...),
+		Resources(corev1.ResourceRequirements{Limits: limits, Requests: requests}),
+		NodeSelector(o.NodeSelector),
+		Tolerations(tolerations...),
+		Annotations(o.Annotations),
+	)
+	return opts, nil
+}
+
+// IsEmpty returns true if no config settings were provided.
+func (o ConfigOptions) IsEmpty() bool {
+	return o.ConfigFile == "" && len(o.Env) == 0 && len(o.EnvFrom) == 0 &&
+		len(o.ResourceLimits) == 0 && len(o.ResourceRequests) == 0 &&
+		len(o.NodeSelector) == 0 && len(o.Tolerations) == 0 && len(o.Annotations) == 0
+}
+
+func readConfigFile(path string) (*v1alpha1.SubscriptionConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %v", err)
+	}
+	cfg := &v1alpha1.SubscriptionConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config file %q: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// parseEnvFrom parses values of the form configmap:NAME or secret:NAME.
+func parseEnvFrom(v string) (*corev1.EnvFromSource, error) {
+	kind, name, ok := strings.Cut(v, ":")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid env-from %q: expected configmap:NAME or secret:NAME", v)
+	}
+	switch strings.ToLower(kind) {
+	case "configmap", "cm":
+		return &corev1.EnvFromSource{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}}, nil
+	case "secret":
+		return &corev1.EnvFromSource{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}}, nil
+	}
+	return nil, fmt.Errorf("invalid env-from %q: kind must be one of configmap|secret", v)
+}
+
+func parseResourceList(in map[string]string) (corev1.ResourceList, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := corev1.ResourceList{}
+	for k, v := range in {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s=%s: %v", k, v, err)
+		}
+		out[corev1.ResourceName(k)] = q
+	}
+	return out, nil
+}
@@ -0,0 +1,59 @@
+package toleration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Parse parses tolerations using the same syntax as kubectl taint, with an optional
+// toleration period in seconds: key[=value][:effect[:seconds]]. An empty key with no value
+// tolerates every taint.
+func Parse(v string) (*corev1.Toleration, error) {
+	parts := strings.Split(v, ":")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid toleration %q: expected key[=value][:effect[:seconds]]", v)
+	}
+	t := &corev1.Toleration{Operator: corev1.TolerationOpExists}
+	if key, value, ok := strings.Cut(parts[0], "="); ok {
+		t.Key, t.Value, t.Operator = key, value, corev1.TolerationOpEqual
+	} else {
+		t.Key = key
+	}
+	if len(parts) > 1 {
+		switch effect := corev1.TaintEffect(parts[1]); effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+			t.Effect = effect
+		default:
+			return nil, fmt.Errorf("invalid toleration %q: unknown effect %q", v, parts[1])
+		}
+	}
+	if len(parts) > 2 {
+		seconds, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid toleration %q: invalid seconds: %v", v, err)
+		}
+		if t.Effect != corev1.TaintEffectNoExecute {
+			return nil, fmt.Errorf("invalid toleration %q: seconds can only be set for effect %s", v, corev1.TaintEffectNoExecute)
+		}
+		t.TolerationSeconds = &seconds
+	}
+	return t, nil
+}
+
+// Merge returns existing with the tolerations of v appended, skipping those that match a
+// toleration already present.
+func Merge(existing []corev1.Toleration, v ...corev1.Toleration) []corev1.Toleration {
+next:
+	for _, t := range v {
+		for _, e := range existing {
+			if e.MatchToleration(&t) {
+				continue next
+			}
+		}
+		existing = append(existing, t)
+	}
+	return existing
+}
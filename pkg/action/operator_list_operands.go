@@ -2,8 +2,10 @@ package action
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -14,16 +16,22 @@ import (
 
 	v1 "github.com/operator-framework/api/pkg/operators/v1"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 )
 
 // OperatorListOperands knows how to find and list custom resources given a package name and namespace.
 type OperatorListOperands struct {
 	config *Configuration
+
+	// Logf reports warnings about operators whose operand list may be inaccurate, such as
+	// operators with a CSV that did not succeed.
+	Logf func(string, ...interface{})
 }
 
 func NewOperatorListOperands(cfg *Configuration) *OperatorListOperands {
 	return &OperatorListOperands{
 		config: cfg,
+		Logf:   func(string, ...interface{}) {},
 	}
 }
 
@@ -46,52 +54,155 @@ func (o *OperatorListOperands) findOperator(ctx context.Context, packageName str
 	err := o.config.Client.Get(ctx, opKey, &operator)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, fmt.Errorf("package %q not found in namespace %q", packageName, o.config.Namespace)
+			return nil, errPackageNotFound{packageName: packageName, namespace: o.config.Namespace}
 		}
 		return nil, err
 	}
 	return &operator, nil
 }
 
-// Unzip finds the CSV referenced by the provided operator and then inspects the spec.customresourcedefinitions.owned
-// section of the CSV to return a list of APIs that are owned by the CSV.
-func (o *OperatorListOperands) unzip(ctx context.Context, operator *v1.Operator) ([]v1alpha1.CRDDescription, error) {
-	csv := v1alpha1.ClusterServiceVersion{}
+type errPackageNotFound struct {
+	packageName string
+	namespace   string
+}
+
+func (e errPackageNotFound) Error() string {
+	return fmt.Sprintf("package %q not found in namespace %q", e.packageName, e.namespace)
+}
+
+// findCSV finds the CSV of the operator. The CSV is looked up from the operator's components,
+// falling back to the installed CSV of the package's subscription, since OLM may not populate
+// the operator object for operators that failed to install.
+func (o *OperatorListOperands) findCSV(ctx context.Context, packageName string) (*v1alpha1.ClusterServiceVersion, error) {
+	var csvKey types.NamespacedName
+	operator, err := o.findOperator(ctx, packageName)
+	if err == nil {
+		csvKey, err = o.operatorCSVKey(operator)
+	}
+	if err != nil {
+		var subErr error
+		csvKey, subErr = o.subscriptionCSVKey(ctx, packageName)
+		if subErr != nil {
+			return nil, err
+		}
+	}
+
+	csv := &v1alpha1.ClusterServiceVersion{}
+	if err := o.config.Client.Get(ctx, csvKey, csv); err != nil {
+		return nil, fmt.Errorf("could not get %s CSV on cluster: %s", csvKey.String(), err)
+	}
+
+	// Copied CSVs in the operator group's target namespaces mirror the original CSV in the
+	// operator's namespace, which is the one that carries the operator group annotations.
+	if csv.IsCopied() {
+		origKey := types.NamespacedName{Name: csv.Name, Namespace: csv.GetLabels()[v1alpha1.CopiedLabelKey]}
+		if err := o.config.Client.Get(ctx, origKey, csv); err != nil {
+			return nil, fmt.Errorf("could not get %s CSV copied to %s on cluster: %s", origKey.String(), csvKey.Namespace, err)
+		}
+	}
+	return csv, nil
+}
+
+// operatorCSVKey returns the CSV referenced by the operator's components, preferring the CSV in
+// the operator's namespace over copied CSVs.
+func (o *OperatorListOperands) operatorCSVKey(operator *v1.Operator) (types.NamespacedName, error) {
 	csvKey := types.NamespacedName{}
 
 	if operator.Status.Components == nil {
-		return nil, fmt.Errorf("could not find underlying components for operator %s", operator.Name)
+		return csvKey, fmt.Errorf("could not find underlying components for operator %s", operator.Name)
 	}
 	for _, resource := range operator.Status.Components.Refs {
-		if resource.Kind == v1alpha1.ClusterServiceVersionKind {
+		if resource.ObjectReference == nil || resource.Kind != v1alpha1.ClusterServiceVersionKind {
+			continue
+		}
+		if csvKey.Name == "" || resource.Namespace == o.config.Namespace {
 			csvKey.Name = resource.Name
 			csvKey.Namespace = resource.Namespace
-			break
 		}
 	}
 
 	if csvKey.Name == "" && csvKey.Namespace == "" {
-		return nil, fmt.Errorf("could not find underlying CSV for operator %s", operator.Name)
+		return csvKey, fmt.Errorf("could not find underlying CSV for operator %s", operator.Name)
 	}
+	return csvKey, nil
+}
 
-	err := o.config.Client.Get(ctx, csvKey, &csv)
-	if err != nil {
-		return nil, fmt.Errorf("could not get %s CSV on cluster: %s", csvKey.String(), err)
+func (o *OperatorListOperands) subscriptionCSVKey(ctx context.Context, packageName string) (types.NamespacedName, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := o.config.Client.List(ctx, &subs, client.InNamespace(o.config.Namespace)); err != nil {
+		return types.NamespacedName{}, err
 	}
+	for _, sub := range subs.Items {
+		if sub.Spec == nil || sub.Spec.Package != packageName {
+			continue
+		}
+		name := sub.Status.InstalledCSV
+		if name == "" {
+			name = sub.Status.CurrentCSV
+		}
+		if name == "" {
+			break
+		}
+		return types.NamespacedName{Name: name, Namespace: sub.Namespace}, nil
+	}
+	return types.NamespacedName{}, fmt.Errorf("no installed CSV found for package %q", packageName)
+}
+
+// Unzip inspects the spec.customresourcedefinitions.owned section of the CSV to return a list
+// of APIs that are owned by the CSV.
+func (o *OperatorListOperands) unzip(csv *v1alpha1.ClusterServiceVersion) ([]v1alpha1.CRDDescription, error) {
+	csvKey := types.NamespacedName{Name: csv.Name, Namespace: csv.Namespace}
 
 	// check if owned CRDs are defined on the csv
 	if len(csv.Spec.CustomResourceDefinitions.Owned) == 0 {
 		return nil, fmt.Errorf("no owned CustomResourceDefinitions specified on CSV %s, no custom resources to display", csvKey.String())
 	}
 
-	// check CSV is not in a failed state (to ensure some OLM multitenancy rules are not violated)
+	// Operands are still listed for CSVs that did not succeed, since that is usually when they are
+	// needed most, but OLM may not have settled the operator's namespaces yet.
 	if csv.Status.Phase != v1alpha1.CSVPhaseSucceeded {
-		return nil, fmt.Errorf("CSV underlying operator is not in a succeeded state: custom resource list may not be accurate")
+		phase := csv.Status.Phase
+		if phase == v1alpha1.CSVPhaseNone {
+			phase = "Unknown"
+		}
+		o.Logf("CSV %s is in phase %s, not Succeeded: custom resource list may not be accurate", csvKey.String(), phase)
 	}
 
 	return csv.Spec.CustomResourceDefinitions.Owned, nil
 }
 
+// targetNamespaces returns the namespaces the operator watches. They are taken from the
+// namespace's operator group, or from the operator group annotations OLM puts on the CSV if the
+// namespace has no operator group or several of them. An empty namespace means all namespaces.
+func (o *OperatorListOperands) targetNamespaces(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) ([]string, error) {
+	ogList := v1.OperatorGroupList{}
+	options := client.ListOptions{Namespace: csv.Namespace}
+	if err := o.config.Client.List(ctx, &ogList, &options); err != nil {
+		return nil, err
+	}
+
+	annotations := csv.GetAnnotations()
+	switch len(ogList.Items) {
+	case 1:
+		return ogList.Items[0].Status.Namespaces, nil
+	case 0:
+		o.Logf("no operator group found in namespace %s", csv.Namespace)
+	default:
+		for _, og := range ogList.Items {
+			if og.Name == annotations[v1.OperatorGroupAnnotationKey] {
+				return og.Status.Namespaces, nil
+			}
+		}
+		o.Logf("%d operator groups found in namespace %s", len(ogList.Items), csv.Namespace)
+	}
+
+	targets, ok := annotations[v1.OperatorGroupTargetsAnnotationKey]
+	if !ok {
+		return nil, fmt.Errorf("could not determine target namespaces of CSV %s/%s: no unique operator group in namespace and no %s annotation", csv.Namespace, csv.Name, v1.OperatorGroupTargetsAnnotationKey)
+	}
+	return strings.Split(targets, ","), nil
+}
+
 // List takes in a CRD description and finds the associated CRs on-cluster.
 // List can return a potentially unbounded list that callers may need to paginate.
 func (o *OperatorListOperands) list(ctx context.Context, crdDesc v1alpha1.CRDDescription, namespaces []string) (*unstructured.UnstructuredList, error) {
@@ -133,28 +244,11 @@ func (o *OperatorListOperands) list(ctx context.Context, crdDesc v1alpha1.CRDDes
 
 // ListAll wraps the above functions to provide a convenient command to go from package/namespace to custom resources.
 func (o *OperatorListOperands) listAll(ctx context.Context, packageName string) (*unstructured.UnstructuredList, error) {
-	operator, err := o.findOperator(ctx, packageName)
+	crdDescs, namespaces, err := o.operandSources(ctx, packageName)
 	if err != nil {
 		return nil, err
 	}
 
-	crdDescs, err := o.unzip(ctx, operator)
-	if err != nil {
-		return nil, err
-	}
-
-	// find all namespaces associated with operator via operatorgroup
-	// query for CRs in these namespaces
-	ogList := v1.OperatorGroupList{}
-	options := client.ListOptions{Namespace: o.config.Namespace}
-	if err := o.config.Client.List(ctx, &ogList, &options); err != nil {
-		return nil, err
-	}
-	if len(ogList.Items) != 1 {
-		return nil, fmt.Errorf("unexpected number (%d) of operator groups found in namespace %s", len(ogList.Items), o.config.Namespace)
-	}
-	namespaces := ogList.Items[0].Status.Namespaces
-
 	var result unstructured.UnstructuredList
 	result.SetGroupVersionKind(schema.GroupVersionKind{
 		Version: "v1",
@@ -168,21 +262,116 @@ func (o *OperatorListOperands) listAll(ctx context.Context, packageName string)
 		result.Items = append(result.Items, list.Items...)
 	}
 
-	// sort results
-	sort.Slice(result.Items, func(i, j int) bool {
-		if result.Items[i].GetAPIVersion() != result.Items[j].GetAPIVersion() {
-			return result.Items[i].GetAPIVersion() < result.Items[j].GetAPIVersion()
+	sortOperands(result.Items)
+
+	return &result, nil
+}
+
+// operandSources returns the APIs of the package's operands and the namespaces the operator
+// watches. No namespaces means all namespaces.
+func (o *OperatorListOperands) operandSources(ctx context.Context, packageName string) ([]v1alpha1.CRDDescription, []string, error) {
+	csv, err := o.findCSV(ctx, packageName)
+	var notFound errPackageNotFound
+	switch {
+	case errors.As(err, &notFound):
+		// The package may have been installed by OLMv1, which installs CRDs for a ClusterExtension
+		// instead of creating a CSV. Extensions are cluster-wide, so operands are listed in all
+		// namespaces.
+		ext, extErr := o.findClusterExtension(ctx, packageName)
+		if extErr != nil {
+			return nil, nil, err
+		}
+		crdDescs, err := o.clusterExtensionCRDs(ctx, ext)
+		return crdDescs, nil, err
+	case err != nil:
+		return nil, nil, err
+	}
+
+	crdDescs, err := o.unzip(csv)
+	if err != nil {
+		return nil, nil, err
+	}
+	// find all namespaces associated with operator via operatorgroup
+	// query for CRs in these namespaces
+	namespaces, err := o.targetNamespaces(ctx, csv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return crdDescs, namespaces, nil
+}
+
+// sortOperands sorts operands by API version, kind, namespace and name.
+func sortOperands(items []unstructured.Unstructured) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetAPIVersion() != items[j].GetAPIVersion() {
+			return items[i].GetAPIVersion() < items[j].GetAPIVersion()
 		}
-		if result.Items[i].GetKind() != result.Items[j].GetKind() {
-			return result.Items[i].GetKind() < result.Items[j].GetKind()
+		if items[i].GetKind() != items[j].GetKind() {
+			return items[i].GetKind() < items[j].GetKind()
 		}
-		if result.Items[i].GetNamespace() != result.Items[j].GetNamespace() {
-			return result.Items[i].GetNamespace() < result.Items[j].GetNamespace()
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
 		}
-		return result.Items[i].GetName() < result.Items[j].GetName()
+		return items[i].GetName() < items[j].GetName()
 	})
+}
 
-	return &result, nil
+// Labels that operator-controller puts on the objects it installs for a ClusterExtension.
+const (
+	extensionOwnerKindLabel = "olm.operatorframework.io/owner-kind"
+	extensionOwnerNameLabel = "olm.operatorframework.io/owner-name"
+)
+
+// findClusterExtension finds the ClusterExtension named after the package, or else the only
+// ClusterExtension that installs the package.
+func (o *OperatorListOperands) findClusterExtension(ctx context.Context, packageName string) (*olmv1.ClusterExtension, error) {
+	exts := olmv1.ClusterExtensionList{}
+	if err := o.config.Client.List(ctx, &exts); err != nil {
+		return nil, err
+	}
+	var matches []olmv1.ClusterExtension
+	for _, ext := range exts.Items {
+		if ext.Name == packageName {
+			return &ext, nil
+		}
+		if ext.Spec.Source.Catalog != nil && ext.Spec.Source.Catalog.PackageName == packageName {
+			matches = append(matches, ext)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no clusterextension found for package %q", packageName)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d clusterextensions found for package %q", len(matches), packageName)
+	}
+}
+
+// clusterExtensionCRDs returns the CRDs installed for the extension, at their storage version.
+func (o *OperatorListOperands) clusterExtensionCRDs(ctx context.Context, ext *olmv1.ClusterExtension) ([]v1alpha1.CRDDescription, error) {
+	crds := apiextensionsv1.CustomResourceDefinitionList{}
+	if err := o.config.Client.List(ctx, &crds, client.MatchingLabels{
+		extensionOwnerKindLabel: olmv1.ClusterExtensionKind,
+		extensionOwnerNameLabel: ext.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("list crds of clusterextension %q: %v", ext.Name, err)
+	}
+	if len(crds.Items) == 0 {
+		return nil, fmt.Errorf("no CustomResourceDefinitions installed by clusterextension %q, no custom resources to display", ext.Name)
+	}
+
+	descs := make([]v1alpha1.CRDDescription, 0, len(crds.Items))
+	for _, crd := range crds.Items {
+		desc := v1alpha1.CRDDescription{Name: crd.Name, Kind: crd.Spec.Names.Kind}
+		for _, v := range crd.Spec.Versions {
+			if v.Storage {
+				desc.Version = v.Name
+			}
+		}
+		descs = append(descs, desc)
+	}
+	return descs, nil
 }
 
 func inNamespace(ns string, namespaces []string) bool {
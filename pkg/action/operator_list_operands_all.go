@@ -0,0 +1,212 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// operatorLabelPrefix prefixes the label OLM puts on the objects of an operator, in the form
+// operators.coreos.com/<package>.<namespace>.
+const operatorLabelPrefix = "operators.coreos.com/"
+
+// maxConcurrentLists bounds the number of operand list calls made at the same time.
+const maxConcurrentLists = 8
+
+// OperatorOperands are the operands of a single installed operator.
+type OperatorOperands struct {
+	Package   string                      `json:"package"`
+	Namespace string                      `json:"namespace"`
+	Operands  []unstructured.Unstructured `json:"operands"`
+}
+
+// OrphanedOperands are operands of a CRD that OLM installed for an operator that is no longer
+// installed.
+type OrphanedOperands struct {
+	CRD string `json:"crd"`
+	// Operator is the operator named by the CRD's OLM label, in the form <package>.<namespace>.
+	Operator string                      `json:"operator"`
+	Operands []unstructured.Unstructured `json:"operands"`
+}
+
+// AllOperands are the operands of every installed operator.
+type AllOperands struct {
+	Operators []OperatorOperands `json:"operators"`
+	// SharedCRDs maps CRDs owned by more than one operator to those operators, each in the form
+	// <namespace>/<package>.
+	SharedCRDs map[string][]string `json:"sharedCRDs,omitempty"`
+	Orphaned   []OrphanedOperands  `json:"orphaned,omitempty"`
+}
+
+// RunAll lists the operands of every operator installed by a subscription in any namespace.
+// Each CRD is listed once, even if several operators own it. Operators whose operands cannot be
+// determined are reported through Logf and skipped.
+func (o *OperatorListOperands) RunAll(ctx context.Context) (*AllOperands, error) {
+	subs := v1alpha1.SubscriptionList{}
+	if err := o.config.Client.List(ctx, &subs); err != nil {
+		return nil, fmt.Errorf("list subscriptions: %v", err)
+	}
+
+	type operatorSources struct {
+		OperatorOperands
+		crdDescs   []v1alpha1.CRDDescription
+		namespaces []string
+	}
+	var operators []operatorSources
+	owners := map[string][]string{}
+	skipped := map[string]struct{}{}
+	crdDescs := map[string]v1alpha1.CRDDescription{}
+	for _, sub := range subs.Items {
+		if sub.Spec == nil {
+			continue
+		}
+		cfg := *o.config
+		cfg.Namespace = sub.Namespace
+		lister := &OperatorListOperands{config: &cfg, Logf: o.Logf}
+		descs, namespaces, err := lister.operandSources(ctx, sub.Spec.Package)
+		if err != nil {
+			o.Logf("skipping operator %q in namespace %s: %v", sub.Spec.Package, sub.Namespace, err)
+			skipped[sub.Spec.Package+"."+sub.Namespace] = struct{}{}
+			continue
+		}
+		operators = append(operators, operatorSources{
+			OperatorOperands: OperatorOperands{Package: sub.Spec.Package, Namespace: sub.Namespace},
+			crdDescs:         descs,
+			namespaces:       namespaces,
+		})
+		for _, desc := range descs {
+			owners[desc.Name] = append(owners[desc.Name], sub.Namespace+"/"+sub.Spec.Package)
+			crdDescs[desc.Name] = desc
+		}
+	}
+
+	orphanedCRDs, err := o.orphanedCRDs(ctx, owners, skipped)
+	if err != nil {
+		return nil, err
+	}
+	for _, crd := range orphanedCRDs {
+		crdDescs[crd.desc.Name] = crd.desc
+	}
+
+	listed, err := o.listConcurrently(ctx, crdDescs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AllOperands{}
+	for _, op := range operators {
+		for _, desc := range op.crdDescs {
+			for _, cr := range listed[desc.Name] {
+				if len(op.namespaces) == 0 || cr.GetNamespace() == "" || inNamespace(cr.GetNamespace(), op.namespaces) {
+					op.Operands = append(op.Operands, cr)
+				}
+			}
+		}
+		sortOperands(op.Operands)
+		result.Operators = append(result.Operators, op.OperatorOperands)
+	}
+	sort.Slice(result.Operators, func(i, j int) bool {
+		if result.Operators[i].Namespace != result.Operators[j].Namespace {
+			return result.Operators[i].Namespace < result.Operators[j].Namespace
+		}
+		return result.Operators[i].Package < result.Operators[j].Package
+	})
+
+	for name, crdOwners := range owners {
+		if len(crdOwners) > 1 {
+			if result.SharedCRDs == nil {
+				result.SharedCRDs = map[string][]string{}
+			}
+			result.SharedCRDs[name] = crdOwners
+		}
+	}
+
+	for _, crd := range orphanedCRDs {
+		operands := listed[crd.desc.Name]
+		if len(operands) == 0 {
+			continue
+		}
+		sortOperands(operands)
+		result.Orphaned = append(result.Orphaned, OrphanedOperands{CRD: crd.desc.Name, Operator: crd.operator, Operands: operands})
+	}
+	return result, nil
+}
+
+type orphanedCRD struct {
+	desc     v1alpha1.CRDDescription
+	operator string
+}
+
+// orphanedCRDs returns the CRDs that OLM labeled for an operator, but that no installed
+// operator owns. CRDs labeled for skipped operators are not orphaned, since those operators
+// are still installed.
+func (o *OperatorListOperands) orphanedCRDs(ctx context.Context, owners map[string][]string, skipped map[string]struct{}) ([]orphanedCRD, error) {
+	crds := apiextensionsv1.CustomResourceDefinitionList{}
+	if err := o.config.Client.List(ctx, &crds); err != nil {
+		return nil, fmt.Errorf("list crds: %v", err)
+	}
+	var orphaned []orphanedCRD
+	for _, crd := range crds.Items {
+		if _, ok := owners[crd.Name]; ok {
+			continue
+		}
+		for key := range crd.GetLabels() {
+			operator, ok := strings.CutPrefix(key, operatorLabelPrefix)
+			if !ok {
+				continue
+			}
+			if _, ok := skipped[operator]; ok {
+				break
+			}
+			desc := v1alpha1.CRDDescription{Name: crd.Name, Kind: crd.Spec.Names.Kind}
+			for _, v := range crd.Spec.Versions {
+				if v.Storage {
+					desc.Version = v.Name
+				}
+			}
+			orphaned = append(orphaned, orphanedCRD{desc: desc, operator: operator})
+			break
+		}
+	}
+	sort.Slice(orphaned, func(i, j int) bool { return orphaned[i].desc.Name < orphaned[j].desc.Name })
+	return orphaned, nil
+}
+
+// listConcurrently lists the custom resources of each CRD in all namespaces, keyed by CRD name.
+// CRDs that cannot be listed are reported through Logf and have no custom resources.
+func (o *OperatorListOperands) listConcurrently(ctx context.Context, crdDescs map[string]v1alpha1.CRDDescription) (map[string][]unstructured.Unstructured, error) {
+	var mu sync.Mutex
+	listed := make(map[string][]unstructured.Unstructured, len(crdDescs))
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(maxConcurrentLists)
+	for name, desc := range crdDescs {
+		name, desc := name, desc
+		eg.Go(func() error {
+			list, err := o.list(egCtx, desc, nil)
+			if err != nil {
+				if egCtx.Err() != nil {
+					return egCtx.Err()
+				}
+				o.Logf("could not list custom resources of %s: %v", name, err)
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			listed[name] = list.Items
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return listed, nil
+}
@@ -2,6 +2,7 @@ package action_test
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -16,6 +17,7 @@ import (
 
 	v1 "github.com/operator-framework/api/pkg/operators/v1"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	olmv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
@@ -171,21 +173,121 @@ var _ = Describe("OperatorListOperands", func() {
 		Expect(err.Error()).To(ContainSubstring("no owned CustomResourceDefinitions specified on CSV etcd-namespace/etcdoperator.v0.9.4-clusterwide"))
 	})
 
-	It("should fail if the CSV is not in phase Succeeded", func() {
+	It("should list operands with a warning if the CSV is not in phase Succeeded", func() {
 		csv.Status.Phase = v1alpha1.CSVPhaseFailed
 		Expect(cfg.Client.Update(context.TODO(), csv)).To(Succeed())
 
+		var warnings []string
 		lister := action.NewOperatorListOperands(&cfg)
-		_, err := lister.Run(context.TODO(), "etcd")
-		Expect(err.Error()).To(ContainSubstring("CSV underlying operator is not in a succeeded state"))
+		lister.Logf = func(format string, args ...interface{}) {
+			warnings = append(warnings, fmt.Sprintf(format, args...))
+		}
+		operands, err := lister.Run(context.TODO(), "etcd")
+		Expect(err).To(BeNil())
+		Expect(operands.Items).To(HaveLen(3))
+		Expect(warnings).To(ConsistOf(ContainSubstring("is in phase Failed")))
 	})
 
-	It("should fail if there is not exactly 1 operator group", func() {
+	It("should fail if there is no operator group and no target namespaces annotation", func() {
 		Expect(cfg.Client.Delete(context.TODO(), og)).To(Succeed())
 
 		lister := action.NewOperatorListOperands(&cfg)
 		_, err := lister.Run(context.TODO(), "etcd")
-		Expect(err.Error()).To(ContainSubstring("unexpected number (0) of operator groups found in namespace etcd"))
+		Expect(err.Error()).To(ContainSubstring("could not determine target namespaces of CSV etcd-namespace/etcdoperator.v0.9.4-clusterwide"))
+	})
+
+	It("should use the target namespaces annotation when there is no operator group", func() {
+		Expect(cfg.Client.Delete(context.TODO(), og)).To(Succeed())
+		csv.SetAnnotations(map[string]string{v1.OperatorGroupTargetsAnnotationKey: "ns1"})
+		Expect(cfg.Client.Update(context.TODO(), csv)).To(Succeed())
+
+		lister := action.NewOperatorListOperands(&cfg)
+		operands, err := lister.Run(context.TODO(), "etcd")
+		Expect(err).To(BeNil())
+		Expect(getObjectNames(*operands)).To(ConsistOf(
+			types.NamespacedName{Name: "cluster1", Namespace: "ns1"},
+			types.NamespacedName{Name: "cluster3", Namespace: ""},
+		))
+	})
+
+	It("should use the operator group named on the CSV when there are several", func() {
+		og.Status.Namespaces = []string{"ns2"}
+		Expect(cfg.Client.Update(context.TODO(), og)).To(Succeed())
+		other := &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "etcd-namespace"},
+			Status:     v1.OperatorGroupStatus{Namespaces: []string{"ns1"}},
+		}
+		Expect(cfg.Client.Create(context.TODO(), other)).To(Succeed())
+		csv.SetAnnotations(map[string]string{v1.OperatorGroupAnnotationKey: "etcd"})
+		Expect(cfg.Client.Update(context.TODO(), csv)).To(Succeed())
+
+		lister := action.NewOperatorListOperands(&cfg)
+		operands, err := lister.Run(context.TODO(), "etcd")
+		Expect(err).To(BeNil())
+		Expect(getObjectNames(*operands)).To(ConsistOf(
+			types.NamespacedName{Name: "cluster2", Namespace: "ns2"},
+			types.NamespacedName{Name: "cluster3", Namespace: ""},
+		))
+	})
+
+	It("should fall back to the subscription when the operator has no components", func() {
+		operator.Status.Components = nil
+		Expect(cfg.Client.Update(context.TODO(), operator)).To(Succeed())
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd"},
+			Status:     v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v0.9.4-clusterwide"},
+		}
+		Expect(cfg.Client.Create(context.TODO(), sub)).To(Succeed())
+
+		lister := action.NewOperatorListOperands(&cfg)
+		operands, err := lister.Run(context.TODO(), "etcd")
+		Expect(err).To(BeNil())
+		Expect(operands.Items).To(HaveLen(3))
+	})
+
+	It("should follow copied CSVs to the original", func() {
+		copied := &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcdoperator.v0.9.4-clusterwide",
+				Namespace: "ns1",
+				Labels:    map[string]string{v1alpha1.CopiedLabelKey: "etcd-namespace"},
+			},
+			Status: v1alpha1.ClusterServiceVersionStatus{Reason: v1alpha1.CSVReasonCopied},
+		}
+		Expect(cfg.Client.Create(context.TODO(), copied)).To(Succeed())
+		operator.Status.Components.Refs[0].Namespace = "ns1"
+		Expect(cfg.Client.Update(context.TODO(), operator)).To(Succeed())
+
+		lister := action.NewOperatorListOperands(&cfg)
+		operands, err := lister.Run(context.TODO(), "etcd")
+		Expect(err).To(BeNil())
+		Expect(operands.Items).To(HaveLen(3))
+	})
+
+	It("should list operands of a ClusterExtension", func() {
+		ext := &olmv1.ClusterExtension{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-etcd"},
+			Spec: olmv1.ClusterExtensionSpec{
+				Source: olmv1.SourceConfig{
+					SourceType: olmv1.SourceTypeCatalog,
+					Catalog:    &olmv1.CatalogFilter{PackageName: "etcd-v1"},
+				},
+			},
+		}
+		Expect(cfg.Client.Create(context.TODO(), ext)).To(Succeed())
+		crd.SetLabels(map[string]string{
+			"olm.operatorframework.io/owner-kind": "ClusterExtension",
+			"olm.operatorframework.io/owner-name": "my-etcd",
+		})
+		crd.Spec.Names.Kind = "EtcdCluster"
+		crd.Spec.Versions = []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1beta2", Served: true, Storage: true}}
+		Expect(cfg.Client.Update(context.TODO(), crd)).To(Succeed())
+
+		lister := action.NewOperatorListOperands(&cfg)
+		operands, err := lister.Run(context.TODO(), "etcd-v1")
+		Expect(err).To(BeNil())
+		Expect(operands.Items).To(HaveLen(3))
 	})
 
 	It("should fail if an owned CRD does not exist", func() {
@@ -269,6 +371,78 @@ var _ = Describe("OperatorListOperands", func() {
 			types.NamespacedName{Name: "cluster3", Namespace: ""},
 		))
 	})
+
+	It("should list the operands of every installed operator", func() {
+		og.Status.Namespaces = []string{"ns1"}
+		Expect(cfg.Client.Update(context.TODO(), og)).To(Succeed())
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-namespace"},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd"},
+			Status:     v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v0.9.4-clusterwide"},
+		}
+		Expect(cfg.Client.Create(context.TODO(), sub)).To(Succeed())
+
+		// A second operator owning the same CRD in another namespace.
+		otherCSV := csv.DeepCopy()
+		otherCSV.ResourceVersion = ""
+		otherCSV.Namespace = "other-namespace"
+		Expect(cfg.Client.Create(context.TODO(), otherCSV)).To(Succeed())
+		otherOG := &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other-namespace"},
+			Status:     v1.OperatorGroupStatus{Namespaces: []string{"ns2"}},
+		}
+		Expect(cfg.Client.Create(context.TODO(), otherOG)).To(Succeed())
+		otherSub := sub.DeepCopy()
+		otherSub.ResourceVersion = ""
+		otherSub.Namespace = "other-namespace"
+		Expect(cfg.Client.Create(context.TODO(), otherSub)).To(Succeed())
+
+		// A CRD left behind by an operator that is no longer installed.
+		orphanedGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+		cfg.Scheme.AddKnownTypeWithName(orphanedGVK, &unstructured.Unstructured{})
+		cfg.Scheme.AddKnownTypeWithName(orphanedGVK.GroupVersion().WithKind("WidgetList"), &unstructured.UnstructuredList{})
+		orphanedCRD := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "widgets.example.com",
+				Labels: map[string]string{"operators.coreos.com/widgets.widget-namespace": ""},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group:    "example.com",
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", ListKind: "WidgetList"},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+			},
+		}
+		Expect(cfg.Client.Create(context.TODO(), orphanedCRD)).To(Succeed())
+		widget := &unstructured.Unstructured{}
+		widget.SetGroupVersionKind(orphanedGVK)
+		widget.SetNamespace("ns1")
+		widget.SetName("widget")
+		Expect(cfg.Client.Create(context.TODO(), widget)).To(Succeed())
+
+		lister := action.NewOperatorListOperands(&cfg)
+		result, err := lister.RunAll(context.TODO())
+		Expect(err).To(BeNil())
+
+		Expect(result.Operators).To(HaveLen(2))
+		Expect(result.Operators[0].Namespace).To(Equal("etcd-namespace"))
+		Expect(getObjectNames(unstructured.UnstructuredList{Items: result.Operators[0].Operands})).To(ConsistOf(
+			types.NamespacedName{Name: "cluster1", Namespace: "ns1"},
+			types.NamespacedName{Name: "cluster3", Namespace: ""},
+		))
+		Expect(result.Operators[1].Namespace).To(Equal("other-namespace"))
+		Expect(getObjectNames(unstructured.UnstructuredList{Items: result.Operators[1].Operands})).To(ConsistOf(
+			types.NamespacedName{Name: "cluster2", Namespace: "ns2"},
+			types.NamespacedName{Name: "cluster3", Namespace: ""},
+		))
+
+		Expect(result.SharedCRDs).To(HaveKeyWithValue("etcdclusters.etcd.database.coreos.com",
+			ConsistOf("etcd-namespace/etcd", "other-namespace/etcd")))
+
+		Expect(result.Orphaned).To(HaveLen(1))
+		Expect(result.Orphaned[0].CRD).To(Equal("widgets.example.com"))
+		Expect(result.Orphaned[0].Operator).To(Equal("widgets.widget-namespace"))
+		Expect(result.Orphaned[0].Operands).To(HaveLen(1))
+	})
 })
 
 func getObjectNames(objects unstructured.UnstructuredList) []types.NamespacedName {
@@ -1,4 +1,4 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
@@ -15,7 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
@@ -26,7 +26,7 @@ const (
 
 func TestCommand(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Internal v1 action Suite")
+	RunSpecs(t, "OLMv1 Suite")
 }
 
 type fakeClient struct {
@@ -102,109 +102,109 @@ func setupTestCatalogs(n int) []client.Object {
 	return result
 }
 
-func newClusterCatalog(name string) *olmv1.ClusterCatalog {
-	return &olmv1.ClusterCatalog{
+func newClusterCatalog(name string) *ocv1.ClusterCatalog {
+	return &ocv1.ClusterCatalog{
 		ObjectMeta: metav1.ObjectMeta{Name: name},
 	}
 }
 
-type extensionOpt func(*olmv1.ClusterExtension)
+type extensionOpt func(*ocv1.ClusterExtension)
 
-type catalogOpt func(*olmv1.ClusterCatalog)
+type catalogOpt func(*ocv1.ClusterCatalog)
 
 func withVersion(version string) extensionOpt {
-	return func(ext *olmv1.ClusterExtension) {
+	return func(ext *ocv1.ClusterExtension) {
 		ext.Spec.Source.Catalog.Version = version
 	}
 }
 
 func withSourceType(sourceType string) extensionOpt {
-	return func(ext *olmv1.ClusterExtension) {
+	return func(ext *ocv1.ClusterExtension) {
 		ext.Spec.Source.SourceType = sourceType
 	}
 }
 
 // nolint: unparam
 func withConstraintPolicy(policy string) extensionOpt {
-	return func(ext *olmv1.ClusterExtension) {
-		ext.Spec.Source.Catalog.UpgradeConstraintPolicy = olmv1.UpgradeConstraintPolicy(policy)
+	return func(ext *ocv1.ClusterExtension) {
+		ext.Spec.Source.Catalog.UpgradeConstraintPolicy = ocv1.UpgradeConstraintPolicy(policy)
 	}
 }
 
 func withChannels(channels ...string) extensionOpt {
-	return func(ext *olmv1.ClusterExtension) {
+	return func(ext *ocv1.ClusterExtension) {
 		ext.Spec.Source.Catalog.Channels = channels
 	}
 }
 
 func withLabels(labels map[string]string) extensionOpt {
-	return func(ext *olmv1.ClusterExtension) {
+	return func(ext *ocv1.ClusterExtension) {
 		ext.SetLabels(labels)
 	}
 }
 
 func withCRDUpgradePolicy(policy string) extensionOpt {
-	return func(ext *olmv1.ClusterExtension) {
+	return func(ext *ocv1.ClusterExtension) {
 		if ext.Spec.Install == nil {
-			ext.Spec.Install = &olmv1.ClusterExtensionInstallConfig{}
+			ext.Spec.Install = &ocv1.ClusterExtensionInstallConfig{}
 		}
 		if ext.Spec.Install.Preflight == nil {
-			ext.Spec.Install.Preflight = &olmv1.PreflightConfig{}
+			ext.Spec.Install.Preflight = &ocv1.PreflightConfig{}
 		}
 		if ext.Spec.Install.Preflight.CRDUpgradeSafety == nil {
-			ext.Spec.Install.Preflight.CRDUpgradeSafety = &olmv1.CRDUpgradeSafetyPreflightConfig{}
+			ext.Spec.Install.Preflight.CRDUpgradeSafety = &ocv1.CRDUpgradeSafetyPreflightConfig{}
 		}
-		ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement = olmv1.CRDUpgradeSafetyEnforcement(policy)
+		ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement = ocv1.CRDUpgradeSafetyEnforcement(policy)
 	}
 }
 
-func withCatalogSourceType(sourceType olmv1.SourceType) catalogOpt {
-	return func(catalog *olmv1.ClusterCatalog) {
+func withCatalogSourceType(sourceType ocv1.SourceType) catalogOpt {
+	return func(catalog *ocv1.ClusterCatalog) {
 		catalog.Spec.Source.Type = sourceType
 	}
 }
 
 func withCatalogSourcePriority(priority *int32) catalogOpt {
-	return func(catalog *olmv1.ClusterCatalog) {
+	return func(catalog *ocv1.ClusterCatalog) {
 		catalog.Spec.Priority = *priority
 	}
 }
 
 func withCatalogPollInterval(pollInterval *int) catalogOpt {
-	return func(catalog *olmv1.ClusterCatalog) {
+	return func(catalog *ocv1.ClusterCatalog) {
 		if catalog.Spec.Source.Image == nil {
-			catalog.Spec.Source.Image = &olmv1.ImageSource{}
+			catalog.Spec.Source.Image = &ocv1.ImageSource{}
 		}
 		catalog.Spec.Source.Image.PollIntervalMinutes = pollInterval
 	}
 }
 
 func withCatalogImageRef(ref string) catalogOpt {
-	return func(catalog *olmv1.ClusterCatalog) {
+	return func(catalog *ocv1.ClusterCatalog) {
 		if catalog.Spec.Source.Image == nil {
-			catalog.Spec.Source.Image = &olmv1.ImageSource{}
+			catalog.Spec.Source.Image = &ocv1.ImageSource{}
 		}
 		catalog.Spec.Source.Image.Ref = ref
 	}
 }
 
-func withCatalogAvailabilityMode(mode olmv1.AvailabilityMode) catalogOpt {
-	return func(catalog *olmv1.ClusterCatalog) {
+func withCatalogAvailabilityMode(mode ocv1.AvailabilityMode) catalogOpt {
+	return func(catalog *ocv1.ClusterCatalog) {
 		catalog.Spec.AvailabilityMode = mode
 	}
 }
 
 func withCatalogLabels(labels map[string]string) catalogOpt {
-	return func(catalog *olmv1.ClusterCatalog) {
+	return func(catalog *ocv1.ClusterCatalog) {
 		catalog.Labels = labels
 	}
 }
 
-func buildExtension(packageName string, opts ...extensionOpt) *olmv1.ClusterExtension {
-	ext := &olmv1.ClusterExtension{
-		Spec: olmv1.ClusterExtensionSpec{
-			Source: olmv1.SourceConfig{
-				Catalog: &olmv1.CatalogFilter{PackageName: packageName},
+func buildExtension(packageName string, opts ...extensionOpt) *ocv1.ClusterExtension {
+	ext := &ocv1.ClusterExtension{
+		Spec: ocv1.ClusterExtensionSpec{
+			Source: ocv1.SourceConfig{
+				Catalog: &ocv1.CatalogFilter{PackageName: packageName},
 			},
 		},
 	}
@@ -217,7 +217,7 @@ func buildExtension(packageName string, opts ...extensionOpt) *olmv1.ClusterExte
 }
 
 func updateExtensionConditionStatus(name string, cl client.Client, typ string, status metav1.ConditionStatus) error {
-	var ext olmv1.ClusterExtension
+	var ext ocv1.ClusterExtension
 	key := types.NamespacedName{Name: name}
 
 	if err := cl.Get(context.TODO(), key, &ext); err != nil {
@@ -233,14 +233,14 @@ func updateExtensionConditionStatus(name string, cl client.Client, typ string, s
 	return cl.Update(context.TODO(), &ext)
 }
 
-func buildCatalog(catalogName string, opts ...catalogOpt) *olmv1.ClusterCatalog {
-	catalog := &olmv1.ClusterCatalog{
+func buildCatalog(catalogName string, opts ...catalogOpt) *ocv1.ClusterCatalog {
+	catalog := &ocv1.ClusterCatalog{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: catalogName,
 		},
-		Spec: olmv1.ClusterCatalogSpec{
-			Source: olmv1.CatalogSource{
-				Type: olmv1.SourceTypeImage,
+		Spec: ocv1.ClusterCatalogSpec{
+			Source: ocv1.CatalogSource{
+				Type: ocv1.SourceTypeImage,
 			},
 		},
 	}
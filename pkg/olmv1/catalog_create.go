@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
@@ -7,11 +7,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+// CatalogCreate creates a ClusterCatalog and waits until it reports the Serving condition
+// that matches its availability mode. The catalog is deleted again if it does not.
 type CatalogCreate struct {
 	config      *action.Configuration
 	CatalogName string
@@ -20,22 +22,24 @@ type CatalogCreate struct {
 	Priority            int32
 	PollIntervalMinutes int
 	Labels              map[string]string
-	AvailabilityMode    string
+	AvailabilityMode    ocv1.AvailabilityMode
 	CleanupTimeout      time.Duration
 
-	DryRun string
-	Output string
+	DryRun DryRunMode
 	Logf   func(string, ...interface{})
+	// Progress receives the steps the action waits for. It discards them by default.
+	Progress ProgressReporter
 }
 
 func NewCatalogCreate(config *action.Configuration) *CatalogCreate {
 	return &CatalogCreate{
-		config: config,
-		Logf:   func(string, ...interface{}) {},
+		config:   config,
+		Logf:     func(string, ...interface{}) {},
+		Progress: nopProgress,
 	}
 }
 
-func (i *CatalogCreate) Run(ctx context.Context) (*olmv1.ClusterCatalog, error) {
+func (i *CatalogCreate) Run(ctx context.Context) (*ocv1.ClusterCatalog, error) {
 	catalog := i.buildCatalog()
 	if i.DryRun == DryRunAll {
 		if err := i.config.Client.Create(ctx, &catalog, client.DryRunAll); err != nil {
@@ -48,14 +52,15 @@ func (i *CatalogCreate) Run(ctx context.Context) (*olmv1.ClusterCatalog, error)
 	}
 
 	var err error
-	if i.AvailabilityMode == string(olmv1.AvailabilityModeAvailable) {
-		err = waitUntilCatalogStatusCondition(ctx, i.config.Client, &catalog, olmv1.TypeServing, metav1.ConditionTrue)
+	if catalog.Spec.AvailabilityMode == ocv1.AvailabilityModeAvailable {
+		err = waitUntilCatalogStatusCondition(ctx, i.config.Client, &catalog, ocv1.TypeServing, metav1.ConditionTrue, i.Progress)
 	} else {
-		err = waitUntilCatalogStatusCondition(ctx, i.config.Client, &catalog, olmv1.TypeServing, metav1.ConditionFalse)
+		err = waitUntilCatalogStatusCondition(ctx, i.config.Client, &catalog, ocv1.TypeServing, metav1.ConditionFalse, i.Progress)
 	}
 
 	if err != nil {
-		if cleanupErr := deleteWithTimeout(i.config.Client, &catalog, i.CleanupTimeout); cleanupErr != nil {
+		catalog.SetGroupVersionKind(ocv1.GroupVersion.WithKind("ClusterCatalog"))
+		if cleanupErr := deleteWithTimeout(ctx, i.config.Client, &catalog, i.CleanupTimeout, i.Progress); cleanupErr != nil {
 			i.Logf("cleaning up failed catalog: %v", cleanupErr)
 		}
 		return nil, err
@@ -64,25 +69,25 @@ func (i *CatalogCreate) Run(ctx context.Context) (*olmv1.ClusterCatalog, error)
 	return &catalog, nil
 }
 
-func (i *CatalogCreate) buildCatalog() olmv1.ClusterCatalog {
-	catalog := olmv1.ClusterCatalog{
+func (i *CatalogCreate) buildCatalog() ocv1.ClusterCatalog {
+	catalog := ocv1.ClusterCatalog{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   i.CatalogName,
 			Labels: i.Labels,
 		},
-		Spec: olmv1.ClusterCatalogSpec{
-			Source: olmv1.CatalogSource{
-				Type: olmv1.SourceTypeImage,
-				Image: &olmv1.ImageSource{
+		Spec: ocv1.ClusterCatalogSpec{
+			Source: ocv1.CatalogSource{
+				Type: ocv1.SourceTypeImage,
+				Image: &ocv1.ImageSource{
 					Ref: i.ImageSourceRef,
 				},
 			},
 			Priority:         i.Priority,
-			AvailabilityMode: olmv1.AvailabilityModeAvailable,
+			AvailabilityMode: ocv1.AvailabilityModeAvailable,
 		},
 	}
 	if len(i.AvailabilityMode) != 0 {
-		catalog.Spec.AvailabilityMode = olmv1.AvailabilityMode(i.AvailabilityMode)
+		catalog.Spec.AvailabilityMode = i.AvailabilityMode
 	}
 	if i.PollIntervalMinutes > 0 {
 		catalog.Spec.Source.Image.PollIntervalMinutes = &i.PollIntervalMinutes
@@ -1,8 +1,9 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -11,30 +12,30 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 var _ = Describe("CatalogCreate", func() {
 	catalogName := "testcatalog"
 	pollInterval := 20
-	expectedCatalog := olmv1.ClusterCatalog{
+	expectedCatalog := ocv1.ClusterCatalog{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   catalogName,
 			Labels: map[string]string{"a": "b"},
 		},
-		Spec: olmv1.ClusterCatalogSpec{
-			Source: olmv1.CatalogSource{
-				Type: olmv1.SourceTypeImage,
-				Image: &olmv1.ImageSource{
+		Spec: ocv1.ClusterCatalogSpec{
+			Source: ocv1.CatalogSource{
+				Type: ocv1.SourceTypeImage,
+				Image: &ocv1.ImageSource{
 					Ref:                 "testcatalog:latest",
 					PollIntervalMinutes: &pollInterval,
 				},
 			},
 			Priority:         77,
-			AvailabilityMode: olmv1.AvailabilityModeAvailable,
+			AvailabilityMode: ocv1.AvailabilityModeAvailable,
 		},
 	}
 
@@ -43,8 +44,8 @@ var _ = Describe("CatalogCreate", func() {
 		testClient := fakeClient{createErr: expectedErr}
 		Expect(testClient.Initialize()).To(Succeed())
 
-		creator := internalaction.NewCatalogCreate(&action.Configuration{Client: testClient})
-		creator.AvailabilityMode = string(olmv1.AvailabilityModeAvailable)
+		creator := olmv1.NewCatalogCreate(&action.Configuration{Client: testClient})
+		creator.AvailabilityMode = ocv1.AvailabilityModeAvailable
 		creator.CatalogName = expectedCatalog.Name
 		creator.ImageSourceRef = expectedCatalog.Spec.Source.Image.Ref
 		creator.Priority = expectedCatalog.Spec.Priority
@@ -62,7 +63,13 @@ var _ = Describe("CatalogCreate", func() {
 		testClient := fakeClient{getErr: expectedErr}
 		Expect(testClient.Initialize()).To(Succeed())
 
-		creator := internalaction.NewCatalogCreate(&action.Configuration{Client: testClient})
+		var phases []olmv1.Phase
+		creator := olmv1.NewCatalogCreate(&action.Configuration{Client: testClient})
+		creator.Progress = olmv1.ProgressFunc(func(e olmv1.Event) {
+			Expect(e.Kind).To(Equal("ClusterCatalog"))
+			Expect(e.Name).To(Equal(catalogName))
+			phases = append(phases, e.Phase)
+		})
 		// fakeClient requires at least the catalogName to be set to run
 		creator.CatalogName = expectedCatalog.Name
 		_, err := creator.Run(context.TODO())
@@ -72,6 +79,8 @@ var _ = Describe("CatalogCreate", func() {
 		Expect(testClient.createCalled).To(Equal(1))
 		Expect(testClient.getCalled).To(Equal(1))
 		Expect(testClient.deleteCalled).To(Equal(1))
+		// waiting for the catalog fails, deleting it succeeds
+		Expect(phases).To(Equal([]olmv1.Phase{olmv1.PhaseStarted, olmv1.PhaseFailed, olmv1.PhaseStarted, olmv1.PhaseSucceeded}))
 	})
 
 	It("fails waiting for created catalog status, fails clean up", func() {
@@ -80,7 +89,7 @@ var _ = Describe("CatalogCreate", func() {
 		testClient := fakeClient{deleteErr: deleteErr, getErr: getErr}
 		Expect(testClient.Initialize()).To(Succeed())
 
-		creator := internalaction.NewCatalogCreate(&action.Configuration{Client: testClient})
+		creator := olmv1.NewCatalogCreate(&action.Configuration{Client: testClient})
 		// fakeClient requires at least the catalogName to be set to run
 		creator.CatalogName = expectedCatalog.Name
 		_, err := creator.Run(context.TODO())
@@ -101,19 +110,19 @@ var _ = Describe("CatalogCreate", func() {
 						if obj == nil {
 							return
 						}
-						catalogObj, ok := (*obj).(*olmv1.ClusterCatalog)
+						catalogObj, ok := (*obj).(*ocv1.ClusterCatalog)
 						if !ok {
 							return
 						}
-						catalogObj.Status.Conditions = []metav1.Condition{{Type: olmv1.TypeServing, Status: metav1.ConditionTrue}}
+						catalogObj.Status.Conditions = []metav1.Condition{{Type: ocv1.TypeServing, Status: metav1.ConditionTrue}}
 					},
 				},
 			},
 		}
 		Expect(testClient.Initialize()).To(Succeed())
 
-		creator := internalaction.NewCatalogCreate(&action.Configuration{Client: testClient})
-		creator.AvailabilityMode = string(olmv1.AvailabilityModeAvailable)
+		creator := olmv1.NewCatalogCreate(&action.Configuration{Client: testClient})
+		creator.AvailabilityMode = ocv1.AvailabilityModeAvailable
 		creator.CatalogName = expectedCatalog.Name
 		creator.ImageSourceRef = expectedCatalog.Spec.Source.Image.Ref
 		creator.Priority = expectedCatalog.Spec.Priority
@@ -124,13 +133,44 @@ var _ = Describe("CatalogCreate", func() {
 
 		Expect(testClient.createCalled).To(Equal(1))
 
-		actualCatalog := &olmv1.ClusterCatalog{TypeMeta: metav1.TypeMeta{Kind: "ClusterCatalog", APIVersion: olmv1.GroupVersion.String()}}
+		actualCatalog := &ocv1.ClusterCatalog{TypeMeta: metav1.TypeMeta{Kind: "ClusterCatalog", APIVersion: ocv1.GroupVersion.String()}}
 		Expect(testClient.Client.Get(context.TODO(), types.NamespacedName{Name: catalogName}, actualCatalog)).To(Succeed())
 		validateCreateCatalog(actualCatalog, &expectedCatalog)
 	})
+
+	It("waits for a catalog created without an availability mode to serve", func() {
+		testClient := fakeClient{
+			transformers: []objectTransformer{
+				{
+					verb:      verbCreate,
+					objectKey: types.NamespacedName{Name: catalogName},
+					transformFunc: func(obj *client.Object) {
+						if obj == nil {
+							return
+						}
+						catalogObj, ok := (*obj).(*ocv1.ClusterCatalog)
+						if !ok {
+							return
+						}
+						catalogObj.Status.Conditions = []metav1.Condition{{Type: ocv1.TypeServing, Status: metav1.ConditionTrue}}
+					},
+				},
+			},
+		}
+		Expect(testClient.Initialize()).To(Succeed())
+
+		creator := olmv1.NewCatalogCreate(&action.Configuration{Client: testClient})
+		creator.CatalogName = expectedCatalog.Name
+		creator.ImageSourceRef = expectedCatalog.Spec.Source.Image.Ref
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+		catalog, err := creator.Run(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(catalog.Spec.AvailabilityMode).To(Equal(ocv1.AvailabilityModeAvailable))
+	})
 })
 
-func validateCreateCatalog(actual, expected *olmv1.ClusterCatalog) {
+func validateCreateCatalog(actual, expected *ocv1.ClusterCatalog) {
 	Expect(actual.Spec.Source.Image.Ref).To(Equal(expected.Spec.Source.Image.Ref))
 	Expect(actual.Spec.Source.Image.PollIntervalMinutes).To(Equal(expected.Spec.Source.Image.PollIntervalMinutes))
 	Expect(actual.Spec.AvailabilityMode).To(Equal(expected.Spec.AvailabilityMode))
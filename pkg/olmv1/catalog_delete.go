@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
@@ -7,33 +7,36 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+// CatalogDelete deletes a ClusterCatalog, or all ClusterCatalogs, and waits until they are gone.
 type CatalogDelete struct {
 	config      *action.Configuration
 	CatalogName string
 
 	DeleteAll bool
 
-	DryRun string
-	Output string
+	DryRun DryRunMode
 	Logf   func(string, ...interface{})
+	// Progress receives the steps the action waits for. It discards them by default.
+	Progress ProgressReporter
 }
 
 func NewCatalogDelete(cfg *action.Configuration) *CatalogDelete {
 	return &CatalogDelete{
-		config: cfg,
-		Logf:   func(string, ...interface{}) {},
+		config:   cfg,
+		Logf:     func(string, ...interface{}) {},
+		Progress: nopProgress,
 	}
 }
 
-func (i *CatalogDelete) Run(ctx context.Context) ([]olmv1.ClusterCatalog, error) {
+func (i *CatalogDelete) Run(ctx context.Context) ([]ocv1.ClusterCatalog, error) {
 	// validate
 	if i.DeleteAll && i.CatalogName != "" {
-		return nil, ErrNameAndSelector
+		return nil, ErrNameAndDeleteAll
 	}
 
 	// delete single, specified catalog
@@ -42,11 +45,11 @@ func (i *CatalogDelete) Run(ctx context.Context) ([]olmv1.ClusterCatalog, error)
 		if err != nil {
 			return nil, err
 		}
-		return []olmv1.ClusterCatalog{obj}, nil
+		return []ocv1.ClusterCatalog{obj}, nil
 	}
 
 	// delete all existing catalogs
-	var catatalogList olmv1.ClusterCatalogList
+	var catatalogList ocv1.ClusterCatalogList
 	if err := i.config.Client.List(ctx, &catatalogList); err != nil {
 		return nil, err
 	}
@@ -55,7 +58,7 @@ func (i *CatalogDelete) Run(ctx context.Context) ([]olmv1.ClusterCatalog, error)
 	}
 
 	errs := make([]error, 0, len(catatalogList.Items))
-	result := []olmv1.ClusterCatalog{}
+	result := []ocv1.ClusterCatalog{}
 	for _, catalog := range catatalogList.Items {
 		if obj, err := i.deleteCatalog(ctx, catalog.Name); err != nil {
 			errs = append(errs, fmt.Errorf("failed deleting catalog %q: %w", catalog.Name, err))
@@ -67,9 +70,10 @@ func (i *CatalogDelete) Run(ctx context.Context) ([]olmv1.ClusterCatalog, error)
 	return result, errors.Join(errs...)
 }
 
-func (i *CatalogDelete) deleteCatalog(ctx context.Context, name string) (olmv1.ClusterCatalog, error) {
-	op := &olmv1.ClusterCatalog{}
+func (i *CatalogDelete) deleteCatalog(ctx context.Context, name string) (ocv1.ClusterCatalog, error) {
+	op := &ocv1.ClusterCatalog{}
 	op.SetName(name)
+	op.SetGroupVersionKind(ocv1.GroupVersion.WithKind("ClusterCatalog"))
 
 	if i.DryRun == DryRunAll {
 		err := i.config.Client.Delete(ctx, op, client.DryRunAll)
@@ -79,5 +83,5 @@ func (i *CatalogDelete) deleteCatalog(ctx context.Context, name string) (olmv1.C
 		return *op, err
 	}
 
-	return *op, waitForDeletion(ctx, i.config.Client, op)
+	return *op, waitForDeletion(ctx, i.config.Client, i.Progress, op)
 }
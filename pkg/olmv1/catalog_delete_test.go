@@ -1,4 +1,4 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
@@ -10,10 +10,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 var _ = Describe("CatalogDelete", func() {
@@ -36,7 +36,7 @@ var _ = Describe("CatalogDelete", func() {
 	It("fails because of both resource name and --all specifier being present", func() {
 		cfg := setupEnv(setupTestCatalogs(2)...)
 
-		deleter := internalaction.NewCatalogDelete(&cfg)
+		deleter := olmv1.NewCatalogDelete(&cfg)
 		deleter.CatalogName = "name"
 		deleter.DeleteAll = true
 		catalogs, err := deleter.Run(context.TODO())
@@ -49,7 +49,7 @@ var _ = Describe("CatalogDelete", func() {
 	It("fails deleting a non-existing catalog", func() {
 		cfg := setupEnv(setupTestCatalogs(2)...)
 
-		deleter := internalaction.NewCatalogDelete(&cfg)
+		deleter := olmv1.NewCatalogDelete(&cfg)
 		deleter.CatalogName = "does-not-exist"
 		catalogs, err := deleter.Run(context.TODO())
 		Expect(err).NotTo(BeNil())
@@ -61,7 +61,7 @@ var _ = Describe("CatalogDelete", func() {
 	It("successfully deletes an existing catalog", func() {
 		cfg := setupEnv(setupTestCatalogs(3)...)
 
-		deleter := internalaction.NewCatalogDelete(&cfg)
+		deleter := olmv1.NewCatalogDelete(&cfg)
 		deleter.CatalogName = "cat2"
 		catalogs, err := deleter.Run(context.TODO())
 		Expect(err).To(BeNil())
@@ -74,7 +74,7 @@ var _ = Describe("CatalogDelete", func() {
 	It("fails deleting catalogs because there are none", func() {
 		cfg := setupEnv()
 
-		deleter := internalaction.NewCatalogDelete(&cfg)
+		deleter := olmv1.NewCatalogDelete(&cfg)
 		deleter.DeleteAll = true
 		catalogs, err := deleter.Run(context.TODO())
 		Expect(err).NotTo(BeNil())
@@ -86,7 +86,7 @@ var _ = Describe("CatalogDelete", func() {
 	It("successfully deletes all catalogs", func() {
 		cfg := setupEnv(setupTestCatalogs(3)...)
 
-		deleter := internalaction.NewCatalogDelete(&cfg)
+		deleter := olmv1.NewCatalogDelete(&cfg)
 		deleter.DeleteAll = true
 		catalogs, err := deleter.Run(context.TODO())
 		Expect(err).To(BeNil())
@@ -97,7 +97,7 @@ var _ = Describe("CatalogDelete", func() {
 })
 
 func validateExistingCatalogs(c client.Client, wantedNames []string) {
-	var catalogsList olmv1.ClusterCatalogList
+	var catalogsList ocv1.ClusterCatalogList
 	err := c.List(context.TODO(), &catalogsList)
 	Expect(err).To(BeNil())
 
@@ -106,9 +106,9 @@ func validateExistingCatalogs(c client.Client, wantedNames []string) {
 	validateCatalogList(catalogs, wantedNames)
 }
 
-func validateCatalogList(catalogs []olmv1.ClusterCatalog, wantedNames []string) {
+func validateCatalogList(catalogs []ocv1.ClusterCatalog, wantedNames []string) {
 	for _, wantedName := range wantedNames {
-		Expect(slices.ContainsFunc(catalogs, func(cat olmv1.ClusterCatalog) bool {
+		Expect(slices.ContainsFunc(catalogs, func(cat ocv1.ClusterCatalog) bool {
 			return cat.Name == wantedName
 		})).To(BeTrue())
 	}
@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
@@ -7,11 +7,12 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+// CatalogInstalledGet gets a ClusterCatalog by name, or lists ClusterCatalogs matching Selector.
 type CatalogInstalledGet struct {
 	config      *action.Configuration
 	CatalogName string
@@ -28,10 +29,10 @@ func NewCatalogInstalledGet(cfg *action.Configuration) *CatalogInstalledGet {
 	}
 }
 
-func (i *CatalogInstalledGet) Run(ctx context.Context) ([]olmv1.ClusterCatalog, error) {
+func (i *CatalogInstalledGet) Run(ctx context.Context) ([]ocv1.ClusterCatalog, error) {
 	// get
 	if i.CatalogName != "" {
-		var result olmv1.ClusterCatalog
+		var result ocv1.ClusterCatalog
 
 		opKey := types.NamespacedName{Name: i.CatalogName}
 		err := i.config.Client.Get(ctx, opKey, &result)
@@ -39,11 +40,11 @@ func (i *CatalogInstalledGet) Run(ctx context.Context) ([]olmv1.ClusterCatalog,
 			return nil, err
 		}
 
-		return []olmv1.ClusterCatalog{result}, nil
+		return []ocv1.ClusterCatalog{result}, nil
 	}
 
 	// list
-	var result olmv1.ClusterCatalogList
+	var result ocv1.ClusterCatalogList
 	listOpts := &client.ListOptions{}
 	if i.Selector != nil {
 		listOpts.LabelSelector = i.Selector
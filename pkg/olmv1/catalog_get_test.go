@@ -1,4 +1,4 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
@@ -11,10 +11,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 var _ = Describe("CatalogInstalledGet", func() {
@@ -37,14 +37,14 @@ var _ = Describe("CatalogInstalledGet", func() {
 	It("lists all installed catalogs", func() {
 		cfg := setupEnv(setupTestCatalogs(3)...)
 
-		getter := internalaction.NewCatalogInstalledGet(&cfg)
+		getter := olmv1.NewCatalogInstalledGet(&cfg)
 		catalogs, err := getter.Run(context.TODO())
 		Expect(err).To(BeNil())
 		Expect(catalogs).NotTo(BeEmpty())
 		Expect(catalogs).To(HaveLen(3))
 
 		for _, testCatalogName := range []string{"cat1", "cat2", "cat3"} {
-			Expect(slices.ContainsFunc(catalogs, func(cat olmv1.ClusterCatalog) bool {
+			Expect(slices.ContainsFunc(catalogs, func(cat ocv1.ClusterCatalog) bool {
 				return cat.Name == testCatalogName
 			})).To(BeTrue())
 		}
@@ -53,7 +53,7 @@ var _ = Describe("CatalogInstalledGet", func() {
 	It("returns empty list in case no catalogs were found", func() {
 		cfg := setupEnv()
 
-		getter := internalaction.NewCatalogInstalledGet(&cfg)
+		getter := olmv1.NewCatalogInstalledGet(&cfg)
 		catalogs, err := getter.Run(context.TODO())
 		Expect(err).To(BeNil())
 		Expect(catalogs).To(BeEmpty())
@@ -62,7 +62,7 @@ var _ = Describe("CatalogInstalledGet", func() {
 	It("gets an installed catalog", func() {
 		cfg := setupEnv(setupTestCatalogs(3)...)
 
-		getter := internalaction.NewCatalogInstalledGet(&cfg)
+		getter := olmv1.NewCatalogInstalledGet(&cfg)
 		getter.CatalogName = "cat2"
 		catalogs, err := getter.Run(context.TODO())
 		Expect(err).To(BeNil())
@@ -74,7 +74,7 @@ var _ = Describe("CatalogInstalledGet", func() {
 	It("returns an empty list when an installed catalog was not found", func() {
 		cfg := setupEnv()
 
-		getter := internalaction.NewCatalogInstalledGet(&cfg)
+		getter := olmv1.NewCatalogInstalledGet(&cfg)
 		getter.CatalogName = "cat2"
 		catalogs, err := getter.Run(context.TODO())
 		Expect(err).NotTo(BeNil())
@@ -93,7 +93,7 @@ var _ = Describe("CatalogInstalledGet", func() {
 
 		cfg := setupEnv(initCatalogs...)
 
-		getter := internalaction.NewCatalogInstalledGet(&cfg)
+		getter := olmv1.NewCatalogInstalledGet(&cfg)
 		var err error
 		getter.Selector, err = labels.Parse("foo=bar")
 		Expect(err).To(BeNil())
@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
@@ -8,23 +8,28 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 
-	catalogClient "github.com/operator-framework/kubectl-operator/internal/pkg/v1/client"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1/catalogd"
 )
 
+// CatalogSearch fetches the contents of serving ClusterCatalogs, optionally restricted to a
+// single package.
 type CatalogSearch struct {
 	config      *action.Configuration
 	CatalogName string
 
 	Selector          labels.Selector
-	ListVersions      bool
 	Package           string
 	CatalogdNamespace string
-	Timeout           string
+	// Timeout bounds each request made to fetch catalog contents. Zero means no timeout.
+	Timeout time.Duration
+	// Catalogd fetches the catalog contents. If nil, catalogd is reached by port forwarding.
+	Catalogd catalogd.Client
 
 	Logf func(string, ...interface{})
 }
@@ -37,14 +42,7 @@ func NewCatalogSearch(cfg *action.Configuration) *CatalogSearch {
 }
 
 func (i *CatalogSearch) Run(ctx context.Context) (map[string]*declcfg.DeclarativeConfig, error) {
-	if len(i.Timeout) > 0 {
-		catalogListTimeout, err := time.ParseDuration(i.Timeout)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse timeout %q: %w", i.Timeout, err)
-		}
-		i.config.Config.Timeout = catalogListTimeout
-	}
-	var catalogList []olmv1.ClusterCatalog
+	var catalogList []ocv1.ClusterCatalog
 	listCmd := NewCatalogInstalledGet(i.config)
 	listCmd.Selector = i.Selector
 	listCmd.CatalogName = i.CatalogName
@@ -62,11 +60,17 @@ func (i *CatalogSearch) Run(ctx context.Context) (map[string]*declcfg.Declarativ
 			return nil, fmt.Errorf("failed to query for catalog contents: catalog(s) unhealthy")
 		}
 		if i.Selector != nil {
-			return nil, fmt.Errorf("no serving catalogs matching label selector %q found", i.Selector)
+			return nil, fmt.Errorf("%w matching label selector %q", ErrNoServingCatalogs, i.Selector)
 		}
-		return nil, fmt.Errorf("no serving catalogs found")
+		return nil, ErrNoServingCatalogs
+	}
+	catalogdClient := i.Catalogd
+	if catalogdClient == nil {
+		restConfig := rest.CopyConfig(i.config.Config)
+		restConfig.Timeout = i.Timeout
+		catalogdClient = catalogd.NewK8sClient(restConfig, i.config.Client, i.CatalogdNamespace)
 	}
-	searchClientV1 := catalogClient.NewK8sClient(i.config.Config, i.config.Client, i.CatalogdNamespace).V1()
+	searchClientV1 := catalogdClient.V1()
 	catalogDeclCfg := map[string]*declcfg.DeclarativeConfig{}
 	foundPackage := len(i.Package) == 0 // whether to check for empty package query
 	for _, c := range catalogList {
@@ -93,22 +97,16 @@ func (i *CatalogSearch) Run(ctx context.Context) (map[string]*declcfg.Declarativ
 	}
 	if !foundPackage {
 		// package name was specified and query was empty across all available catalogs.
-		if len(i.CatalogName) != 0 {
-			return nil, fmt.Errorf("package %q was not found in ClusterCatalog %q", i.Package, i.CatalogName)
-		}
-		if i.Selector != nil {
-			return nil, fmt.Errorf("package %q was not found in ClusterCatalogs matching label %q", i.Package, i.Selector)
-		}
-		return nil, fmt.Errorf("package %q was not found in any serving ClusterCatalog", i.Package)
+		return nil, &PackageNotFoundError{Package: i.Package, Catalog: i.CatalogName, Selector: i.Selector}
 	}
 	return catalogDeclCfg, nil
 }
 
-func isCatalogServing(c olmv1.ClusterCatalog) bool {
-	if c.Spec.AvailabilityMode != olmv1.AvailabilityModeAvailable {
+func isCatalogServing(c ocv1.ClusterCatalog) bool {
+	if c.Spec.AvailabilityMode != ocv1.AvailabilityModeAvailable {
 		return false
 	}
-	if !meta.IsStatusConditionPresentAndEqual(c.Status.Conditions, olmv1.TypeServing, metav1.ConditionTrue) {
+	if !meta.IsStatusConditionPresentAndEqual(c.Status.Conditions, ocv1.TypeServing, metav1.ConditionTrue) {
 		return false
 	}
 	if c.Status.ResolvedSource == nil || c.Status.ResolvedSource.Image == nil {
@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
@@ -8,11 +8,12 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+// CatalogUpdate updates the spec and labels of a ClusterCatalog.
 type CatalogUpdate struct {
 	config      *action.Configuration
 	CatalogName string
@@ -20,12 +21,11 @@ type CatalogUpdate struct {
 	Priority            *int32
 	PollIntervalMinutes *int
 	Labels              map[string]string
-	AvailabilityMode    string
+	AvailabilityMode    ocv1.AvailabilityMode
 	ImageRef            string
 	IgnoreUnset         bool
 
-	DryRun string
-	Output string
+	DryRun DryRunMode
 	Logf   func(string, ...interface{})
 }
 
@@ -36,8 +36,8 @@ func NewCatalogUpdate(config *action.Configuration) *CatalogUpdate {
 	}
 }
 
-func (i *CatalogUpdate) Run(ctx context.Context) (*olmv1.ClusterCatalog, error) {
-	var catalog olmv1.ClusterCatalog
+func (i *CatalogUpdate) Run(ctx context.Context) (*ocv1.ClusterCatalog, error) {
+	var catalog ocv1.ClusterCatalog
 	var err error
 
 	cuKey := types.NamespacedName{
@@ -48,7 +48,7 @@ func (i *CatalogUpdate) Run(ctx context.Context) (*olmv1.ClusterCatalog, error)
 		return nil, err
 	}
 
-	if catalog.Spec.Source.Type != olmv1.SourceTypeImage {
+	if catalog.Spec.Source.Type != ocv1.SourceTypeImage {
 		return nil, fmt.Errorf("unrecognized source type: %q", catalog.Spec.Source.Type)
 	}
 
@@ -75,7 +75,7 @@ func (i *CatalogUpdate) Run(ctx context.Context) (*olmv1.ClusterCatalog, error)
 	return &catalog, nil
 }
 
-func (i *CatalogUpdate) setUpdatedCatalog(catalog *olmv1.ClusterCatalog) {
+func (i *CatalogUpdate) setUpdatedCatalog(catalog *ocv1.ClusterCatalog) {
 	existingLabels := catalog.GetLabels()
 	if existingLabels == nil {
 		existingLabels = make(map[string]string)
@@ -96,7 +96,7 @@ func (i *CatalogUpdate) setUpdatedCatalog(catalog *olmv1.ClusterCatalog) {
 	}
 
 	if catalog.Spec.Source.Image == nil {
-		catalog.Spec.Source.Image = &olmv1.ImageSource{}
+		catalog.Spec.Source.Image = &ocv1.ImageSource{}
 	}
 
 	if i.PollIntervalMinutes != nil {
@@ -111,10 +111,10 @@ func (i *CatalogUpdate) setUpdatedCatalog(catalog *olmv1.ClusterCatalog) {
 		catalog.Spec.Source.Image.Ref = i.ImageRef
 	}
 
-	catalog.Spec.AvailabilityMode = olmv1.AvailabilityMode(i.AvailabilityMode)
+	catalog.Spec.AvailabilityMode = i.AvailabilityMode
 }
 
-func (i *CatalogUpdate) setDefaults(catalog *olmv1.ClusterCatalog) {
+func (i *CatalogUpdate) setDefaults(catalog *ocv1.ClusterCatalog) {
 	if !i.IgnoreUnset {
 		return
 	}
@@ -133,7 +133,7 @@ func (i *CatalogUpdate) setDefaults(catalog *olmv1.ClusterCatalog) {
 		i.ImageRef = catalogSrc.Image.Ref
 	}
 	if i.AvailabilityMode == "" {
-		i.AvailabilityMode = string(catalog.Spec.AvailabilityMode)
+		i.AvailabilityMode = catalog.Spec.AvailabilityMode
 	}
 	if len(i.Labels) == 0 {
 		i.Labels = catalog.Labels
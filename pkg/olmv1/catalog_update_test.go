@@ -1,4 +1,4 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
@@ -9,10 +9,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 var _ = Describe("CatalogUpdate", func() {
@@ -35,7 +35,7 @@ var _ = Describe("CatalogUpdate", func() {
 	It("fails finding existing catalog", func() {
 		cfg := setupEnv()
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "does-not-exist"
 		cat, err := updater.Run(context.TODO())
 
@@ -47,7 +47,7 @@ var _ = Describe("CatalogUpdate", func() {
 	It("fails to handle catalog with unknown source type", func() {
 		cfg := setupEnv(buildCatalog("test", withCatalogSourceType("invalid-type")))
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		_, err := updater.Run(context.TODO())
 
@@ -58,20 +58,20 @@ var _ = Describe("CatalogUpdate", func() {
 	It("successfully updates catalog", func() {
 		testCatalog := buildCatalog(
 			"testCatalog",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogPollInterval(pointerToInt(5)),
 			withCatalogSourcePriority(pointerToInt32(1)),
 			withCatalogImageRef("quay.io/myrepo/myimage"),
-			withCatalogAvailabilityMode(olmv1.AvailabilityModeAvailable),
+			withCatalogAvailabilityMode(ocv1.AvailabilityModeAvailable),
 			withCatalogLabels(map[string]string{"foo": "bar"}),
 		)
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "testCatalog"
 		updater.Priority = pointerToInt32(1)
 		updater.Labels = map[string]string{"abc": "xyz"}
-		updater.AvailabilityMode = string(olmv1.AvailabilityModeAvailable)
+		updater.AvailabilityMode = ocv1.AvailabilityModeAvailable
 		updater.PollIntervalMinutes = pointerToInt(5)
 		catalog, err := updater.Run(context.TODO())
 
@@ -82,19 +82,19 @@ var _ = Describe("CatalogUpdate", func() {
 		Expect(catalog.Spec.Priority).To(Equal(*updater.Priority))
 		Expect(catalog.Spec.Source.Image.PollIntervalMinutes).ToNot(BeNil())
 		Expect(*catalog.Spec.Source.Image.PollIntervalMinutes).To(Equal(*updater.PollIntervalMinutes))
-		Expect(catalog.Spec.AvailabilityMode).To(Equal(olmv1.AvailabilityMode(updater.AvailabilityMode)))
+		Expect(catalog.Spec.AvailabilityMode).To(Equal(updater.AvailabilityMode))
 	})
 
 	It("unsets the poll interval field when set to 0", func() {
 		testCatalog := buildCatalog(
 			"test",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogPollInterval(pointerToInt(7)),
 			withCatalogImageRef("quay.io/myrepo/myimage"),
 		)
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		updater.PollIntervalMinutes = pointerToInt(-1)
 		catalog, err := updater.Run(context.TODO())
@@ -106,13 +106,13 @@ var _ = Describe("CatalogUpdate", func() {
 	It("unsets the poll interval field when set to 0", func() {
 		testCatalog := buildCatalog(
 			"test",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogPollInterval(pointerToInt(10)),
 			withCatalogImageRef("quay.io/myrepo/myimage"),
 		)
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		updater.PollIntervalMinutes = pointerToInt(0)
 
@@ -125,16 +125,16 @@ var _ = Describe("CatalogUpdate", func() {
 	It("succeessfully updates catalog with a valid image reference", func() {
 		testCatalog := buildCatalog(
 			"test",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogImageRef("quay.io/myrepo/myimage"),
 			withCatalogPollInterval(pointerToInt(10)),
 			withCatalogSourcePriority(pointerToInt32(5)),
-			withCatalogAvailabilityMode(olmv1.AvailabilityModeAvailable),
+			withCatalogAvailabilityMode(ocv1.AvailabilityModeAvailable),
 			withCatalogLabels(map[string]string{"foo": "bar"}),
 		)
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		updater.ImageRef = "quay.io/myrepo/mynewimage"
 		catalog, err := updater.Run(context.TODO())
@@ -146,12 +146,12 @@ var _ = Describe("CatalogUpdate", func() {
 	It("fails catalog update with an invalid image reference", func() {
 		testCatalog := buildCatalog(
 			"test",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogImageRef("quay.io/valid/image"),
 		)
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		updater.ImageRef = "invalid//image!!"
 
@@ -164,12 +164,12 @@ var _ = Describe("CatalogUpdate", func() {
 		initial := map[string]string{"foo": "bar", "remove": "yes"}
 		testCatalog := buildCatalog(
 			"test",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogLabels(initial),
 		)
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		updater.Labels = map[string]string{
 			"remove": "",
@@ -187,12 +187,12 @@ var _ = Describe("CatalogUpdate", func() {
 	It("preserves labels when Labels field is nil", func() {
 		testCatalog := buildCatalog(
 			"test",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogLabels(map[string]string{"retain": "this"}),
 		)
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		updater.Labels = nil
 
@@ -204,7 +204,7 @@ var _ = Describe("CatalogUpdate", func() {
 	It("preserves priority and poll interval when ignoreUnset flag is true and flags not explicitly set", func() {
 		testCatalog := buildCatalog(
 			"test",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogPollInterval(pointerToInt(10)),
 			withCatalogSourcePriority(pointerToInt32(3)),
 			withCatalogImageRef("quay.io/myrepo/image"),
@@ -213,7 +213,7 @@ var _ = Describe("CatalogUpdate", func() {
 
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		updater.IgnoreUnset = true
 
@@ -232,7 +232,7 @@ var _ = Describe("CatalogUpdate", func() {
 	It("resets priority and poll interval when ignoreUnset is false and flags are nil", func() {
 		testCatalog := buildCatalog(
 			"test",
-			withCatalogSourceType(olmv1.SourceTypeImage),
+			withCatalogSourceType(ocv1.SourceTypeImage),
 			withCatalogPollInterval(pointerToInt(10)),
 			withCatalogSourcePriority(pointerToInt32(3)),
 			withCatalogImageRef("quay.io/myrepo/image"),
@@ -240,7 +240,7 @@ var _ = Describe("CatalogUpdate", func() {
 
 		cfg := setupEnv(testCatalog)
 
-		updater := internalaction.NewCatalogUpdate(&cfg)
+		updater := olmv1.NewCatalogUpdate(&cfg)
 		updater.CatalogName = "test"
 		updater.IgnoreUnset = false
 		updater.Priority = nil
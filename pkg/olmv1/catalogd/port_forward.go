@@ -1,4 +1,5 @@
-package client
+// Package catalogd fetches the contents of ClusterCatalogs from the catalogd HTTP API.
+package catalogd
 
 import (
 	"context"
@@ -8,7 +9,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"os"
 	"strconv"
 	"strings"
 
@@ -20,17 +20,22 @@ import (
 	"k8s.io/client-go/transport/spdy"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 )
 
+// Client is a client for the catalogd HTTP API.
 type Client interface {
 	V1() V1Client
 }
 
+// V1Client queries the v1 catalogd API.
 type V1Client interface {
-	All(ctx context.Context, cc *olmv1.ClusterCatalog) (io.ReadCloser, error)
+	// All returns the full file-based catalog contents of a ClusterCatalog. The caller must
+	// close the returned reader.
+	All(ctx context.Context, cc *ocv1.ClusterCatalog) (io.ReadCloser, error)
 }
 
+// LiveClient queries a catalogd server that is directly reachable at BaseURL.
 type LiveClient struct {
 	HTTPClient *http.Client
 	BaseURL    *url.URL
@@ -44,7 +49,7 @@ type LiveClientV1 struct {
 	*LiveClient
 }
 
-func (c *LiveClientV1) All(ctx context.Context, _ *olmv1.ClusterCatalog) (io.ReadCloser, error) {
+func (c *LiveClientV1) All(ctx context.Context, _ *ocv1.ClusterCatalog) (io.ReadCloser, error) {
 	allURL := c.LiveClient.BaseURL.JoinPath("api", "v1", "all").String()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, allURL, nil)
 	if err != nil {
@@ -60,6 +65,9 @@ func (c *LiveClientV1) All(ctx context.Context, _ *olmv1.ClusterCatalog) (io.Rea
 	return resp.Body, nil
 }
 
+// NewK8sClient returns a client that reaches catalogd by port forwarding to one of the pods
+// behind a ClusterCatalog's service. The CA certificates of the catalogd service are read from
+// the catalogd-prefixed secrets in caNamespace.
 func NewK8sClient(cfg *rest.Config, cl client.Client, caNamespace string) Client {
 	c := &portForwardClient{
 		cfg: cfg,
@@ -90,8 +98,8 @@ type portForwardClientV1 struct {
 	*portForwardClient
 }
 
-func (c *portForwardClientV1) All(ctx context.Context, cc *olmv1.ClusterCatalog) (io.ReadCloser, error) {
-	if !meta.IsStatusConditionTrue(cc.Status.Conditions, olmv1.TypeServing) {
+func (c *portForwardClientV1) All(ctx context.Context, cc *ocv1.ClusterCatalog) (io.ReadCloser, error) {
+	if !meta.IsStatusConditionTrue(cc.Status.Conditions, ocv1.TypeServing) {
 		return nil, fmt.Errorf("cluster catalog %q is not serving", cc.Name)
 	}
 	if cc.Status.URLs == nil {
@@ -231,7 +239,7 @@ func (c *portForwardClient) getPortForwarder(namespace, podName string, podPort
 	stopChan := make(chan struct{}, 1)
 	readyChan := make(chan struct{}, 1)
 
-	pf, err := portforward.New(dialer, ports, stopChan, readyChan, io.Discard, os.Stderr)
+	pf, err := portforward.New(dialer, ports, stopChan, readyChan, io.Discard, io.Discard)
 	if err != nil {
 		return nil, err
 	}
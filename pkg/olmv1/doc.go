@@ -0,0 +1,14 @@
+// Package olmv1 manages OLMv1 ClusterExtensions and ClusterCatalogs.
+//
+// Each action is created from an action.Configuration with its New function, configured
+// through its exported fields, and executed with Run. Actions honor the context passed to
+// Run, and wait for the cluster to reflect the change before returning. They do not write to
+// stdout or stderr: messages are passed to the Logf field, and the steps an action waits for
+// are reported as Events to its Progress field. Both discard their input by default; the
+// progress package has reporters for terminals, plain text and JSON lines.
+//
+// Errors are returned as sentinel errors such as ErrNoChange, which is wrapped by every update
+// that would not change anything, as *PackageNotFoundError when a package is not in the
+// searched catalogs, and as *WaitError when an object does not reach the expected state. Errors from the API server are returned unchanged, so they can be
+// inspected with the k8s.io/apimachinery/pkg/api/errors helpers.
+package olmv1
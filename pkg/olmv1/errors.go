@@ -0,0 +1,58 @@
+package olmv1
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var (
+	ErrNoResourcesFound  = errors.New("no resources found")
+	ErrNameAndSelector   = errors.New("name cannot be provided when a selector is specified")
+	ErrNameAndDeleteAll  = errors.New("name cannot be provided when deleting all resources")
+	ErrNoChange          = errors.New("no changes detected")
+	ErrNoServingCatalogs = errors.New("no serving catalogs found")
+)
+
+// PackageNotFoundError is returned when a package is not found in the catalogs that were
+// searched. Catalog and Selector describe the catalogs, if they were restricted.
+type PackageNotFoundError struct {
+	Package  string
+	Catalog  string
+	Selector labels.Selector
+}
+
+func (e *PackageNotFoundError) Error() string {
+	switch {
+	case e.Catalog != "":
+		return fmt.Sprintf("package %q was not found in ClusterCatalog %q", e.Package, e.Catalog)
+	case e.Selector != nil:
+		return fmt.Sprintf("package %q was not found in ClusterCatalogs matching label %q", e.Package, e.Selector)
+	default:
+		return fmt.Sprintf("package %q was not found in any serving ClusterCatalog", e.Package)
+	}
+}
+
+// WaitError is returned when an object does not reach the expected state before the context
+// is done. Message is the last status message reported by the object, if any, and Err is the
+// error that stopped the wait.
+type WaitError struct {
+	Kind    string
+	Name    string
+	State   string
+	Message string
+	Err     error
+}
+
+func (e *WaitError) Error() string {
+	reason := e.Message
+	if reason == "" && e.Err != nil {
+		reason = e.Err.Error()
+	}
+	return fmt.Sprintf("%s %q did not become %s: %s", e.Kind, e.Name, e.State, reason)
+}
+
+func (e *WaitError) Unwrap() error {
+	return e.Err
+}
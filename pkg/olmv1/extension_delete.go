@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
@@ -7,7 +7,7 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
@@ -19,9 +19,10 @@ type ExtensionDeletion struct {
 
 	DeleteAll bool
 
-	DryRun string
-	Output string
+	DryRun DryRunMode
 	Logf   func(string, ...interface{})
+	// Progress receives the steps the action waits for. It discards them by default.
+	Progress ProgressReporter
 }
 
 // NewExtensionDelete creates a new ExtensionDeletion action
@@ -29,18 +30,19 @@ type ExtensionDeletion struct {
 // and a logger function that can be used to log messages
 func NewExtensionDelete(cfg *action.Configuration) *ExtensionDeletion {
 	return &ExtensionDeletion{
-		config: cfg,
-		Logf:   func(string, ...interface{}) {},
+		config:   cfg,
+		Logf:     func(string, ...interface{}) {},
+		Progress: nopProgress,
 	}
 }
 
-func (i *ExtensionDeletion) Run(ctx context.Context) ([]olmv1.ClusterExtension, error) {
+func (i *ExtensionDeletion) Run(ctx context.Context) ([]ocv1.ClusterExtension, error) {
 	if i.DeleteAll && i.ExtensionName != "" {
-		return nil, fmt.Errorf("cannot specify both --all and an extension name")
+		return nil, ErrNameAndDeleteAll
 	}
 	if !i.DeleteAll {
 		ext, err := i.deleteExtension(ctx, i.ExtensionName)
-		return []olmv1.ClusterExtension{ext}, err
+		return []ocv1.ClusterExtension{ext}, err
 	}
 
 	// delete all existing extensions
@@ -48,10 +50,10 @@ func (i *ExtensionDeletion) Run(ctx context.Context) ([]olmv1.ClusterExtension,
 }
 
 // deleteExtension deletes a single extension in the cluster
-func (i *ExtensionDeletion) deleteExtension(ctx context.Context, extName string) (olmv1.ClusterExtension, error) {
-	op := &olmv1.ClusterExtension{}
+func (i *ExtensionDeletion) deleteExtension(ctx context.Context, extName string) (ocv1.ClusterExtension, error) {
+	op := &ocv1.ClusterExtension{}
 	op.SetName(extName)
-	op.SetGroupVersionKind(olmv1.GroupVersion.WithKind("ClusterExtension"))
+	op.SetGroupVersionKind(ocv1.GroupVersion.WithKind("ClusterExtension"))
 
 	if i.DryRun == DryRunAll {
 		err := i.config.Client.Delete(ctx, op, client.DryRunAll)
@@ -63,12 +65,12 @@ func (i *ExtensionDeletion) deleteExtension(ctx context.Context, extName string)
 		return *op, err
 	}
 	// wait for deletion
-	return *op, waitForDeletion(ctx, i.config.Client, op)
+	return *op, waitForDeletion(ctx, i.config.Client, i.Progress, op)
 }
 
 // deleteAllExtensions deletes all extensions in the cluster
-func (i *ExtensionDeletion) deleteAllExtensions(ctx context.Context) ([]olmv1.ClusterExtension, error) {
-	var extensionList olmv1.ClusterExtensionList
+func (i *ExtensionDeletion) deleteAllExtensions(ctx context.Context) ([]ocv1.ClusterExtension, error) {
+	var extensionList ocv1.ClusterExtensionList
 	if err := i.config.Client.List(ctx, &extensionList); err != nil {
 		return nil, err
 	}
@@ -76,7 +78,7 @@ func (i *ExtensionDeletion) deleteAllExtensions(ctx context.Context) ([]olmv1.Cl
 		return nil, ErrNoResourcesFound
 	}
 	errs := make([]error, 0, len(extensionList.Items))
-	result := []olmv1.ClusterExtension{}
+	result := []ocv1.ClusterExtension{}
 	for _, extension := range extensionList.Items {
 		if op, err := i.deleteExtension(ctx, extension.Name); err != nil {
 			errs = append(errs, fmt.Errorf("failed deleting extension %q: %w", extension.Name, err))
@@ -1,4 +1,4 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
@@ -10,10 +10,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 var _ = Describe("ExtensionDelete", func() {
@@ -36,11 +36,11 @@ var _ = Describe("ExtensionDelete", func() {
 	It("fails because of both extension name and --all specifier being present", func() {
 		cfg := setupEnv(setupTestExtensions(2)...)
 
-		deleter := internalaction.NewExtensionDelete(&cfg)
+		deleter := olmv1.NewExtensionDelete(&cfg)
 		deleter.ExtensionName = "foo"
 		deleter.DeleteAll = true
 		extensions, err := deleter.Run(context.TODO())
-		Expect(err).NotTo(BeNil())
+		Expect(err).To(MatchError(olmv1.ErrNameAndDeleteAll))
 		Expect(extensions).To(BeEmpty())
 
 		validateExistingExtensions(cfg.Client, []string{"ext1", "ext2"})
@@ -49,7 +49,7 @@ var _ = Describe("ExtensionDelete", func() {
 	It("fails deleting a non-existent extensions", func() {
 		cfg := setupEnv(setupTestExtensions(2)...)
 
-		deleter := internalaction.NewExtensionDelete(&cfg)
+		deleter := olmv1.NewExtensionDelete(&cfg)
 		deleter.ExtensionName = "does-not-exist"
 		extensions, err := deleter.Run(context.TODO())
 		Expect(err).NotTo(BeNil())
@@ -62,7 +62,7 @@ var _ = Describe("ExtensionDelete", func() {
 	It("successfully deletes an existing extension", func() {
 		cfg := setupEnv(setupTestExtensions(3)...)
 
-		deleter := internalaction.NewExtensionDelete(&cfg)
+		deleter := olmv1.NewExtensionDelete(&cfg)
 		deleter.ExtensionName = "ext2"
 		_, err := deleter.Run(context.TODO())
 		Expect(err).To(BeNil())
@@ -73,10 +73,10 @@ var _ = Describe("ExtensionDelete", func() {
 	It("fails deleting all extensions because there are none", func() {
 		cfg := setupEnv()
 
-		deleter := internalaction.NewExtensionDelete(&cfg)
+		deleter := olmv1.NewExtensionDelete(&cfg)
 		deleter.DeleteAll = true
 		extensions, err := deleter.Run(context.TODO())
-		Expect(err).NotTo(BeNil())
+		Expect(err).To(MatchError(olmv1.ErrNoResourcesFound))
 		Expect(extensions).To(BeEmpty())
 
 		validateExistingExtensions(cfg.Client, []string{})
@@ -85,7 +85,7 @@ var _ = Describe("ExtensionDelete", func() {
 	It("successfully deletes all extensions", func() {
 		cfg := setupEnv(setupTestExtensions(3)...)
 
-		deleter := internalaction.NewExtensionDelete(&cfg)
+		deleter := olmv1.NewExtensionDelete(&cfg)
 		deleter.DeleteAll = true
 		extensions, err := deleter.Run(context.TODO())
 		Expect(err).To(BeNil())
@@ -98,7 +98,7 @@ var _ = Describe("ExtensionDelete", func() {
 // validateExistingExtensions compares the names of the existing extensions with the wanted names
 // and ensures that all wanted names are present in the existing extensions
 func validateExistingExtensions(c client.Client, wantedNames []string) {
-	var extensionList olmv1.ClusterExtensionList
+	var extensionList ocv1.ClusterExtensionList
 	err := c.List(context.TODO(), &extensionList)
 	Expect(err).To(BeNil())
 
@@ -107,9 +107,9 @@ func validateExistingExtensions(c client.Client, wantedNames []string) {
 	validateExtensionList(extensions, wantedNames)
 }
 
-func validateExtensionList(extensions []olmv1.ClusterExtension, wantedNames []string) {
+func validateExtensionList(extensions []ocv1.ClusterExtension, wantedNames []string) {
 	for _, wantedName := range wantedNames {
-		Expect(slices.ContainsFunc(extensions, func(ext olmv1.ClusterExtension) bool {
+		Expect(slices.ContainsFunc(extensions, func(ext ocv1.ClusterExtension) bool {
 			return ext.Name == wantedName
 		})).To(BeTrue())
 	}
@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
@@ -7,11 +7,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+// ExtensionInstalledGet gets a ClusterExtension by name, or lists ClusterExtensions matching
+// Selector.
 type ExtensionInstalledGet struct {
 	config        *action.Configuration
 	ExtensionName string
@@ -28,21 +30,21 @@ func NewExtensionInstalledGet(cfg *action.Configuration) *ExtensionInstalledGet
 	}
 }
 
-func (i *ExtensionInstalledGet) Run(ctx context.Context) ([]olmv1.ClusterExtension, error) {
+func (i *ExtensionInstalledGet) Run(ctx context.Context) ([]ocv1.ClusterExtension, error) {
 	// get
 	if i.ExtensionName != "" {
-		var result olmv1.ClusterExtension
+		var result ocv1.ClusterExtension
 		opKey := types.NamespacedName{Name: i.ExtensionName}
 		err := i.config.Client.Get(ctx, opKey, &result)
 		if err != nil {
 			return nil, err
 		}
 
-		return []olmv1.ClusterExtension{result}, nil
+		return []ocv1.ClusterExtension{result}, nil
 	}
 
 	// list
-	var result olmv1.ClusterExtensionList
+	var result ocv1.ClusterExtensionList
 	listOpts := &client.ListOptions{}
 	if i.Selector != nil {
 		listOpts.LabelSelector = i.Selector
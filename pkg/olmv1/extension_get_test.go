@@ -1,4 +1,4 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
@@ -12,10 +12,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 var _ = Describe("ExtensionInstalledGet", func() {
@@ -38,14 +38,14 @@ var _ = Describe("ExtensionInstalledGet", func() {
 	It("lists all installed extensions", func() {
 		cfg := setupEnv(setupTestExtensions(3)...)
 
-		getter := internalaction.NewExtensionInstalledGet(&cfg)
+		getter := olmv1.NewExtensionInstalledGet(&cfg)
 		extensions, err := getter.Run(context.TODO())
 		Expect(err).To(BeNil())
 		Expect(extensions).NotTo(BeEmpty())
 		Expect(extensions).To(HaveLen(3))
 
 		for _, testExtensionName := range []string{"ext1", "ext2", "ext3"} {
-			Expect(slices.ContainsFunc(extensions, func(op olmv1.ClusterExtension) bool {
+			Expect(slices.ContainsFunc(extensions, func(op ocv1.ClusterExtension) bool {
 				return op.Name == testExtensionName
 			})).To(BeTrue())
 		}
@@ -54,7 +54,7 @@ var _ = Describe("ExtensionInstalledGet", func() {
 	It("returns empty list in case no extensions were found", func() {
 		cfg := setupEnv()
 
-		getter := internalaction.NewExtensionInstalledGet(&cfg)
+		getter := olmv1.NewExtensionInstalledGet(&cfg)
 		extensions, err := getter.Run(context.TODO())
 		Expect(err).To(BeNil())
 		Expect(extensions).To(BeEmpty())
@@ -63,7 +63,7 @@ var _ = Describe("ExtensionInstalledGet", func() {
 	It("gets an installed extension", func() {
 		cfg := setupEnv(setupTestExtensions(3)...)
 
-		getter := internalaction.NewExtensionInstalledGet(&cfg)
+		getter := olmv1.NewExtensionInstalledGet(&cfg)
 		getter.ExtensionName = "ext2"
 		extensions, err := getter.Run(context.TODO())
 		Expect(err).To(BeNil())
@@ -75,7 +75,7 @@ var _ = Describe("ExtensionInstalledGet", func() {
 	It("returns an empty list and an error when an installed extension was not found", func() {
 		cfg := setupEnv()
 
-		getter := internalaction.NewExtensionInstalledGet(&cfg)
+		getter := olmv1.NewExtensionInstalledGet(&cfg)
 		getter.ExtensionName = "ext2"
 		extensions, err := getter.Run(context.TODO())
 		Expect(err).NotTo(BeNil())
@@ -92,12 +92,12 @@ func setupTestExtensions(n int) []client.Object {
 	return result
 }
 
-func newClusterExtension(name, version string) *olmv1.ClusterExtension {
-	return &olmv1.ClusterExtension{
+func newClusterExtension(name, version string) *ocv1.ClusterExtension {
+	return &ocv1.ClusterExtension{
 		ObjectMeta: metav1.ObjectMeta{Name: name},
-		Status: olmv1.ClusterExtensionStatus{
-			Install: &olmv1.ClusterExtensionInstallStatus{
-				Bundle: olmv1.BundleMetadata{
+		Status: ocv1.ClusterExtensionStatus{
+			Install: &ocv1.ClusterExtensionInstallStatus{
+				Bundle: ocv1.BundleMetadata{
 					Name:    name,
 					Version: version,
 				},
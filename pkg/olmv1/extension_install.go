@@ -0,0 +1,171 @@
+package olmv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+
+	"github.com/operator-framework/kubectl-operator/pkg/action"
+)
+
+// ExtensionInstall creates a ClusterExtension and waits until it is installed. The extension
+// is cleaned up if the installation does not succeed.
+type ExtensionInstall struct {
+	config        *action.Configuration
+	ExtensionName string
+
+	Namespace                   NamespaceConfig
+	PackageName                 string
+	Channels                    []string
+	Version                     string
+	CatalogSelector             *metav1.LabelSelector
+	ServiceAccount              string
+	CleanupTimeout              time.Duration
+	UpgradeConstraintPolicy     ocv1.UpgradeConstraintPolicy
+	CRDUpgradeSafetyEnforcement ocv1.CRDUpgradeSafetyEnforcement
+	Labels                      map[string]string
+
+	DryRun DryRunMode
+	Logf   func(string, ...interface{})
+	// Progress receives the steps the action waits for. It discards them by default.
+	Progress ProgressReporter
+}
+type NamespaceConfig struct {
+	Name string
+}
+
+func NewExtensionInstall(cfg *action.Configuration) *ExtensionInstall {
+	return &ExtensionInstall{
+		config:   cfg,
+		Logf:     func(string, ...interface{}) {},
+		Progress: nopProgress,
+	}
+}
+
+func (i *ExtensionInstall) buildClusterExtension() ocv1.ClusterExtension {
+	extension := ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   i.ExtensionName,
+			Labels: i.Labels,
+		},
+		Spec: ocv1.ClusterExtensionSpec{
+			Source: ocv1.SourceConfig{
+				SourceType: ocv1.SourceTypeCatalog,
+				Catalog: &ocv1.CatalogFilter{
+					PackageName: i.PackageName,
+					Version:     i.Version,
+				},
+			},
+			Namespace: i.Namespace.Name,
+			ServiceAccount: ocv1.ServiceAccountReference{
+				Name: i.ServiceAccount,
+			},
+		},
+	}
+	if i.CatalogSelector != nil {
+		extension.Spec.Source.Catalog.Selector = i.CatalogSelector
+	}
+	if len(i.UpgradeConstraintPolicy) > 0 {
+		extension.Spec.Source.Catalog.UpgradeConstraintPolicy = i.UpgradeConstraintPolicy
+	}
+	if len(i.CRDUpgradeSafetyEnforcement) > 0 {
+		extension.Spec.Install = &ocv1.ClusterExtensionInstallConfig{
+			Preflight: &ocv1.PreflightConfig{
+				CRDUpgradeSafety: &ocv1.CRDUpgradeSafetyPreflightConfig{
+					Enforcement: i.CRDUpgradeSafetyEnforcement,
+				},
+			},
+		}
+	}
+
+	return extension
+}
+
+func (i *ExtensionInstall) Run(ctx context.Context) (*ocv1.ClusterExtension, error) {
+	extension := i.buildClusterExtension()
+
+	// Add Channels to extension
+	if len(i.Channels) > 0 {
+		extension.Spec.Source.Catalog.Channels = i.Channels
+	}
+
+	if i.DryRun == DryRunAll {
+		if err := i.config.Client.Create(ctx, &extension, client.DryRunAll); err != nil {
+			return nil, err
+		}
+		return &extension, nil
+	}
+	// Create the extension
+	if err := i.config.Client.Create(ctx, &extension); err != nil {
+		return nil, err
+	}
+	clusterExtension, err := i.waitForExtensionInstall(ctx)
+	if err != nil {
+		i.Logf("failed to install extension %s: %v; cleaning up extension", i.PackageName, err)
+		cleanupCtx, cancelCleanup := context.WithTimeout(context.WithoutCancel(ctx), i.CleanupTimeout)
+		defer cancelCleanup()
+		cleanupErr := i.cleanup(cleanupCtx)
+		return nil, errors.Join(err, cleanupErr)
+	}
+	return clusterExtension, nil
+}
+
+// waitForClusterExtensionInstalled waits for the ClusterExtension to be installed
+// and returns the ClusterExtension object
+func (i *ExtensionInstall) waitForExtensionInstall(ctx context.Context) (*ocv1.ClusterExtension, error) {
+	clusterExtension := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: i.ExtensionName,
+		},
+	}
+	errMsg := ""
+	key := client.ObjectKeyFromObject(clusterExtension)
+	message := fmt.Sprintf("waiting for ClusterExtension %q to be installed", clusterExtension.Name)
+	if err := track(i.Progress, "ClusterExtension", clusterExtension.Name, message, func() error {
+		if err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(conditionCtx context.Context) (bool, error) {
+			if err := i.config.Client.Get(conditionCtx, key, clusterExtension); err != nil {
+				return false, err
+			}
+			progressingCondition := meta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeProgressing)
+			if progressingCondition != nil && progressingCondition.Reason != ocv1.ReasonSucceeded {
+				errMsg = progressingCondition.Message
+				return false, nil
+			}
+			if !meta.IsStatusConditionPresentAndEqual(clusterExtension.Status.Conditions, ocv1.TypeInstalled, metav1.ConditionTrue) {
+				return false, nil
+			}
+			return true, nil
+		}); err != nil {
+			return &WaitError{Kind: "ClusterExtension", Name: clusterExtension.Name, State: "installed", Message: errMsg, Err: err}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return clusterExtension, nil
+}
+
+func (i *ExtensionInstall) cleanup(ctx context.Context) error {
+	clusterExtension := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: i.ExtensionName,
+		},
+	}
+	clusterExtension.SetGroupVersionKind(ocv1.GroupVersion.WithKind("ClusterExtension"))
+	if err := i.config.Client.Delete(ctx, clusterExtension); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete clusterextension %q: %w", i.ExtensionName, err)
+	}
+	if err := waitForDeletion(ctx, i.config.Client, i.Progress, clusterExtension); err != nil {
+		return fmt.Errorf("delete clusterextension %q: %w", i.ExtensionName, err)
+	}
+	return nil
+}
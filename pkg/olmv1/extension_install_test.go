@@ -1,4 +1,4 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
@@ -12,8 +12,8 @@ import (
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 var _ = Describe("InstallExtension", func() {
@@ -46,7 +46,7 @@ var _ = Describe("InstallExtension", func() {
 		testClient := fakeClient{createErr: expectedErr}
 		Expect(testClient.Initialize()).To(Succeed())
 
-		installer := internalaction.NewExtensionInstall(&action.Configuration{Client: testClient})
+		installer := olmv1.NewExtensionInstall(&action.Configuration{Client: testClient})
 		installer.ExtensionName = expectedExtension.Name
 		installer.PackageName = expectedExtension.Spec.Source.Catalog.PackageName
 		installer.Channels = expectedExtension.Spec.Source.Catalog.Channels
@@ -60,4 +60,22 @@ var _ = Describe("InstallExtension", func() {
 		Expect(err).To(MatchError(expectedErr))
 		Expect(testClient.createCalled).To(Equal(1))
 	})
+
+	It("deletes the cluster extension when waiting for it fails", func() {
+		expectedErr := errors.New("get failed")
+		testClient := fakeClient{getErr: expectedErr}
+		Expect(testClient.Initialize()).To(Succeed())
+
+		installer := olmv1.NewExtensionInstall(&action.Configuration{Client: testClient})
+		installer.ExtensionName = expectedExtension.Name
+		installer.PackageName = expectedExtension.Spec.Source.Catalog.PackageName
+		installer.ServiceAccount = expectedExtension.Spec.ServiceAccount.Name
+		installer.CleanupTimeout = 1 * time.Minute
+		installer.Namespace.Name = expectedExtension.Spec.Namespace
+		_, err := installer.Run(context.TODO())
+
+		Expect(err).To(MatchError(expectedErr))
+		Expect(testClient.createCalled).To(Equal(1))
+		Expect(testClient.deleteCalled).To(Equal(1))
+	})
 })
@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
@@ -12,11 +12,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
 	"github.com/operator-framework/kubectl-operator/pkg/action"
 )
 
+// ExtensionUpdate updates the catalog source and labels of a ClusterExtension and waits until
+// the update is installed.
 type ExtensionUpdate struct {
 	config        *action.Configuration
 	ExtensionName string
@@ -24,27 +26,29 @@ type ExtensionUpdate struct {
 	Version                 string
 	Channels                []string
 	CatalogSelector         *metav1.LabelSelector
-	UpgradeConstraintPolicy string
+	UpgradeConstraintPolicy ocv1.UpgradeConstraintPolicy
 	Labels                  map[string]string
 	IgnoreUnset             bool
 
 	CleanupTimeout              time.Duration
-	CRDUpgradeSafetyEnforcement string
+	CRDUpgradeSafetyEnforcement ocv1.CRDUpgradeSafetyEnforcement
 
-	DryRun string
-	Output string
+	DryRun DryRunMode
 	Logf   func(string, ...interface{})
+	// Progress receives the steps the action waits for. It discards them by default.
+	Progress ProgressReporter
 }
 
 func NewExtensionUpdate(cfg *action.Configuration) *ExtensionUpdate {
 	return &ExtensionUpdate{
-		config: cfg,
-		Logf:   func(string, ...interface{}) {},
+		config:   cfg,
+		Logf:     func(string, ...interface{}) {},
+		Progress: nopProgress,
 	}
 }
 
-func (i *ExtensionUpdate) Run(ctx context.Context) (*olmv1.ClusterExtension, error) {
-	var ext olmv1.ClusterExtension
+func (i *ExtensionUpdate) Run(ctx context.Context) (*ocv1.ClusterExtension, error) {
+	var ext ocv1.ClusterExtension
 	var err error
 
 	opKey := types.NamespacedName{Name: i.ExtensionName}
@@ -52,7 +56,7 @@ func (i *ExtensionUpdate) Run(ctx context.Context) (*olmv1.ClusterExtension, err
 		return nil, err
 	}
 
-	if ext.Spec.Source.SourceType != olmv1.SourceTypeCatalog {
+	if ext.Spec.Source.SourceType != ocv1.SourceTypeCatalog {
 		return nil, fmt.Errorf("unrecognized source type: %q", ext.Spec.Source.SourceType)
 	}
 
@@ -65,7 +69,7 @@ func (i *ExtensionUpdate) Run(ctx context.Context) (*olmv1.ClusterExtension, err
 	}
 
 	if !i.needsUpdate(ext) {
-		return nil, ErrNoChange
+		return nil, fmt.Errorf("%w - extension already in desired state", ErrNoChange)
 	}
 
 	i.prepareUpdatedExtension(&ext)
@@ -80,20 +84,20 @@ func (i *ExtensionUpdate) Run(ctx context.Context) (*olmv1.ClusterExtension, err
 		return nil, err
 	}
 
-	if err := waitUntilExtensionStatusCondition(ctx, i.config.Client, &ext, olmv1.TypeInstalled, metav1.ConditionTrue); err != nil {
+	if err := waitUntilExtensionStatusCondition(ctx, i.config.Client, &ext, ocv1.TypeInstalled, metav1.ConditionTrue, i.Progress); err != nil {
 		return nil, fmt.Errorf("timed out waiting for extension: %w", err)
 	}
 
 	return &ext, nil
 }
 
-func (i *ExtensionUpdate) setDefaults(ext olmv1.ClusterExtension) {
+func (i *ExtensionUpdate) setDefaults(ext ocv1.ClusterExtension) {
 	if !i.IgnoreUnset {
 		if i.UpgradeConstraintPolicy == "" {
-			i.UpgradeConstraintPolicy = string(olmv1.UpgradeConstraintPolicyCatalogProvided)
+			i.UpgradeConstraintPolicy = ocv1.UpgradeConstraintPolicyCatalogProvided
 		}
 		if i.CRDUpgradeSafetyEnforcement == "" {
-			i.CRDUpgradeSafetyEnforcement = string(olmv1.CRDUpgradeSafetyEnforcementStrict)
+			i.CRDUpgradeSafetyEnforcement = ocv1.CRDUpgradeSafetyEnforcementStrict
 		}
 
 		return
@@ -109,11 +113,11 @@ func (i *ExtensionUpdate) setDefaults(ext olmv1.ClusterExtension) {
 		i.Channels = catalogSrc.Channels
 	}
 	if i.UpgradeConstraintPolicy == "" {
-		i.UpgradeConstraintPolicy = string(catalogSrc.UpgradeConstraintPolicy)
+		i.UpgradeConstraintPolicy = catalogSrc.UpgradeConstraintPolicy
 	}
 	if i.CRDUpgradeSafetyEnforcement == "" && ext.Spec.Install != nil && ext.Spec.Install.Preflight != nil &&
 		ext.Spec.Install.Preflight.CRDUpgradeSafety != nil {
-		i.CRDUpgradeSafetyEnforcement = string(ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement)
+		i.CRDUpgradeSafetyEnforcement = ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement
 	}
 	if len(i.Labels) == 0 {
 		i.Labels = ext.Labels
@@ -123,7 +127,7 @@ func (i *ExtensionUpdate) setDefaults(ext olmv1.ClusterExtension) {
 	}
 }
 
-func (i *ExtensionUpdate) needsUpdate(ext olmv1.ClusterExtension) bool {
+func (i *ExtensionUpdate) needsUpdate(ext ocv1.ClusterExtension) bool {
 	catalogSrc := ext.Spec.Source.Catalog
 
 	// object string form is used for comparison to:
@@ -133,15 +137,15 @@ func (i *ExtensionUpdate) needsUpdate(ext olmv1.ClusterExtension) bool {
 		(catalogSrc.Selector != nil && i.CatalogSelector != nil &&
 			catalogSrc.Selector.String() == i.CatalogSelector.String())
 
-	var crdUpgradeSafetyEnforcement string
+	var crdUpgradeSafetyEnforcement ocv1.CRDUpgradeSafetyEnforcement
 	if ext.Spec.Install != nil && ext.Spec.Install.Preflight != nil &&
 		ext.Spec.Install.Preflight.CRDUpgradeSafety != nil {
-		crdUpgradeSafetyEnforcement = string(ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement)
+		crdUpgradeSafetyEnforcement = ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement
 	}
 
 	if catalogSrc.Version == i.Version &&
 		slices.Equal(catalogSrc.Channels, i.Channels) &&
-		string(catalogSrc.UpgradeConstraintPolicy) == i.UpgradeConstraintPolicy &&
+		catalogSrc.UpgradeConstraintPolicy == i.UpgradeConstraintPolicy &&
 		maps.Equal(ext.Labels, i.Labels) &&
 		crdUpgradeSafetyEnforcement == i.CRDUpgradeSafetyEnforcement &&
 		sameSelectors {
@@ -151,7 +155,7 @@ func (i *ExtensionUpdate) needsUpdate(ext olmv1.ClusterExtension) bool {
 	return true
 }
 
-func (i *ExtensionUpdate) prepareUpdatedExtension(ext *olmv1.ClusterExtension) {
+func (i *ExtensionUpdate) prepareUpdatedExtension(ext *ocv1.ClusterExtension) {
 	existingLabels := ext.GetLabels()
 	if existingLabels == nil {
 		existingLabels = make(map[string]string)
@@ -169,6 +173,19 @@ func (i *ExtensionUpdate) prepareUpdatedExtension(ext *olmv1.ClusterExtension) {
 	ext.Spec.Source.Catalog.Version = i.Version
 	ext.Spec.Source.Catalog.Selector = i.CatalogSelector
 	ext.Spec.Source.Catalog.Channels = i.Channels
-	ext.Spec.Source.Catalog.UpgradeConstraintPolicy = olmv1.UpgradeConstraintPolicy(i.UpgradeConstraintPolicy)
-	ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement = olmv1.CRDUpgradeSafetyEnforcement(i.CRDUpgradeSafetyEnforcement)
+	ext.Spec.Source.Catalog.UpgradeConstraintPolicy = i.UpgradeConstraintPolicy
+	if i.CRDUpgradeSafetyEnforcement == "" {
+		ext.Spec.Install = nil
+		return
+	}
+	if ext.Spec.Install == nil {
+		ext.Spec.Install = &ocv1.ClusterExtensionInstallConfig{}
+	}
+	if ext.Spec.Install.Preflight == nil {
+		ext.Spec.Install.Preflight = &ocv1.PreflightConfig{}
+	}
+	if ext.Spec.Install.Preflight.CRDUpgradeSafety == nil {
+		ext.Spec.Install.Preflight.CRDUpgradeSafety = &ocv1.CRDUpgradeSafetyPreflightConfig{}
+	}
+	ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement = i.CRDUpgradeSafetyEnforcement
 }
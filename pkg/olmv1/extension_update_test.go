@@ -1,7 +1,8 @@
-package action_test
+package olmv1_test
 
 import (
 	"context"
+	"errors"
 	"maps"
 	"time"
 
@@ -12,10 +13,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	olmv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 
-	internalaction "github.com/operator-framework/kubectl-operator/internal/pkg/v1/action"
 	"github.com/operator-framework/kubectl-operator/pkg/action"
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
 )
 
 var _ = Describe("ExtensionUpdate", func() {
@@ -38,7 +39,7 @@ var _ = Describe("ExtensionUpdate", func() {
 	It("fails finding existing extension", func() {
 		cfg := setupEnv()
 
-		updater := internalaction.NewExtensionUpdate(&cfg)
+		updater := olmv1.NewExtensionUpdate(&cfg)
 		updater.ExtensionName = "does-not-exist"
 		ext, err := updater.Run(context.TODO())
 
@@ -50,7 +51,7 @@ var _ = Describe("ExtensionUpdate", func() {
 	It("fails to handle extension with non-catalog source type", func() {
 		cfg := setupEnv(buildExtension("test", withSourceType("unknown")))
 
-		updater := internalaction.NewExtensionUpdate(&cfg)
+		updater := olmv1.NewExtensionUpdate(&cfg)
 		updater.ExtensionName = "test"
 		ext, err := updater.Run(context.TODO())
 
@@ -62,50 +63,50 @@ var _ = Describe("ExtensionUpdate", func() {
 	It("fails because desired extension state matches current", func() {
 		cfg := setupEnv(buildExtension(
 			"test",
-			withSourceType(olmv1.SourceTypeCatalog),
-			withCRDUpgradePolicy(string(olmv1.CRDUpgradeSafetyEnforcementStrict)),
-			withConstraintPolicy(string(olmv1.UpgradeConstraintPolicyCatalogProvided))),
+			withSourceType(ocv1.SourceTypeCatalog),
+			withCRDUpgradePolicy(string(ocv1.CRDUpgradeSafetyEnforcementStrict)),
+			withConstraintPolicy(string(ocv1.UpgradeConstraintPolicyCatalogProvided))),
 		)
 
-		updater := internalaction.NewExtensionUpdate(&cfg)
+		updater := olmv1.NewExtensionUpdate(&cfg)
 		updater.ExtensionName = "test"
 		ext, err := updater.Run(context.TODO())
 
 		Expect(err).NotTo(BeNil())
-		Expect(err).To(MatchError(internalaction.ErrNoChange))
+		Expect(err).To(MatchError(olmv1.ErrNoChange))
 		Expect(ext).To(BeNil())
 	})
 
 	It("fails because desired extension state matches current with IgnoreUnset enabled", func() {
 		cfg := setupEnv(buildExtension(
 			"test",
-			withSourceType(olmv1.SourceTypeCatalog),
-			withConstraintPolicy(string(olmv1.UpgradeConstraintPolicyCatalogProvided)),
-			withCRDUpgradePolicy(string(olmv1.CRDUpgradeSafetyEnforcementStrict)),
+			withSourceType(ocv1.SourceTypeCatalog),
+			withConstraintPolicy(string(ocv1.UpgradeConstraintPolicyCatalogProvided)),
+			withCRDUpgradePolicy(string(ocv1.CRDUpgradeSafetyEnforcementStrict)),
 			withChannels("a", "b"),
 			withLabels(map[string]string{"c": "d"}),
 			withVersion("10.0.4"),
 		))
 
-		updater := internalaction.NewExtensionUpdate(&cfg)
+		updater := olmv1.NewExtensionUpdate(&cfg)
 		updater.ExtensionName = "test"
 		updater.IgnoreUnset = true
 		ext, err := updater.Run(context.TODO())
 
 		Expect(err).NotTo(BeNil())
-		Expect(err).To(MatchError(internalaction.ErrNoChange))
+		Expect(err).To(MatchError(olmv1.ErrNoChange))
 		Expect(ext).To(BeNil())
 	})
 
 	It("fails validating extension version", func() {
 		cfg := setupEnv(buildExtension(
 			"test",
-			withSourceType(olmv1.SourceTypeCatalog),
-			withCRDUpgradePolicy(string(olmv1.CRDUpgradeSafetyEnforcementStrict)),
-			withConstraintPolicy(string(olmv1.UpgradeConstraintPolicyCatalogProvided))),
+			withSourceType(ocv1.SourceTypeCatalog),
+			withCRDUpgradePolicy(string(ocv1.CRDUpgradeSafetyEnforcementStrict)),
+			withConstraintPolicy(string(ocv1.UpgradeConstraintPolicyCatalogProvided))),
 		)
 
-		updater := internalaction.NewExtensionUpdate(&cfg)
+		updater := olmv1.NewExtensionUpdate(&cfg)
 		updater.ExtensionName = "test"
 		updater.Version = "10-4"
 		ext, err := updater.Run(context.TODO())
@@ -118,51 +119,55 @@ var _ = Describe("ExtensionUpdate", func() {
 	It("fails updating extension", func() {
 		testExt := buildExtension(
 			"test",
-			withSourceType(olmv1.SourceTypeCatalog),
-			withCRDUpgradePolicy(string(olmv1.CRDUpgradeSafetyEnforcementStrict)),
-			withConstraintPolicy(string(olmv1.UpgradeConstraintPolicyCatalogProvided)),
+			withSourceType(ocv1.SourceTypeCatalog),
+			withCRDUpgradePolicy(string(ocv1.CRDUpgradeSafetyEnforcementStrict)),
+			withConstraintPolicy(string(ocv1.UpgradeConstraintPolicyCatalogProvided)),
 		)
 		cfg := setupEnv(testExt)
 
 		ctx, cancel := context.WithCancel(context.TODO())
 		cancel()
 
-		updater := internalaction.NewExtensionUpdate(&cfg)
+		updater := olmv1.NewExtensionUpdate(&cfg)
 		updater.ExtensionName = "test"
 		updater.Version = "10.0.4"
 		updater.Channels = []string{"a", "b"}
 		updater.Labels = map[string]string{"c": "d"}
-		updater.UpgradeConstraintPolicy = string(olmv1.UpgradeConstraintPolicySelfCertified)
+		updater.UpgradeConstraintPolicy = ocv1.UpgradeConstraintPolicySelfCertified
 		ext, err := updater.Run(ctx)
 
 		Expect(err).NotTo(BeNil())
 		Expect(err.Error()).To(ContainSubstring("timed out"))
+		var waitErr *olmv1.WaitError
+		Expect(errors.As(err, &waitErr)).To(BeTrue())
+		Expect(waitErr.Name).To(Equal("test"))
+		Expect(err).To(MatchError(context.Canceled))
 		Expect(ext).To(BeNil())
 	})
 
 	It("successfully updates extension", func() {
 		testExt := buildExtension(
 			"test",
-			withSourceType(olmv1.SourceTypeCatalog),
-			withCRDUpgradePolicy(string(olmv1.CRDUpgradeSafetyEnforcementNone)),
-			withConstraintPolicy(string(olmv1.UpgradeConstraintPolicyCatalogProvided)),
+			withSourceType(ocv1.SourceTypeCatalog),
+			withCRDUpgradePolicy(string(ocv1.CRDUpgradeSafetyEnforcementNone)),
+			withConstraintPolicy(string(ocv1.UpgradeConstraintPolicyCatalogProvided)),
 		)
 		cfg := setupEnv(testExt, buildExtension("test2"), buildExtension("test3"))
 
 		go func() {
 			Eventually(updateExtensionConditionStatus).
-				WithArguments("test", cfg.Client, olmv1.TypeInstalled, metav1.ConditionTrue).
+				WithArguments("test", cfg.Client, ocv1.TypeInstalled, metav1.ConditionTrue).
 				WithTimeout(5 * time.Second).WithPolling(200 * time.Millisecond).
 				Should(Succeed())
 		}()
 
-		updater := internalaction.NewExtensionUpdate(&cfg)
+		updater := olmv1.NewExtensionUpdate(&cfg)
 		updater.ExtensionName = "test"
 		updater.Version = "10.0.4"
 		updater.Channels = []string{"a", "b"}
 		updater.Labels = map[string]string{"c": "d"}
-		updater.UpgradeConstraintPolicy = string(olmv1.UpgradeConstraintPolicySelfCertified)
-		updater.CRDUpgradeSafetyEnforcement = string(olmv1.CRDUpgradeSafetyEnforcementStrict)
+		updater.UpgradeConstraintPolicy = ocv1.UpgradeConstraintPolicySelfCertified
+		updater.CRDUpgradeSafetyEnforcement = ocv1.CRDUpgradeSafetyEnforcementStrict
 		ext, err := updater.Run(context.TODO())
 
 		Expect(err).To(BeNil())
@@ -171,15 +176,43 @@ var _ = Describe("ExtensionUpdate", func() {
 		Expect(maps.Equal(ext.Labels, updater.Labels)).To(BeTrue())
 		Expect(ext.Spec.Source.Catalog.Channels).To(ContainElements(updater.Channels))
 		Expect(ext.Spec.Source.Catalog.UpgradeConstraintPolicy).
-			To(Equal(olmv1.UpgradeConstraintPolicy(updater.UpgradeConstraintPolicy)))
+			To(Equal(updater.UpgradeConstraintPolicy))
 
 		// also verify that other objects were not updated
 		validateNonUpdatedExtensions(cfg.Client, "test")
 	})
+
+	It("successfully updates extension without install configuration", func() {
+		testExt := buildExtension(
+			"test",
+			withSourceType(ocv1.SourceTypeCatalog),
+			withConstraintPolicy(string(ocv1.UpgradeConstraintPolicyCatalogProvided)),
+		)
+		cfg := setupEnv(testExt)
+
+		go func() {
+			Eventually(updateExtensionConditionStatus).
+				WithArguments("test", cfg.Client, ocv1.TypeInstalled, metav1.ConditionTrue).
+				WithTimeout(5 * time.Second).WithPolling(200 * time.Millisecond).
+				Should(Succeed())
+		}()
+
+		updater := olmv1.NewExtensionUpdate(&cfg)
+		updater.ExtensionName = "test"
+		updater.Version = "10.0.4"
+		updater.CRDUpgradeSafetyEnforcement = ocv1.CRDUpgradeSafetyEnforcementNone
+		ext, err := updater.Run(context.TODO())
+
+		Expect(err).To(BeNil())
+		Expect(ext).NotTo(BeNil())
+		Expect(ext.Spec.Source.Catalog.Version).To(Equal(updater.Version))
+		Expect(ext.Spec.Install.Preflight.CRDUpgradeSafety.Enforcement).
+			To(Equal(ocv1.CRDUpgradeSafetyEnforcementNone))
+	})
 })
 
 func validateNonUpdatedExtensions(c client.Client, exceptName string) {
-	var extList olmv1.ClusterExtensionList
+	var extList ocv1.ClusterExtensionList
 	err := c.List(context.TODO(), &extList)
 	Expect(err).To(BeNil())
 
@@ -0,0 +1,140 @@
+package olmv1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+const pollInterval = 250 * time.Millisecond
+
+// DryRunMode controls whether a request is persisted. The zero value persists it.
+type DryRunMode string
+
+// DryRunAll submits every stage of a request without persisting it, and returns the object
+// the server would have stored.
+const DryRunAll DryRunMode = "All"
+
+func objectKeyForObject(obj client.Object) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+func waitUntilCatalogStatusCondition(
+	ctx context.Context,
+	cl getter,
+	catalog *ocv1.ClusterCatalog,
+	conditionType string,
+	conditionStatus metav1.ConditionStatus,
+	progress ProgressReporter,
+) error {
+	message := fmt.Sprintf("waiting for ClusterCatalog %q to become healthy", catalog.Name)
+	return track(progress, "ClusterCatalog", catalog.Name, message, func() error {
+		return waitForCatalogStatusCondition(ctx, cl, catalog, conditionType, conditionStatus)
+	})
+}
+
+func waitForCatalogStatusCondition(ctx context.Context, cl getter, catalog *ocv1.ClusterCatalog, conditionType string, conditionStatus metav1.ConditionStatus) error {
+	opKey := objectKeyForObject(catalog)
+	err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(conditionCtx context.Context) (bool, error) {
+		if err := cl.Get(conditionCtx, opKey, catalog); err != nil {
+			return false, err
+		}
+
+		if slices.ContainsFunc(catalog.Status.Conditions, func(cond metav1.Condition) bool {
+			return cond.Type == conditionType && cond.Status == conditionStatus
+		}) {
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return &WaitError{Kind: "ClusterCatalog", Name: catalog.Name, State: conditionType + "=" + string(conditionStatus), Err: err}
+	}
+	return nil
+}
+
+func waitUntilExtensionStatusCondition(
+	ctx context.Context,
+	cl getter,
+	extension *ocv1.ClusterExtension,
+	conditionType string,
+	conditionStatus metav1.ConditionStatus,
+	progress ProgressReporter,
+) error {
+	message := fmt.Sprintf("waiting for ClusterExtension %q to become healthy", extension.Name)
+	return track(progress, "ClusterExtension", extension.Name, message, func() error {
+		return waitForExtensionStatusCondition(ctx, cl, extension, conditionType, conditionStatus)
+	})
+}
+
+func waitForExtensionStatusCondition(ctx context.Context, cl getter, extension *ocv1.ClusterExtension, conditionType string, conditionStatus metav1.ConditionStatus) error {
+	opKey := objectKeyForObject(extension)
+	err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(conditionCtx context.Context) (bool, error) {
+		if err := cl.Get(conditionCtx, opKey, extension); err != nil {
+			return false, err
+		}
+
+		if slices.ContainsFunc(extension.Status.Conditions, func(cond metav1.Condition) bool {
+			return cond.Type == conditionType && cond.Status == conditionStatus
+		}) {
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return &WaitError{Kind: "ClusterExtension", Name: extension.Name, State: conditionType + "=" + string(conditionStatus), Err: err}
+	}
+	return nil
+}
+
+// deleteWithTimeout deletes obj, allowing timeout for the request even if ctx is already done,
+// since it is used to clean up after a failed or cancelled request.
+func deleteWithTimeout(ctx context.Context, cl deleter, obj client.Object, timeout time.Duration, progress ProgressReporter) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	message := fmt.Sprintf("deleting %s %q", kind, obj.GetName())
+	return track(progress, kind, obj.GetName(), message, func() error {
+		if err := cl.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+func waitForDeletion(ctx context.Context, cl getter, progress ProgressReporter, objs ...client.Object) error {
+	for _, obj := range objs {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		key := objectKeyForObject(obj)
+		message := fmt.Sprintf("waiting for %s %q to be deleted", kind, key.Name)
+		if err := track(progress, kind, key.Name, message, func() error {
+			if err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(conditionCtx context.Context) (bool, error) {
+				if err := cl.Get(conditionCtx, key, obj); apierrors.IsNotFound(err) {
+					return true, nil
+				} else if err != nil {
+					return false, err
+				}
+				return false, nil
+			}); err != nil {
+				return &WaitError{Kind: kind, Name: key.Name, State: "deleted", Err: err}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
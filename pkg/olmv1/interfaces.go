@@ -1,4 +1,4 @@
-package action
+package olmv1
 
 import (
 	"context"
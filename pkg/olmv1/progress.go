@@ -0,0 +1,56 @@
+package olmv1
+
+// Phase is the stage of a step reported in an Event.
+type Phase string
+
+const (
+	PhaseStarted   Phase = "started"
+	PhaseSucceeded Phase = "succeeded"
+	PhaseFailed    Phase = "failed"
+)
+
+// Event reports the progress of a step of an action that waits for the cluster, such as
+// waiting for a ClusterExtension to be installed. Every started step is followed by an event
+// with the same Kind, Name and Message that reports whether it succeeded or failed.
+type Event struct {
+	Phase Phase
+	Kind  string
+	Name  string
+	// Message describes the step, for example `waiting for ClusterCatalog "foo" to become healthy`.
+	Message string
+	// Err is the reason a step failed.
+	Err error
+}
+
+// ProgressReporter receives the progress events of an action. Implementations for terminals,
+// plain text and JSON lines are in the progress package.
+type ProgressReporter interface {
+	Report(Event)
+}
+
+// ProgressFunc adapts a function to a ProgressReporter.
+type ProgressFunc func(Event)
+
+func (f ProgressFunc) Report(e Event) {
+	f(e)
+}
+
+// nopProgress is the default ProgressReporter of every action.
+var nopProgress = ProgressFunc(func(Event) {})
+
+// track reports fn as a step to p, started before fn is called and finished when it returns.
+func track(p ProgressReporter, kind, name, message string, fn func() error) error {
+	if p == nil {
+		p = nopProgress
+	}
+	e := Event{Phase: PhaseStarted, Kind: kind, Name: name, Message: message}
+	p.Report(e)
+	err := fn()
+	if err != nil {
+		e.Phase, e.Err = PhaseFailed, err
+	} else {
+		e.Phase = PhaseSucceeded
+	}
+	p.Report(e)
+	return err
+}
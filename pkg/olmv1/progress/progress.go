@@ -0,0 +1,106 @@
+// Package progress provides olmv1.ProgressReporter implementations that write to terminals,
+// plain text logs and JSON lines streams.
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/briandowns/spinner"
+
+	"github.com/operator-framework/kubectl-operator/pkg/olmv1"
+)
+
+// Spinner shows a spinner next to the message of the step in progress. Finished steps are
+// erased, so it is only suitable for terminals; on other files it writes nothing.
+type Spinner struct {
+	f  *os.File
+	mu sync.Mutex
+	s  *spinner.Spinner
+}
+
+// NewSpinner returns a Spinner that writes to f.
+func NewSpinner(f *os.File) *Spinner {
+	return &Spinner{f: f}
+}
+
+func (p *Spinner) Report(e olmv1.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.s != nil {
+		p.s.Stop()
+		p.s = nil
+	}
+	if e.Phase != olmv1.PhaseStarted {
+		return
+	}
+	p.s = spinner.New(spinner.CharSets[1], 100*time.Millisecond, spinner.WithWriterFile(p.f), spinner.WithSuffix(" "+e.Message+"..."))
+	p.s.Start()
+}
+
+// Plain writes a line when a step starts and when it finishes.
+type Plain struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewPlain returns a Plain reporter that writes to w.
+func NewPlain(w io.Writer) *Plain {
+	return &Plain{w: w}
+}
+
+func (p *Plain) Report(e olmv1.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e.Phase {
+	case olmv1.PhaseStarted:
+		_, _ = fmt.Fprintf(p.w, "%s...\n", e.Message)
+	case olmv1.PhaseSucceeded:
+		_, _ = fmt.Fprintf(p.w, "%s: done\n", e.Message)
+	case olmv1.PhaseFailed:
+		_, _ = fmt.Fprintf(p.w, "%s: failed: %v\n", e.Message, e.Err)
+	}
+}
+
+// JSON writes every event as a single line JSON object.
+type JSON struct {
+	enc *json.Encoder
+	mu  sync.Mutex
+}
+
+// NewJSON returns a JSON reporter that writes to w.
+func NewJSON(w io.Writer) *JSON {
+	return &JSON{enc: json.NewEncoder(w)}
+}
+
+type jsonEvent struct {
+	Time    time.Time   `json:"time"`
+	Phase   olmv1.Phase `json:"phase"`
+	Kind    string      `json:"kind,omitempty"`
+	Name    string      `json:"name,omitempty"`
+	Message string      `json:"message"`
+	Error   string      `json:"error,omitempty"`
+}
+
+func (p *JSON) Report(e olmv1.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := jsonEvent{
+		Time:    time.Now().UTC(),
+		Phase:   e.Phase,
+		Kind:    e.Kind,
+		Name:    e.Name,
+		Message: e.Message,
+	}
+	if e.Err != nil {
+		out.Error = e.Err.Error()
+	}
+	_ = p.enc.Encode(out)
+}